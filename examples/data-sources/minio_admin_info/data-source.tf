@@ -0,0 +1,20 @@
+data "minio_admin_info" "cluster" {}
+
+locals {
+  # development builds report no release date and are assumed recent
+  tiering_supported = !can(timecmp(data.minio_admin_info.cluster.version, "2021-04-22T15:44:28Z")) || timecmp(data.minio_admin_info.cluster.version, "2021-04-22T15:44:28Z") >= 0
+}
+
+resource "minio_ilm_tier" "warm" {
+  count = local.tiering_supported ? 1 : 0
+
+  name     = "WARM"
+  type     = "minio"
+  bucket   = "warm"
+  endpoint = "https://warm.example.com"
+
+  minio_config {
+    access_key = var.warm_access_key
+    secret_key = var.warm_secret_key
+  }
+}
@@ -0,0 +1,8 @@
+data "minio_iam_policy" "readwrite" {
+  name = "readwrite"
+}
+
+resource "minio_iam_policy" "readwrite_logs" {
+  name   = "readwrite-logs"
+  policy = replace(data.minio_iam_policy.readwrite.policy, "arn:aws:s3:::*", "arn:aws:s3:::logs*")
+}
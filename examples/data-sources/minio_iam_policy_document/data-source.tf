@@ -36,6 +36,15 @@ data "minio_iam_policy_document" "example" {
       "arn:aws:s3:::state-terraform-s3/*",
     ]
   }
+
+  # Equivalent to resources = ["arn:aws:s3:::state-terraform-s3/home/*"]
+  statement {
+    actions = [
+      "s3:GetObject",
+    ]
+    bucket         = "state-terraform-s3"
+    object_pattern = "home/*"
+  }
 }
 
 resource "minio_iam_policy" "test_policy" {
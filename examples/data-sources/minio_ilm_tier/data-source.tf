@@ -0,0 +1,7 @@
+data "minio_ilm_tier" "warm" {
+  name = "WARM"
+}
+
+output "warm_tier_size" {
+  value = data.minio_ilm_tier.warm.total_size
+}
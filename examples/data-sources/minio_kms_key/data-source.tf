@@ -0,0 +1,16 @@
+data "minio_kms_key" "shared" {
+  key_id = "shared-key"
+}
+
+resource "minio_s3_bucket_server_side_encryption" "encryption" {
+  bucket          = "my-bucket"
+  encryption_type = "aws:kms"
+  kms_key_id      = data.minio_kms_key.shared.key_id
+
+  lifecycle {
+    precondition {
+      condition     = data.minio_kms_key.shared.encryption_error == "" && data.minio_kms_key.shared.decryption_error == ""
+      error_message = "KMS key shared-key is not usable."
+    }
+  }
+}
@@ -0,0 +1,10 @@
+data "minio_kms_keys" "team" {
+  prefix = "team-"
+}
+
+check "kms_keys" {
+  assert {
+    condition     = data.minio_kms_keys.team.key_ids == tolist(["team-a-key", "team-b-key"])
+    error_message = "Unexpected KMS keys: ${join(", ", data.minio_kms_keys.team.key_ids)}."
+  }
+}
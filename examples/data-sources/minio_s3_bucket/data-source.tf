@@ -0,0 +1,11 @@
+data "minio_s3_bucket" "archive" {
+  bucket = "archive"
+}
+
+# only hold the report when the bucket supports it
+resource "minio_s3_object_legal_hold" "report" {
+  count = data.minio_s3_bucket.archive.object_locking ? 1 : 0
+
+  bucket = data.minio_s3_bucket.archive.bucket
+  key    = "reports/2023.pdf"
+}
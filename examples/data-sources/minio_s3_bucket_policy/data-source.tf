@@ -0,0 +1,7 @@
+data "minio_s3_bucket_policy" "example" {
+  bucket = "my-bucket"
+}
+
+output "has_policy" {
+  value = data.minio_s3_bucket_policy.example.policy != ""
+}
@@ -0,0 +1,15 @@
+resource "minio_admin_config" "compression" {
+  key = "compression"
+  value = {
+    enable     = "on"
+    extensions = ".txt,.log,.csv,.json"
+  }
+}
+
+# MinIO has no per-bucket CORS configuration, the origins allowed to reach the server from a browser are set server-wide
+resource "minio_admin_config" "cors" {
+  key = "api"
+  value = {
+    cors_allow_origin = "https://app.example.com,https://admin.example.com"
+  }
+}
@@ -0,0 +1,10 @@
+# WARNING: decommissioning drains all objects off the pool. Once complete, the
+# pool must be removed from the server command line and cannot be reused.
+resource "minio_admin_pool_decommission" "old_pool" {
+  pool    = "http://minio{1...4}/data{1...4}"
+  confirm = true
+
+  triggers = {
+    ticket = "OPS-1234"
+  }
+}
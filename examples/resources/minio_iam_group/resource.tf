@@ -2,6 +2,17 @@ resource "minio_iam_group" "developer" {
   name = "developer"
 }
 
+resource "minio_iam_user" "alice" {
+  name = "alice"
+}
+
+resource "minio_iam_group" "readers" {
+  name    = "readers"
+  members = [minio_iam_user.alice.name]
+  policy  = "readonly"
+  status  = "enabled"
+}
+
 output "minio_user_group" {
   value = minio_iam_group.developer.group_name
 }
\ No newline at end of file
@@ -0,0 +1,29 @@
+resource "minio_iam_policy" "test_policy" {
+  name   = "state-terraform-s3"
+  policy = <<EOF
+{
+  "Version":"2012-10-17",
+  "Statement": [
+    {
+      "Sid":"ListAllBucket",
+      "Effect": "Allow",
+      "Action": ["s3:PutObject"],
+      "Principal":"*",
+      "Resource": "arn:aws:s3:::state-terraform-s3/*"
+    }
+  ]
+}
+EOF
+}
+
+resource "minio_iam_ldap_policy_attachment" "developer" {
+  user_dn     = "CN=My User,OU=Unit,DC=example,DC=com"
+  policy_name = minio_iam_policy.test_policy.id
+}
+
+# Example attaching the policy to an LDAP group instead of an LDAP user
+
+resource "minio_iam_ldap_policy_attachment" "developers" {
+  group_dn    = "CN=Developers,OU=Unit,DC=example,DC=com"
+  policy_name = minio_iam_policy.test_policy.id
+}
@@ -9,4 +9,14 @@ resource "minio_ilm_policy" "bucket-lifecycle-rules" {
     id         = "expire-7d"
     expiration = "7d"
   }
+
+  rule {
+    id         = "expire-large-temp-objects"
+    expiration = "1d"
+
+    rule_filter {
+      prefix                   = "temp/"
+      object_size_greater_than = 1048576
+    }
+  }
 }
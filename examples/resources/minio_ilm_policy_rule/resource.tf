@@ -0,0 +1,18 @@
+resource "minio_s3_bucket" "bucket" {
+  bucket = "bucket"
+}
+
+resource "minio_ilm_policy_rule" "expire" {
+  for_each = {
+    logs = "30d"
+    temp = "1d"
+  }
+
+  bucket     = minio_s3_bucket.bucket.bucket
+  rule_id    = "expire-${each.key}"
+  expiration = each.value
+
+  rule_filter {
+    prefix = "${each.key}/"
+  }
+}
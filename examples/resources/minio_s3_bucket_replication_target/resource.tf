@@ -0,0 +1,53 @@
+resource "minio_s3_bucket" "my_bucket_in_a" {
+  bucket = "my-bucket"
+}
+
+resource "minio_s3_bucket" "my_bucket_in_b" {
+  provider = minio.deployment_b
+  bucket   = "my-bucket"
+}
+
+resource "minio_s3_bucket_versioning" "my_bucket_in_a" {
+  bucket = minio_s3_bucket.my_bucket_in_a.bucket
+
+  versioning_configuration {
+    status = "Enabled"
+  }
+}
+
+resource "minio_s3_bucket_versioning" "my_bucket_in_b" {
+  provider = minio.deployment_b
+  bucket   = minio_s3_bucket.my_bucket_in_b.bucket
+
+  versioning_configuration {
+    status = "Enabled"
+  }
+}
+
+resource "minio_s3_bucket_replication_target" "my_bucket_in_b" {
+  bucket        = minio_s3_bucket.my_bucket_in_a.bucket
+  target_bucket = minio_s3_bucket.my_bucket_in_b.bucket
+  host          = var.minio_server_b
+  region        = "eu-west-1"
+  access_key    = minio_iam_service_account.replication_in_b.access_key
+  secret_key    = minio_iam_service_account.replication_in_b.secret_key
+
+  bandwidth_limit     = "100M"
+  health_check_period = "1m"
+
+  depends_on = [
+    minio_s3_bucket_versioning.my_bucket_in_a,
+    minio_s3_bucket_versioning.my_bucket_in_b,
+  ]
+}
+
+resource "minio_s3_bucket_replication" "my_bucket_in_a" {
+  bucket = minio_s3_bucket.my_bucket_in_a.bucket
+
+  rule {
+    arn                         = minio_s3_bucket_replication_target.my_bucket_in_b.arn
+    delete_replication          = true
+    delete_marker_replication   = true
+    existing_object_replication = true
+  }
+}
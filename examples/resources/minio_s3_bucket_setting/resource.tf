@@ -0,0 +1,10 @@
+resource "minio_s3_bucket" "audit_logs" {
+  bucket         = "audit-logs"
+  object_locking = true
+}
+
+resource "minio_s3_bucket_setting" "audit_logs_retention" {
+  bucket = minio_s3_bucket.audit_logs.bucket
+  name   = "object_lock_default_retention"
+  value  = "GOVERNANCE 30d"
+}
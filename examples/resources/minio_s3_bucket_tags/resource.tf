@@ -0,0 +1,12 @@
+resource "minio_s3_bucket" "state_terraform_s3" {
+  bucket = "state-terraform-s3"
+}
+
+resource "minio_s3_bucket_tags" "state_terraform_s3" {
+  bucket = minio_s3_bucket.state_terraform_s3.bucket
+
+  tags = {
+    team        = "platform"
+    cost-center = "1234"
+  }
+}
@@ -0,0 +1,16 @@
+resource "minio_s3_bucket" "evidence" {
+  bucket         = "evidence"
+  object_locking = true
+}
+
+resource "minio_s3_object" "report" {
+  bucket_name = minio_s3_bucket.evidence.bucket
+  object_name = "case-42/report.pdf"
+  source      = "report.pdf"
+}
+
+resource "minio_s3_object_legal_hold" "report" {
+  bucket = minio_s3_bucket.evidence.bucket
+  key    = minio_s3_object.report.object_name
+  status = "ON"
+}
@@ -0,0 +1,17 @@
+resource "minio_s3_bucket" "evidence" {
+  bucket         = "evidence"
+  object_locking = true
+}
+
+resource "minio_s3_object" "report" {
+  bucket_name = minio_s3_bucket.evidence.bucket
+  object_name = "case-42/report.pdf"
+  source      = "report.pdf"
+}
+
+resource "minio_s3_object_retention" "report" {
+  bucket            = minio_s3_bucket.evidence.bucket
+  key               = minio_s3_object.report.object_name
+  mode              = "GOVERNANCE"
+  retain_until_date = "2030-01-01T00:00:00Z"
+}
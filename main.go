@@ -7,6 +7,9 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
 )
 
+// version is set by the release build flags
+var version = "dev"
+
 func main() {
 	var debugMode bool
 
@@ -14,7 +17,7 @@ func main() {
 	flag.Parse()
 
 	plugin.Serve(&plugin.ServeOpts{
-		ProviderFunc: minio.Provider,
+		ProviderFunc: minio.New(version),
 		Debug:        debugMode,
 		ProviderAddr: "registry.terraform.io/aminueza/minio",
 	})
@@ -0,0 +1,197 @@
+package minio
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testAccServerImage is the MinIO release the acceptance tests run against, matching docker-compose.yml
+const testAccServerImage = "minio/minio:RELEASE.2023-08-31T15-31-16Z"
+
+// testAccServerKMSKey is the built-in KMS key of the started servers, so SSE-KMS can be tested without KES
+const testAccServerKMSKey = "minio-test-key:IyqsU3kMFloCNup4BsZtf/rmfHVcTgznO2F25CkEH1g="
+
+// testAccServer describes how the provider with the given environment prefix reaches its MinIO server
+type testAccServer struct {
+	prefix   string
+	password string
+}
+
+// testAccServers lists the servers of the test providers, with the passwords used in docker-compose.yml
+var testAccServers = []testAccServer{
+	{prefix: "", password: "minio123"},
+	{prefix: "SECOND_", password: "minio321"},
+	{prefix: "THIRD_", password: "minio456"},
+	{prefix: "FOURTH_", password: "minio654"},
+}
+
+// TestMain provides the MinIO servers of the acceptance tests before running them. For each provider, in order:
+//   - <PREFIX>MINIO_ENDPOINT and the other provider variables are used as is when set
+//   - <PREFIX>MINIO_TEST_ENDPOINT points at an existing server, with <PREFIX>MINIO_TEST_USER and
+//     <PREFIX>MINIO_TEST_PASSWORD as root credentials
+//   - otherwise a MinIO container is started with docker and removed once the tests are done
+//
+// Nothing is started unless TF_ACC is set.
+func TestMain(m *testing.M) {
+	if os.Getenv("TF_ACC") == "" {
+		os.Exit(m.Run())
+	}
+
+	var containers []string
+	stop := func() {
+		for _, id := range containers {
+			if out, err := exec.Command("docker", "rm", "-f", id).CombinedOutput(); err != nil {
+				fmt.Fprintf(os.Stderr, "unable to remove MinIO container %s: %s: %s\n", id, err, out)
+			}
+		}
+	}
+
+	for _, server := range testAccServers {
+		container, err := server.provide()
+		if container != "" {
+			containers = append(containers, container)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "unable to provide the %sMINIO server of the acceptance tests: %s\n", server.prefix, err)
+			stop()
+			os.Exit(1)
+		}
+	}
+
+	code := m.Run()
+	stop()
+	os.Exit(code)
+}
+
+// provide sets the provider variables of the server, starting a container when no server is configured.
+// It returns the ID of the started container, if any.
+func (s testAccServer) provide() (string, error) {
+	if _, ok := os.LookupEnv(s.prefix + "MINIO_ENDPOINT"); ok {
+		return "", nil
+	}
+
+	user := testAccEnvDefault(s.prefix+"MINIO_TEST_USER", "minio")
+	password := testAccEnvDefault(s.prefix+"MINIO_TEST_PASSWORD", s.password)
+
+	var container string
+	endpoint := os.Getenv(s.prefix + "MINIO_TEST_ENDPOINT")
+	if endpoint == "" {
+		var err error
+		container, endpoint, err = s.startContainer(user, password)
+		if err != nil {
+			return container, err
+		}
+	}
+
+	for key, value := range map[string]string{
+		"MINIO_ENDPOINT":     endpoint,
+		"MINIO_USER":         user,
+		"MINIO_PASSWORD":     password,
+		"MINIO_ENABLE_HTTPS": testAccEnvDefault(s.prefix+"MINIO_TEST_ENABLE_HTTPS", "false"),
+	} {
+		if err := os.Setenv(s.prefix+key, value); err != nil {
+			return container, err
+		}
+	}
+
+	scheme := "http"
+	if os.Getenv(s.prefix+"MINIO_ENABLE_HTTPS") == "true" {
+		scheme = "https"
+	}
+	return container, testAccWaitForServer(fmt.Sprintf("%s://%s/minio/health/live", scheme, endpoint), 2*time.Minute)
+}
+
+// startContainer runs MinIO in docker and returns the container ID and the endpoint it is reachable at.
+// The endpoint uses the docker bridge gateway so the servers also reach each other for replication tests,
+// MINIO_TEST_HOST overrides it. Variables named MINIO_TEST_SERVER_<NAME> are passed to the server as MINIO_<NAME>.
+func (s testAccServer) startContainer(user, password string) (string, string, error) {
+	args := []string{"run", "--detach", "--publish", "9000",
+		"--env", "MINIO_ROOT_USER=" + user,
+		"--env", "MINIO_ROOT_PASSWORD=" + password,
+		"--env", "MINIO_CI_CD=1",
+		"--env", "MINIO_KMS_SECRET_KEY=" + testAccServerKMSKey,
+		"--env", "MINIO_NOTIFY_WEBHOOK_ENABLE_primary=on",
+		"--env", "MINIO_NOTIFY_WEBHOOK_ENDPOINT_primary=https://webhook.example.com",
+	}
+	for _, env := range os.Environ() {
+		if name, ok := strings.CutPrefix(env, "MINIO_TEST_SERVER_"); ok {
+			args = append(args, "--env", "MINIO_"+name)
+		}
+	}
+	args = append(args, testAccEnvDefault("MINIO_TEST_IMAGE", testAccServerImage), "server", "/data{0...3}")
+
+	out, err := exec.Command("docker", args...).Output()
+	if err != nil {
+		return "", "", fmt.Errorf("starting MinIO container: %w", testAccCommandError(err))
+	}
+	container := strings.TrimSpace(string(out))
+
+	out, err = exec.Command("docker", "port", container, "9000/tcp").Output()
+	if err != nil {
+		return container, "", fmt.Errorf("getting the port of MinIO container %s: %w", container, testAccCommandError(err))
+	}
+	// the first line is the IPv4 binding, e.g. 0.0.0.0:49153
+	binding := strings.SplitN(strings.TrimSpace(string(out)), "\n", 2)[0]
+	port := binding[strings.LastIndex(binding, ":")+1:]
+
+	host := os.Getenv("MINIO_TEST_HOST")
+	if host == "" {
+		host = "127.0.0.1"
+		out, err := exec.Command("docker", "network", "inspect", "bridge", "--format", "{{(index .IPAM.Config 0).Gateway}}").Output()
+		if gateway := strings.TrimSpace(string(out)); err == nil && gateway != "" {
+			host = gateway
+		}
+	}
+
+	return container, fmt.Sprintf("%s:%s", host, port), nil
+}
+
+// testAccWaitForServer polls the liveness endpoint of a server until it answers
+func testAccWaitForServer(url string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var lastErr error
+	for {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return err
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err == nil {
+			_ = resp.Body.Close()
+			if resp.StatusCode == http.StatusOK {
+				return nil
+			}
+			err = fmt.Errorf("unexpected status %s", resp.Status)
+		}
+		lastErr = err
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("MinIO at %s is not live after %s: %w", url, timeout, lastErr)
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+func testAccEnvDefault(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
+
+// testAccCommandError adds the output of a failed docker command to its error
+func testAccCommandError(err error) error {
+	if exitErr, ok := err.(*exec.ExitError); ok && len(exitErr.Stderr) > 0 {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(exitErr.Stderr)))
+	}
+	return err
+}
@@ -0,0 +1,123 @@
+package minio
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/minio/madmin-go/v3"
+)
+
+// minioConfigKVGetter is the part of the admin client needed to read server config
+type minioConfigKVGetter interface {
+	GetConfigKV(ctx context.Context, key string) ([]byte, error)
+}
+
+// minioConfigEnvOverrides returns the keys of configKey ("subsys" or "subsys:target") whose
+// value is overridden by an environment variable on the server, mapped to the variable name.
+func minioConfigEnvOverrides(ctx context.Context, client minioConfigKVGetter, configKey string) (map[string]string, error) {
+	output, err := client.GetConfigKV(ctx, configKey)
+	if err != nil {
+		return nil, err
+	}
+
+	configs, err := madmin.ParseServerConfigOutput(string(output))
+	if err != nil {
+		return nil, err
+	}
+
+	subSystem, target, _ := strings.Cut(configKey, madmin.SubSystemSeparator)
+	overrides := map[string]string{}
+	for _, config := range configs {
+		if config.SubSystem != subSystem || config.Target != target {
+			continue
+		}
+		for _, kv := range config.KV {
+			if kv.EnvOverride == nil {
+				continue
+			}
+			// madmin only strips the target suffix when its case matches the upper-cased variable name
+			key := strings.TrimSuffix(kv.Key, madmin.EnvWordDelimiter+strings.ToLower(target))
+			overrides[key] = kv.EnvOverride.Name
+		}
+	}
+
+	return overrides, nil
+}
+
+// minioConfigEffectiveValues returns the values the server uses for every key of configKey, which are
+// the configured value, the default for keys that were never set, or the environment variable overriding them.
+func minioConfigEffectiveValues(ctx context.Context, client minioConfigKVGetter, configKey string) (map[string]string, error) {
+	output, err := client.GetConfigKV(ctx, configKey)
+	if err != nil {
+		return nil, err
+	}
+
+	configs, err := madmin.ParseServerConfigOutput(string(output))
+	if err != nil {
+		return nil, err
+	}
+
+	subSystem, target, _ := strings.Cut(configKey, madmin.SubSystemSeparator)
+	values := map[string]string{}
+	for _, config := range configs {
+		if config.SubSystem != subSystem || config.Target != target {
+			continue
+		}
+		for _, kv := range config.KV {
+			if kv.EnvOverride == nil {
+				values[kv.Key] = kv.Value
+			}
+		}
+		for _, kv := range config.KV {
+			if kv.EnvOverride != nil {
+				key := strings.TrimSuffix(kv.Key, madmin.EnvWordDelimiter+strings.ToLower(target))
+				values[key] = kv.EnvOverride.Value
+			}
+		}
+	}
+
+	return values, nil
+}
+
+// minioConfigManagedValues keeps the effective values of the keys set in the configuration, so that keys
+// left to their defaults never show up as a diff. Keys the server does not report, such as the enable key of
+// compression, keep their configured value.
+func minioConfigManagedValues(managed map[string]interface{}, effective map[string]string) map[string]string {
+	values := map[string]string{}
+	for key, configured := range managed {
+		if value, ok := effective[key]; ok {
+			values[key] = value
+		} else {
+			values[key] = configured.(string)
+		}
+	}
+
+	return values
+}
+
+// minioConfigEnvOverrideWarnings warns about every key of values that the server ignores
+// because it is set through an environment variable.
+func minioConfigEnvOverrideWarnings(configKey string, values map[string]string, overrides map[string]string) diag.Diagnostics {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		if _, ok := overrides[key]; ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	var diags diag.Diagnostics
+	for _, key := range keys {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("config key %s %s is overridden by an environment variable", configKey, key),
+			Detail: fmt.Sprintf("The server sets %s from %s, so the value %q will not take effect until the variable is removed.",
+				key, overrides[key], values[key]),
+		})
+	}
+
+	return diags
+}
@@ -0,0 +1,94 @@
+package minio
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type fakeConfigKVGetter struct {
+	output string
+}
+
+func (f *fakeConfigKVGetter) GetConfigKV(ctx context.Context, key string) ([]byte, error) {
+	return []byte(f.output), nil
+}
+
+func TestMinioConfigEnvOverrides(t *testing.T) {
+	client := &fakeConfigKVGetter{
+		output: "# MINIO_API_REQUESTS_MAX=1000\napi requests_max=0 requests_deadline=10s cors_allow_origin=*\n",
+	}
+
+	overrides, err := minioConfigEnvOverrides(context.Background(), client, "api")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(overrides) != 1 || overrides["requests_max"] != "MINIO_API_REQUESTS_MAX" {
+		t.Fatalf("expected requests_max to be overridden by MINIO_API_REQUESTS_MAX, got %v", overrides)
+	}
+
+	diags := minioConfigEnvOverrideWarnings("api", map[string]string{
+		"requests_max":      "500",
+		"requests_deadline": "20s",
+	}, overrides)
+	if len(diags) != 1 || diags.HasError() {
+		t.Fatalf("expected a single warning, got %v", diags)
+	}
+	if !strings.Contains(diags[0].Summary, "requests_max") || !strings.Contains(diags[0].Detail, "MINIO_API_REQUESTS_MAX") {
+		t.Fatalf("warning should name the key and variable: %+v", diags[0])
+	}
+}
+
+func TestMinioConfigEnvOverrides_target(t *testing.T) {
+	client := &fakeConfigKVGetter{
+		output: "# MINIO_NOTIFY_WEBHOOK_ENDPOINT_PRIMARY=http://hook\nnotify_webhook:primary endpoint=http://other queue_limit=0\nnotify_webhook:secondary endpoint=http://other\n",
+	}
+
+	overrides, err := minioConfigEnvOverrides(context.Background(), client, "notify_webhook:secondary")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(overrides) != 0 {
+		t.Fatalf("secondary target has no overrides, got %v", overrides)
+	}
+
+	overrides, err = minioConfigEnvOverrides(context.Background(), client, "notify_webhook:primary")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if overrides["endpoint"] != "MINIO_NOTIFY_WEBHOOK_ENDPOINT_PRIMARY" {
+		t.Fatalf("expected endpoint override, got %v", overrides)
+	}
+}
+
+func TestMinioConfigEffectiveValues(t *testing.T) {
+	// requests_deadline was never set and is reported with its default value
+	client := &fakeConfigKVGetter{
+		output: "# MINIO_API_REQUESTS_MAX=1000\napi requests_max=0 requests_deadline=10s\n",
+	}
+
+	effective, err := minioConfigEffectiveValues(context.Background(), client, "api")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if effective["requests_deadline"] != "10s" {
+		t.Fatalf("unset key should report its default, got %v", effective)
+	}
+	if effective["requests_max"] != "1000" {
+		t.Fatalf("overridden key should report the environment value, got %v", effective)
+	}
+
+	managed := map[string]interface{}{"requests_max": "1000"}
+	values := minioConfigManagedValues(managed, effective)
+	// the defaulted requests_deadline is not read back, so it cannot produce a diff
+	if !reflect.DeepEqual(values, map[string]string{"requests_max": "1000"}) {
+		t.Fatalf("only managed keys should be read back, got %v", values)
+	}
+
+	// keys missing from the server output cannot be compared and keep their configured value
+	values = minioConfigManagedValues(map[string]interface{}{"requests_max": "1000", "enable": "on"}, effective)
+	if !reflect.DeepEqual(values, map[string]string{"requests_max": "1000", "enable": "on"}) {
+		t.Fatalf("unreported keys should keep their configured value, got %v", values)
+	}
+}
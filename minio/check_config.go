@@ -1,18 +1,29 @@
 package minio
 
 import (
+	"fmt"
+	"time"
+
+	"github.com/dustin/go-humanize"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/minio/madmin-go/v3"
+	"github.com/minio/minio-go/v7"
 )
 
 // BucketConfig creates a new config for minio buckets
 func BucketConfig(d *schema.ResourceData, meta interface{}) *S3MinioBucket {
 	m := meta.(*S3MinioClient)
 
+	region := m.S3Region
+	if v, ok := d.GetOk("region"); ok {
+		region = v.(string)
+	}
+
 	return &S3MinioBucket{
 		MinioClient:          m.S3Client,
 		MinioAdmin:           m.S3Admin,
-		MinioRegion:          m.S3Region,
+		MinioRegion:          region,
 		MinioAccess:          m.S3UserAccess,
 		MinioBucket:          d.Get("bucket").(string),
 		MinioBucketPrefix:    d.Get("bucket_prefix").(string),
@@ -30,6 +41,7 @@ func BucketPolicyConfig(d *schema.ResourceData, meta interface{}) *S3MinioBucket
 		MinioClient:       m.S3Client,
 		MinioBucket:       d.Get("bucket").(string),
 		MinioBucketPolicy: d.Get("policy").(string),
+		MinioBucketACL:    d.Get("acl").(string),
 	}
 }
 
@@ -60,6 +72,47 @@ func BucketReplicationConfig(d *schema.ResourceData, meta interface{}) (*S3Minio
 	}, diags
 }
 
+// BucketReplicationTargetConfig creates config for managing a minio bucket replication target
+func BucketReplicationTargetConfig(d *schema.ResourceData, meta interface{}) (*S3MinioBucketReplicationTarget, error) {
+	m := meta.(*S3MinioClient)
+
+	bandwidth, err := humanize.ParseBytes(d.Get("bandwidth_limit").(string))
+	if err != nil {
+		return nil, fmt.Errorf("invalid bandwidth_limit: %w", err)
+	}
+	healthCheckPeriod, err := time.ParseDuration(d.Get("health_check_period").(string))
+	if err != nil {
+		return nil, fmt.Errorf("invalid health_check_period: %w", err)
+	}
+
+	pathStyle := S3PathSyleAuto
+	switch d.Get("path_style").(string) {
+	case "on":
+		pathStyle = S3PathSyleOn
+	case "off":
+		pathStyle = S3PathSyleOff
+	}
+
+	return &S3MinioBucketReplicationTarget{
+		MinioAdmin:  m.S3Admin,
+		MinioBucket: d.Get("bucket").(string),
+		Target: S3MinioBucketReplicationRuleTarget{
+			Bucket:            d.Get("target_bucket").(string),
+			Host:              d.Get("host").(string),
+			Secure:            d.Get("secure").(bool),
+			Path:              d.Get("path").(string),
+			PathStyle:         pathStyle,
+			Syncronous:        d.Get("synchronous").(bool),
+			DisableProxy:      d.Get("disable_proxy").(bool),
+			HealthCheckPeriod: healthCheckPeriod,
+			BandwidthLimit:    int64(bandwidth),
+			Region:            d.Get("region").(string),
+			AccessKey:         d.Get("access_key").(string),
+			SecretKey:         d.Get("secret_key").(string),
+		},
+	}, nil
+}
+
 // BucketNotificationConfig creates config for managing minio bucket notifications
 func BucketNotificationConfig(d *schema.ResourceData, meta interface{}) *S3MinioBucketNotification {
 	m := meta.(*S3MinioClient)
@@ -85,6 +138,17 @@ func BucketServerSideEncryptionConfig(d *schema.ResourceData, meta interface{})
 	}
 }
 
+// BucketTagsConfig creates bucket tags config
+func BucketTagsConfig(d *schema.ResourceData, meta interface{}) *S3MinioBucketTags {
+	m := meta.(*S3MinioClient)
+
+	return &S3MinioBucketTags{
+		MinioClient: m.S3Client,
+		MinioBucket: d.Get("bucket").(string),
+		Tags:        mergeDefaultTags(m.DefaultTags, d.Get("tags").(map[string]interface{})),
+	}
+}
+
 // NewConfig creates a new config for minio
 func NewConfig(d *schema.ResourceData) *S3MinioConfig {
 	user := d.Get("minio_user").(string)
@@ -97,18 +161,39 @@ func NewConfig(d *schema.ResourceData) *S3MinioConfig {
 		password = d.Get("minio_secret_key").(string)
 	}
 
+	// MinIO deployments rarely have the wildcard DNS record virtual-hosted buckets need
+	bucketLookup := minio.BucketLookupPath
+	if !d.Get("minio_use_path_style").(bool) {
+		bucketLookup = minio.BucketLookupDNS
+	}
+
+	defaultTags := map[string]string{}
+	for key, value := range d.Get("default_tags").(map[string]interface{}) {
+		defaultTags[key] = value.(string)
+	}
+
 	return &S3MinioConfig{
-		S3HostPort:      d.Get("minio_server").(string),
-		S3Region:        d.Get("minio_region").(string),
-		S3UserAccess:    user,
-		S3UserSecret:    password,
-		S3SessionToken:  d.Get("minio_session_token").(string),
-		S3APISignature:  d.Get("minio_api_version").(string),
-		S3SSL:           d.Get("minio_ssl").(bool),
-		S3SSLCACertFile: d.Get("minio_cacert_file").(string),
-		S3SSLCertFile:   d.Get("minio_cert_file").(string),
-		S3SSLKeyFile:    d.Get("minio_key_file").(string),
-		S3SSLSkipVerify: d.Get("minio_insecure").(bool),
+		S3HostPort:            d.Get("minio_server").(string),
+		S3Region:              d.Get("minio_region").(string),
+		S3UserAccess:          user,
+		S3UserSecret:          password,
+		S3SessionToken:        d.Get("minio_session_token").(string),
+		SharedCredentialsFile: d.Get("shared_credentials_file").(string),
+		Profile:               d.Get("profile").(string),
+		S3APISignature:        d.Get("minio_api_version").(string),
+		S3SSL:                 d.Get("minio_ssl").(bool),
+		S3SSLCACertFile:       d.Get("minio_cacert_file").(string),
+		S3SSLCACertPEM:        d.Get("minio_cacert_pem").(string),
+		S3SSLCertFile:         d.Get("minio_cert_file").(string),
+		S3SSLKeyFile:          d.Get("minio_key_file").(string),
+		S3SSLSkipVerify:       d.Get("minio_insecure").(bool),
+		S3BucketLookup:        bucketLookup,
+		LifecycleCache:        d.Get("minio_lifecycle_cache").(bool),
+		VerifyKMS:             d.Get("minio_verify_kms").(bool),
+		DefaultTags:           defaultTags,
+		ProviderVersion:       providerDevVersion,
+		UserAgentSuffix:       d.Get("minio_user_agent_suffix").(string),
+		MaxConcurrentRequests: d.Get("max_concurrent_requests").(int),
 	}
 }
 
@@ -117,12 +202,13 @@ func ServiceAccountConfig(d *schema.ResourceData, meta interface{}) *S3MinioServ
 	m := meta.(*S3MinioClient)
 
 	return &S3MinioServiceAccountConfig{
-		MinioAdmin:       m.S3Admin,
-		MinioAccessKey:   d.Get("access_key").(string),
-		MinioTargetUser:  d.Get("target_user").(string),
-		MinioDisableUser: d.Get("disable_user").(bool),
-		MinioUpdateKey:   d.Get("update_secret").(bool),
-		MinioSAPolicy:    d.Get("policy").(string),
+		MinioAdmin:        m.S3Admin,
+		MinioAccessKey:    d.Get("access_key").(string),
+		MinioTargetUser:   d.Get("target_user").(string),
+		MinioDisableUser:  d.Get("disable_user").(bool),
+		MinioUpdateKey:    d.Get("update_secret").(bool),
+		MinioSAPolicy:     d.Get("policy").(string),
+		MinioSAPolicyName: d.Get("policy_name").(string),
 	}
 }
 
@@ -135,6 +221,7 @@ func IAMUserConfig(d *schema.ResourceData, meta interface{}) *S3MinioIAMUserConf
 		MinioIAMName:      d.Get("name").(string),
 		MinioSecret:       d.Get("secret").(string),
 		MinioDisableUser:  d.Get("disable_user").(bool),
+		MinioStatus:       madmin.AccountStatus(d.Get("status").(string)),
 		MinioUpdateKey:    d.Get("update_secret").(bool),
 		MinioForceDestroy: d.Get("force_destroy").(bool),
 	}
@@ -147,7 +234,8 @@ func IAMGroupConfig(d *schema.ResourceData, meta interface{}) *S3MinioIAMGroupCo
 	return &S3MinioIAMGroupConfig{
 		MinioAdmin:        m.S3Admin,
 		MinioIAMName:      d.Get("name").(string),
-		MinioDisableGroup: d.Get("disable_group").(bool),
+		MinioStatus:       madmin.GroupStatus(d.Get("status").(string)),
+		MinioPolicy:       d.Get("policy").(string),
 		MinioForceDestroy: d.Get("force_destroy").(bool),
 	}
 }
@@ -205,7 +293,10 @@ func KMSKeyConfig(d *schema.ResourceData, meta interface{}) *S3MinioKMSKeyConfig
 	m := meta.(*S3MinioClient)
 
 	return &S3MinioKMSKeyConfig{
-		MinioAdmin:    m.S3Admin,
-		MinioKMSKeyID: d.Get("key_id").(string),
+		MinioAdmin:             m.S3Admin,
+		MinioClient:            m.S3Client,
+		MinioKMSKeyID:          d.Get("key_id").(string),
+		MinioKMSKeyMaterial:    d.Get("key_material").(string),
+		MinioDefaultForBuckets: getStringList(d.Get("default_for_buckets").(*schema.Set).List()),
 	}
 }
@@ -0,0 +1,131 @@
+package minio
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/minio/madmin-go/v3"
+)
+
+func dataSourceMinioAdminInfo() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceMinioAdminInfoRead,
+		Description: "Reads the metadata of the MinIO deployment, e.g. to only use features, like tiering, supported by the server version. " +
+			"Attributes older servers do not report are left empty",
+
+		Schema: map[string]*schema.Schema{
+			"deployment_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"region": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Release date of the server version (2023-08-31T15:31:16Z), development builds report e.g. `DEVELOPMENT.GOGET` instead. The oldest version when the servers run different versions, e.g. during an upgrade",
+			},
+			"mode": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "`distributed` when the deployment has several servers, `standalone` otherwise",
+			},
+			"backend_type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Storage backend of the deployment, `Erasure` or `FS`",
+			},
+			"server_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"pool_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"drive_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"online_drive_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"offline_drive_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceMinioAdminInfoRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*S3MinioClient).S3Admin
+
+	tflog.Debug(ctx, "Reading server info")
+
+	info, err := client.ServerInfo(ctx)
+	if err != nil {
+		return NewResourceError("error reading server info", "minio_admin_info", err)
+	}
+
+	id := info.DeploymentID
+	if id == "" {
+		id = "minio"
+	}
+	d.SetId(id)
+	for key, value := range flattenAdminInfo(info) {
+		if err := d.Set(key, value); err != nil {
+			return NewResourceError("error setting server info", key, err)
+		}
+	}
+
+	return nil
+}
+
+// flattenAdminInfo returns the attributes of minio_admin_info. Servers that are offline, or older servers, leave
+// out part of the information, which is then derived from what is reported or left empty.
+func flattenAdminInfo(info madmin.InfoMessage) map[string]interface{} {
+	version := ""
+	drives := 0
+	for _, server := range info.Servers {
+		// release dates sort as strings, development builds sort after them as the most recent
+		if server.Version != "" && (version == "" || server.Version < version) {
+			version = server.Version
+		}
+		drives += len(server.Disks)
+	}
+	if drives == 0 {
+		drives = info.Backend.OnlineDisks + info.Backend.OfflineDisks
+	}
+
+	mode := ""
+	switch {
+	case len(info.Servers) > 1:
+		mode = "distributed"
+	case len(info.Servers) == 1:
+		mode = "standalone"
+	}
+
+	pools := len(info.Pools)
+	if pools == 0 {
+		pools = len(info.Backend.TotalSets)
+	}
+
+	return map[string]interface{}{
+		"deployment_id":       info.DeploymentID,
+		"region":              info.Region,
+		"version":             version,
+		"mode":                mode,
+		"backend_type":        string(info.Backend.Type),
+		"server_count":        len(info.Servers),
+		"pool_count":          pools,
+		"drive_count":         drives,
+		"online_drive_count":  info.Backend.OnlineDisks,
+		"offline_drive_count": info.Backend.OfflineDisks,
+	}
+}
@@ -0,0 +1,108 @@
+package minio
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/minio/madmin-go/v3"
+)
+
+func TestFlattenAdminInfo(t *testing.T) {
+	cases := []struct {
+		name string
+		info madmin.InfoMessage
+		want map[string]interface{}
+	}{
+		{
+			name: "distributed",
+			info: madmin.InfoMessage{
+				DeploymentID: "deployment",
+				Region:       "eu-west-1",
+				Backend:      madmin.ErasureBackend{Type: "Erasure", OnlineDisks: 7, OfflineDisks: 1, TotalSets: []int{1}},
+				Servers: []madmin.ServerProperties{
+					{Version: "2023-08-31T15:31:16Z", Disks: make([]madmin.Disk, 4)},
+					{Version: "2023-07-21T21:12:44Z", Disks: make([]madmin.Disk, 4)},
+				},
+				Pools: map[int]map[int]madmin.ErasureSetInfo{0: {}, 1: {}},
+			},
+			want: map[string]interface{}{
+				"deployment_id":       "deployment",
+				"region":              "eu-west-1",
+				"version":             "2023-07-21T21:12:44Z",
+				"mode":                "distributed",
+				"backend_type":        "Erasure",
+				"server_count":        2,
+				"pool_count":          2,
+				"drive_count":         8,
+				"online_drive_count":  7,
+				"offline_drive_count": 1,
+			},
+		},
+		{
+			name: "older standalone server",
+			info: madmin.InfoMessage{
+				Backend: madmin.ErasureBackend{Type: "FS", OnlineDisks: 1, TotalSets: []int{1}},
+				Servers: []madmin.ServerProperties{{}},
+			},
+			want: map[string]interface{}{
+				"deployment_id":       "",
+				"region":              "",
+				"version":             "",
+				"mode":                "standalone",
+				"backend_type":        "FS",
+				"server_count":        1,
+				"pool_count":          1,
+				"drive_count":         1,
+				"online_drive_count":  1,
+				"offline_drive_count": 0,
+			},
+		},
+		{
+			name: "no information",
+			want: map[string]interface{}{
+				"deployment_id":       "",
+				"region":              "",
+				"version":             "",
+				"mode":                "",
+				"backend_type":        "",
+				"server_count":        0,
+				"pool_count":          0,
+				"drive_count":         0,
+				"online_drive_count":  0,
+				"offline_drive_count": 0,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := flattenAdminInfo(tc.info); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("flattenAdminInfo() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestAccMinioDataSourceAdminInfo_basic(t *testing.T) {
+	resourceName := "data.minio_admin_info.info"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "minio_admin_info" "info" {}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(resourceName, "deployment_id"),
+					// the servers started for the tests may be development builds, which report no release date
+					resource.TestCheckResourceAttrSet(resourceName, "version"),
+					resource.TestCheckResourceAttr(resourceName, "mode", "standalone"),
+					resource.TestCheckResourceAttr(resourceName, "server_count", "1"),
+					resource.TestMatchResourceAttr(resourceName, "drive_count", regexp.MustCompile(`^[1-9]\d*$`)),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,57 @@
+package minio
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceMinioIAMPolicy() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceMinioIAMPolicyRead,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"policy": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Policy document JSON",
+			},
+			"builtin": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the policy is one of the built-in policies of the server, which cannot be managed by `minio_iam_policy`",
+			},
+		},
+	}
+}
+
+func dataSourceMinioIAMPolicyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*S3MinioClient).S3Admin
+	name := d.Get("name").(string)
+
+	log.Printf("[DEBUG] Reading IAM Policy: %s", name)
+
+	output, err := client.InfoCannedPolicy(ctx, name)
+	if err != nil {
+		return NewResourceError("unable to read policy", name, err)
+	}
+
+	d.SetId(name)
+	if err := d.Set("policy", strings.TrimSpace(string(output))); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("builtin", minioBuiltinPolicies.Contains(name)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -55,6 +55,17 @@ func dataSourceMinioIAMPolicyDocument() *schema.Resource {
 						},
 						"actions":   stringSet,
 						"resources": stringSet,
+						"bucket": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringIsNotEmpty,
+							Description:  "Bucket the statement applies to, expanded into its ARN and added to `resources`",
+						},
+						"object_pattern": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Object key pattern within `bucket` (e.g. `*` or `logs/*`), expanded into the object ARN instead of the bucket ARN",
+						},
 						"principal": {
 							Type:         schema.TypeString,
 							Optional:     true,
@@ -147,8 +158,18 @@ func dataSourceMinioIAMPolicyDocumentRead(d *schema.ResourceData, meta interface
 				stmt.Actions = minioDecodePolicyStringList(actions)
 			}
 
-			if resources := cfgStmt["resources"].(*schema.Set).List(); len(resources) > 0 {
-				var err error
+			resources := cfgStmt["resources"].(*schema.Set).List()
+			bucketResources, err := dataSourceMinioIAMPolicyDocumentBucketResources(
+				cfgStmt["bucket"].(string), cfgStmt["object_pattern"].(string),
+			)
+			if err != nil {
+				return err
+			}
+			for _, bucketResource := range bucketResources {
+				resources = append(resources, bucketResource)
+			}
+
+			if len(resources) > 0 {
 				stmt.Resources, err = dataSourceMinioIAMPolicyDocumentReplaceVarsInList(
 					minioDecodePolicyStringList(resources), doc.Version,
 				)
@@ -223,6 +244,26 @@ func dataSourceMinioIAMPolicyDocumentReplaceVarsInList(in interface{}, version s
 	}
 }
 
+// dataSourceMinioIAMPolicyDocumentBucketResources expands a statement bucket and object pattern into S3 ARNs
+func dataSourceMinioIAMPolicyDocumentBucketResources(bucket, objectPattern string) ([]string, error) {
+	if bucket == "" {
+		if objectPattern != "" {
+			return nil, fmt.Errorf("object_pattern (%s) requires bucket to be set", objectPattern)
+		}
+		return nil, nil
+	}
+
+	if strings.HasPrefix(bucket, awsResourcePrefix) {
+		return nil, fmt.Errorf("bucket (%s) must be a bucket name, not an ARN", bucket)
+	}
+
+	if objectPattern == "" {
+		return []string{bucketArn(bucket)}, nil
+	}
+
+	return []string{fmt.Sprintf("%s/%s", bucketArn(bucket), strings.TrimPrefix(objectPattern, "/"))}, nil
+}
+
 func dataSourceMinioIAMPolicyDocumentMakeConditions(in []interface{}, version string) (interface{}, error) {
 	out := make(ConditionMap, len(in))
 	for _, itemI := range in {
@@ -1,6 +1,7 @@
 package minio
 
 import (
+	"reflect"
 	"regexp"
 	"testing"
 
@@ -175,6 +176,57 @@ func TestAccMinioDataSourceIAMPolicyDocument_Statement_Principal_Identifiers_Mul
 	})
 }
 
+func TestAccMinioDataSourceIAMPolicyDocument_bucketResources(t *testing.T) {
+	dataSourceName := "data.minio_iam_policy_document.test"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMinioIAMPolicyDocumentConfigBucketResources,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "json", testAccMinioIAMPolicyDocumentExpectedJSONBucketResources),
+				),
+			},
+			{
+				Config:      testAccMinioIAMPolicyDocumentConfigObjectPatternWithoutBucket,
+				ExpectError: regexp.MustCompile("object_pattern .* requires bucket to be set"),
+			},
+		},
+	})
+}
+
+func TestDataSourceMinioIAMPolicyDocumentBucketResources(t *testing.T) {
+	cases := []struct {
+		bucket        string
+		objectPattern string
+		expected      []string
+	}{
+		{"", "", nil},
+		{"foo", "", []string{"arn:aws:s3:::foo"}},
+		{"foo", "*", []string{"arn:aws:s3:::foo/*"}},
+		{"foo", "/home/&{aws:username}/*", []string{"arn:aws:s3:::foo/home/&{aws:username}/*"}},
+	}
+
+	for _, tc := range cases {
+		resources, err := dataSourceMinioIAMPolicyDocumentBucketResources(tc.bucket, tc.objectPattern)
+		if err != nil {
+			t.Fatalf("unexpected error for %q/%q: %s", tc.bucket, tc.objectPattern, err)
+		}
+		if !reflect.DeepEqual(resources, tc.expected) {
+			t.Fatalf("expected %v for %q/%q, got %v", tc.expected, tc.bucket, tc.objectPattern, resources)
+		}
+	}
+
+	if _, err := dataSourceMinioIAMPolicyDocumentBucketResources("", "*"); err == nil {
+		t.Fatalf("object_pattern without bucket should be rejected")
+	}
+	if _, err := dataSourceMinioIAMPolicyDocumentBucketResources("arn:aws:s3:::foo", ""); err == nil {
+		t.Fatalf("bucket ARN should be rejected")
+	}
+}
+
 var testAccMinioIAMPolicyDocumentConfig = `
 data "minio_iam_policy_document" "test" {
     policy_id = "policy_id"
@@ -661,3 +713,53 @@ var testAccMinioIAMPolicyDocumentExpectedJSONStatementPrincipalIdentifiersMultip
     }
   ]
 }`
+
+var testAccMinioIAMPolicyDocumentConfigBucketResources = `
+data "minio_iam_policy_document" "test" {
+  statement {
+    sid     = "ListBucket"
+    actions = ["s3:ListBucket"]
+    bucket  = "foo"
+  }
+  statement {
+    sid            = "ReadWriteObjects"
+    actions        = ["s3:GetObject", "s3:PutObject"]
+    bucket         = "foo"
+    object_pattern = "home/*"
+    resources      = ["arn:aws:s3:::bar/*"]
+  }
+}
+`
+
+var testAccMinioIAMPolicyDocumentExpectedJSONBucketResources = `{
+  "Version": "2012-10-17",
+  "Statement": [
+    {
+      "Sid": "ListBucket",
+      "Effect": "Allow",
+      "Action": "s3:ListBucket",
+      "Resource": "arn:aws:s3:::foo"
+    },
+    {
+      "Sid": "ReadWriteObjects",
+      "Effect": "Allow",
+      "Action": [
+        "s3:PutObject",
+        "s3:GetObject"
+      ],
+      "Resource": [
+        "arn:aws:s3:::foo/home/*",
+        "arn:aws:s3:::bar/*"
+      ]
+    }
+  ]
+}`
+
+var testAccMinioIAMPolicyDocumentConfigObjectPatternWithoutBucket = `
+data "minio_iam_policy_document" "test" {
+  statement {
+    actions        = ["s3:GetObject"]
+    object_pattern = "*"
+  }
+}
+`
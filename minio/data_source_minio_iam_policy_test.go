@@ -0,0 +1,30 @@
+package minio
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccMinioDataSourceIAMPolicy_builtin(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMinioDataSourceIAMPolicyBuiltinConfig,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.minio_iam_policy.readwrite", "builtin", "true"),
+					resource.TestMatchResourceAttr("data.minio_iam_policy.readwrite", "policy", regexp.MustCompile(`"s3:\*"`)),
+				),
+			},
+		},
+	})
+}
+
+var testAccMinioDataSourceIAMPolicyBuiltinConfig = `
+data "minio_iam_policy" "readwrite" {
+  name = "readwrite"
+}
+`
@@ -0,0 +1,102 @@
+package minio
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/minio/madmin-go/v3"
+)
+
+func dataSourceMinioILMTier() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceMinioILMTierRead,
+		Description: "Reads a remote tier and how much data has been transitioned to it",
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"bucket": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"total_objects": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of objects transitioned to the tier, 0 if the server does not report tier stats",
+			},
+			"total_versions": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of object versions transitioned to the tier, 0 if the server does not report tier stats",
+			},
+			"total_size": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Size in bytes of the data transitioned to the tier, 0 if the server does not report tier stats",
+			},
+		},
+	}
+}
+
+func dataSourceMinioILMTierRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*S3MinioClient).S3Admin
+	name := d.Get("name").(string)
+
+	tier, err := getTier(client, ctx, name)
+	if err != nil {
+		return NewResourceError("reading remote tier failed", name, err)
+	}
+	if tier == nil {
+		return NewResourceError("reading remote tier failed", name, errors.New("tier does not exist"))
+	}
+
+	stats, err := ilmTierStats(ctx, client, name)
+	if err != nil {
+		return NewResourceError("reading remote tier stats failed", name, err)
+	}
+
+	d.SetId(name)
+	_ = d.Set("type", tier.Type.String())
+	_ = d.Set("bucket", tier.Bucket())
+	_ = d.Set("total_objects", stats.NumObjects)
+	_ = d.Set("total_versions", stats.NumVersions)
+	_ = d.Set("total_size", int(stats.TotalSize))
+
+	return nil
+}
+
+// minioTierStatsGetter is the part of the admin client needed to read tier stats
+type minioTierStatsGetter interface {
+	TierStats(ctx context.Context) ([]madmin.TierInfo, error)
+}
+
+// ilmTierStats returns the stats of the named tier, or zero stats when the
+// server does not report stats for it or predates the tier stats API.
+func ilmTierStats(ctx context.Context, client minioTierStatsGetter, name string) (madmin.TierStats, error) {
+	tiers, err := client.TierStats(ctx)
+	if err != nil {
+		if madmin.ToErrorResponse(err).Code == "NotImplemented" {
+			tflog.Warn(ctx, "Server does not report tier stats, returning zero values", map[string]interface{}{"tier_name": name})
+			return madmin.TierStats{}, nil
+		}
+		return madmin.TierStats{}, err
+	}
+
+	for _, tier := range tiers {
+		if tier.Name == name {
+			return tier.Stats, nil
+		}
+	}
+
+	tflog.Debug(ctx, "No stats reported for tier", map[string]interface{}{"tier_name": name})
+	return madmin.TierStats{}, nil
+}
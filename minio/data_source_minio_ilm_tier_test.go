@@ -0,0 +1,92 @@
+package minio
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/minio/madmin-go/v3"
+)
+
+type fakeTierStatsGetter struct {
+	tiers []madmin.TierInfo
+	err   error
+}
+
+func (f fakeTierStatsGetter) TierStats(ctx context.Context) ([]madmin.TierInfo, error) {
+	return f.tiers, f.err
+}
+
+func TestILMTierStats(t *testing.T) {
+	tiers := []madmin.TierInfo{
+		{Name: "STANDARD", Type: "internal", Stats: madmin.TierStats{TotalSize: 1, NumObjects: 1, NumVersions: 1}},
+		{Name: "WARM", Type: "minio", Stats: madmin.TierStats{TotalSize: 4096, NumObjects: 3, NumVersions: 4}},
+	}
+
+	cases := []struct {
+		name    string
+		client  fakeTierStatsGetter
+		tier    string
+		want    madmin.TierStats
+		wantErr bool
+	}{
+		{name: "known tier", client: fakeTierStatsGetter{tiers: tiers}, tier: "WARM", want: tiers[1].Stats},
+		{name: "tier without stats", client: fakeTierStatsGetter{tiers: tiers}, tier: "COLD"},
+		{name: "not implemented", client: fakeTierStatsGetter{err: madmin.ErrorResponse{Code: "NotImplemented"}}, tier: "WARM"},
+		{name: "other error", client: fakeTierStatsGetter{err: errors.New("connection refused")}, tier: "WARM", wantErr: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ilmTierStats(context.Background(), tc.client, tc.tier)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tc.want {
+				t.Errorf("ilmTierStats() = %+v, want %+v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestILMTierStats_logFields(t *testing.T) {
+	var output bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &output)
+
+	client := fakeTierStatsGetter{err: madmin.ErrorResponse{Code: "NotImplemented"}}
+	if _, err := ilmTierStats(ctx, client, "WARM"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	entries, err := tflogtest.MultilineJSONDecode(&output)
+	if err != nil {
+		t.Fatalf("unable to decode the log entries: %s", err)
+	}
+	if len(entries) != 1 || entries[0]["@level"] != "warn" || entries[0]["tier_name"] != "WARM" {
+		t.Fatalf("expected a warning with the tier_name field, got %v", entries)
+	}
+}
+
+func TestAccMinioDataSourceILMTier_notFound(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "minio_ilm_tier" "tier" {
+  name = "` + name + `"
+}
+`,
+				ExpectError: regexp.MustCompile("tier does not exist"),
+			},
+		},
+	})
+}
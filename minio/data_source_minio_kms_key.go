@@ -0,0 +1,53 @@
+package minio
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceMinioKMSKey() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceMinioKMSKeyRead,
+		Description: "Reads the status of a KMS key, e.g. to check a key created outside Terraform is usable before configuring bucket encryption with it",
+
+		Schema: map[string]*schema.Schema{
+			"key_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"encryption_error": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Error returned when encrypting with the key, empty if the key works",
+			},
+			"decryption_error": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Error returned when decrypting with the key, empty if the key works",
+			},
+		},
+	}
+}
+
+func dataSourceMinioKMSKeyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*S3MinioClient).S3Admin
+	keyID := d.Get("key_id").(string)
+
+	tflog.Debug(ctx, "Reading KMS key status", map[string]interface{}{"kms_key_id": keyID})
+
+	status, err := client.GetKeyStatus(ctx, keyID)
+	if err != nil {
+		return NewResourceError("error reading KMS key", keyID, err)
+	}
+
+	d.SetId(keyID)
+	_ = d.Set("encryption_error", status.EncryptionErr)
+	_ = d.Set("decryption_error", status.DecryptionErr)
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package minio
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccMinioDataSourceKMSKey_basic(t *testing.T) {
+	keyID := acctest.RandomWithPrefix("tf-acc-key")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "minio_kms_key" "key" {
+  key_id = "%s"
+}
+
+data "minio_kms_key" "key" {
+  key_id = minio_kms_key.key.key_id
+}
+`, keyID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.minio_kms_key.key", "key_id", keyID),
+					resource.TestCheckResourceAttr("data.minio_kms_key.key", "encryption_error", ""),
+					resource.TestCheckResourceAttr("data.minio_kms_key.key", "decryption_error", ""),
+				),
+			},
+		},
+	})
+}
+
+func TestAccMinioDataSourceKMSKey_notFound(t *testing.T) {
+	keyID := acctest.RandomWithPrefix("tf-acc-key")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+data "minio_kms_key" "key" {
+  key_id = "%s"
+}
+`, keyID),
+				ExpectError: regexp.MustCompile("error reading KMS key"),
+			},
+		},
+	})
+}
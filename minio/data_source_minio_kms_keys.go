@@ -0,0 +1,89 @@
+package minio
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/minio/madmin-go/v3"
+)
+
+func dataSourceMinioKMSKeys() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceMinioKMSKeysRead,
+		Description: "Lists the keys of the KMS, e.g. to assert the expected keys exist and detect unexpected ones",
+
+		Schema: map[string]*schema.Schema{
+			"prefix": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only list the keys whose ID starts with this prefix",
+			},
+			"key_ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "IDs of the keys, sorted. Empty when the server has no KMS configured or does not support listing keys",
+			},
+		},
+	}
+}
+
+func dataSourceMinioKMSKeysRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*S3MinioClient).S3Admin
+	prefix := d.Get("prefix").(string)
+
+	keys, diags := listKMSKeys(ctx, client, prefix)
+	if diags.HasError() {
+		return diags
+	}
+
+	d.SetId(prefix + "*")
+	_ = d.Set("key_ids", keys)
+
+	return diags
+}
+
+// minioKMSKeyLister is the part of the admin client needed to list KMS keys
+type minioKMSKeyLister interface {
+	ListKeys(ctx context.Context, pattern string) ([]madmin.KMSKeyInfo, error)
+}
+
+// listKMSKeys returns the sorted IDs of the KMS keys starting with prefix. Servers without a KMS, or predating
+// the key listing API, return no keys with a warning.
+func listKMSKeys(ctx context.Context, client minioKMSKeyLister, prefix string) ([]string, diag.Diagnostics) {
+	tflog.Debug(ctx, "Listing KMS keys", map[string]interface{}{"prefix": prefix})
+
+	infos, err := client.ListKeys(ctx, prefix+"*")
+	if err != nil {
+		switch madmin.ToErrorResponse(err).Code {
+		case "XMinioKMSNotConfigured":
+			return []string{}, diag.Diagnostics{{
+				Severity: diag.Warning,
+				Summary:  "No KMS is configured on the server, no key is listed",
+				Detail:   err.Error(),
+			}}
+		case "NotImplemented":
+			return []string{}, diag.Diagnostics{{
+				Severity: diag.Warning,
+				Summary:  "The server does not support listing KMS keys, no key is listed",
+				Detail:   err.Error(),
+			}}
+		}
+		return nil, NewResourceError("error listing KMS keys", prefix+"*", err)
+	}
+
+	// the built-in KMS of MinIO ignores the pattern and lists its only key
+	keys := []string{}
+	for _, info := range infos {
+		if strings.HasPrefix(info.Name, prefix) {
+			keys = append(keys, info.Name)
+		}
+	}
+	sort.Strings(keys)
+
+	return keys, nil
+}
@@ -0,0 +1,84 @@
+package minio
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/minio/madmin-go/v3"
+)
+
+type fakeKMSKeyLister struct {
+	keys    []madmin.KMSKeyInfo
+	err     error
+	pattern string
+}
+
+func (f *fakeKMSKeyLister) ListKeys(ctx context.Context, pattern string) ([]madmin.KMSKeyInfo, error) {
+	f.pattern = pattern
+	return f.keys, f.err
+}
+
+func TestListKMSKeys(t *testing.T) {
+	keys := []madmin.KMSKeyInfo{{Name: "team-b"}, {Name: "other"}, {Name: "team-a"}}
+
+	cases := []struct {
+		name     string
+		client   *fakeKMSKeyLister
+		prefix   string
+		want     []string
+		severity diag.Severity
+		wantDiag bool
+	}{
+		{name: "all keys sorted", client: &fakeKMSKeyLister{keys: keys}, want: []string{"other", "team-a", "team-b"}},
+		{name: "prefix", client: &fakeKMSKeyLister{keys: keys}, prefix: "team-", want: []string{"team-a", "team-b"}},
+		{name: "no key", client: &fakeKMSKeyLister{}, want: []string{}},
+		{name: "kms not configured", client: &fakeKMSKeyLister{err: madmin.ErrorResponse{Code: "XMinioKMSNotConfigured"}}, want: []string{}, severity: diag.Warning, wantDiag: true},
+		{name: "not implemented", client: &fakeKMSKeyLister{err: madmin.ErrorResponse{Code: "NotImplemented"}}, want: []string{}, severity: diag.Warning, wantDiag: true},
+		{name: "other error", client: &fakeKMSKeyLister{err: errors.New("connection refused")}, severity: diag.Error, wantDiag: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, diags := listKMSKeys(context.Background(), tc.client, tc.prefix)
+			if tc.wantDiag != (len(diags) > 0) || (len(diags) > 0 && diags[0].Severity != tc.severity) {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("listKMSKeys() = %#v, want %#v", got, tc.want)
+			}
+			if tc.client.pattern != tc.prefix+"*" {
+				t.Errorf("expected the keys to be listed with pattern %q, got %q", tc.prefix+"*", tc.client.pattern)
+			}
+		})
+	}
+}
+
+func TestAccMinioDataSourceKMSKeys_basic(t *testing.T) {
+	// the servers started for the tests use the built-in KMS, which only has the key it is started with
+	keyID := strings.SplitN(testAccServerKMSKey, ":", 2)[0]
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+data "minio_kms_keys" "all" {}
+
+data "minio_kms_keys" "none" {
+  prefix = "tf-acc-no-such-key-"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemAttr("data.minio_kms_keys.all", "key_ids.*", keyID),
+					resource.TestCheckResourceAttr("data.minio_kms_keys.none", "key_ids.#", "0"),
+				),
+			},
+		},
+	})
+}
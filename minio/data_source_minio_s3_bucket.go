@@ -0,0 +1,121 @@
+package minio
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/minio/minio-go/v7"
+)
+
+func dataSourceMinioS3Bucket() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceMinioS3BucketRead,
+		Description: "Reads the properties of an existing bucket, e.g. to adapt a module to a bucket it does not manage. Fails when the bucket does not exist",
+
+		Schema: map[string]*schema.Schema{
+			"bucket": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validateBucketName,
+			},
+			"exists": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Always `true`, reading a bucket that does not exist fails",
+			},
+			"arn": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"creation_date": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Date (2030-01-01T00:00:00Z) the bucket was created, empty when the credentials of the provider may not list the buckets",
+			},
+			"region": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Location of the bucket, read from the server even when `minio_region` is set on the provider",
+			},
+			"versioning_status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "`Enabled` or `Suspended`, empty when versioning has never been enabled on the bucket",
+			},
+			"object_locking": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the bucket was created with object locking, which allows object retentions and legal holds",
+			},
+		},
+	}
+}
+
+func dataSourceMinioS3BucketRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*S3MinioClient).S3Client
+	locationClient := meta.(*S3MinioClient).S3LocationClient
+	bucket := d.Get("bucket").(string)
+
+	tflog.Debug(ctx, "Reading bucket", map[string]interface{}{"bucket": bucket})
+
+	exists, err := client.BucketExists(ctx, bucket)
+	if err != nil {
+		return NewResourceError("error reading bucket", bucket, err)
+	}
+	if !exists {
+		return NewResourceError("error reading bucket", bucket, fmt.Errorf("bucket %s does not exist", bucket))
+	}
+
+	region, err := locationClient.GetBucketLocation(ctx, bucket)
+	if err != nil {
+		return NewResourceError("unable to read bucket location", bucket, err)
+	}
+
+	versioning, err := client.GetBucketVersioning(ctx, bucket)
+	if err != nil {
+		return NewResourceError("failed to load bucket versioning", bucket, err)
+	}
+
+	objectLocking, err := minioBucketObjectLockingEnabled(ctx, client, bucket)
+	if err != nil {
+		return NewResourceError("unable to read bucket object lock configuration", bucket, err)
+	}
+
+	d.SetId(bucket)
+	_ = d.Set("exists", true)
+	_ = d.Set("arn", bucketArn(bucket))
+	_ = d.Set("creation_date", minioBucketCreationDate(ctx, client, bucket))
+	_ = d.Set("region", region)
+	_ = d.Set("versioning_status", versioning.Status)
+	_ = d.Set("object_locking", objectLocking)
+
+	return nil
+}
+
+// minioBucketCreationDate returns the creation date of a bucket, which is only reported by the list of buckets,
+// or an empty string when the buckets cannot be listed
+func minioBucketCreationDate(ctx context.Context, client *minio.Client, bucket string) string {
+	buckets, err := client.ListBuckets(ctx)
+	if err != nil {
+		tflog.Debug(ctx, "Unable to list the buckets, leaving the creation date empty", map[string]interface{}{
+			"bucket": bucket,
+			"error":  redactSecrets(err.Error()),
+		})
+		return ""
+	}
+
+	return bucketCreationDate(buckets, bucket)
+}
+
+func bucketCreationDate(buckets []minio.BucketInfo, bucket string) string {
+	for _, info := range buckets {
+		if info.Name == bucket && !info.CreationDate.IsZero() {
+			return info.CreationDate.UTC().Format(time.RFC3339)
+		}
+	}
+	return ""
+}
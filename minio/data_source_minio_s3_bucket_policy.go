@@ -0,0 +1,48 @@
+package minio
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/minio/minio-go/v7"
+)
+
+func dataSourceMinioS3BucketPolicy() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceMinioS3BucketPolicyRead,
+
+		Schema: map[string]*schema.Schema{
+			"bucket": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateDiagFunc: validateBucketName,
+			},
+			"policy": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Policy JSON attached to the bucket, empty if the bucket has no policy",
+			},
+		},
+	}
+}
+
+func dataSourceMinioS3BucketPolicyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*S3MinioClient).S3Client
+	bucket := d.Get("bucket").(string)
+
+	log.Printf("[DEBUG] Reading S3 bucket policy for bucket: %s", bucket)
+
+	policy, err := client.GetBucketPolicy(ctx, bucket)
+	if err != nil && minio.ToErrorResponse(err).Code != "NoSuchBucketPolicy" {
+		return NewResourceError("failed to load bucket policy", bucket, err)
+	}
+
+	d.SetId(bucket)
+	if err := d.Set("policy", policy); err != nil {
+		return NewResourceError("failed to set bucket policy", bucket, err)
+	}
+
+	return nil
+}
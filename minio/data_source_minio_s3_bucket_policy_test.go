@@ -0,0 +1,54 @@
+package minio
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccMinioDataSourceS3BucketPolicy_basic(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckMinioS3BucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMinioDataSourceS3BucketPolicyEmptyConfig(name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.minio_s3_bucket_policy.test", "policy", ""),
+				),
+			},
+			{
+				Config: testAccMinioDataSourceS3BucketPolicyConfig(name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr("data.minio_s3_bucket_policy.test", "policy", regexp.MustCompile(`s3:ListBucket`)),
+				),
+			},
+		},
+	})
+}
+
+func testAccMinioDataSourceS3BucketPolicyEmptyConfig(bucketName string) string {
+	return fmt.Sprintf(`
+resource "minio_s3_bucket" "bucket" {
+  bucket = %q
+}
+
+data "minio_s3_bucket_policy" "test" {
+  bucket = minio_s3_bucket.bucket.bucket
+}
+`, bucketName)
+}
+
+func testAccMinioDataSourceS3BucketPolicyConfig(bucketName string) string {
+	return testAccBucketPolicyConfig(bucketName) + `
+data "minio_s3_bucket_policy" "test" {
+  bucket = minio_s3_bucket_policy.bucket.bucket
+}
+`
+}
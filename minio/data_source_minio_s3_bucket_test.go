@@ -0,0 +1,134 @@
+package minio
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/minio/minio-go/v7"
+)
+
+func TestAccMinioDataSourceS3Bucket_basic(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckMinioS3BucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "minio_s3_bucket" "plain" {
+  bucket = "%[1]s-plain"
+}
+
+resource "minio_s3_bucket" "locked" {
+  bucket         = "%[1]s-locked"
+  object_locking = true
+}
+
+data "minio_s3_bucket" "plain" {
+  bucket = minio_s3_bucket.plain.bucket
+}
+
+data "minio_s3_bucket" "locked" {
+  bucket = minio_s3_bucket.locked.bucket
+}
+`, name),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.minio_s3_bucket.plain", "exists", "true"),
+					resource.TestCheckResourceAttr("data.minio_s3_bucket.plain", "arn", "arn:aws:s3:::"+name+"-plain"),
+					resource.TestMatchResourceAttr("data.minio_s3_bucket.plain", "creation_date", regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}Z$`)),
+					resource.TestCheckResourceAttr("data.minio_s3_bucket.plain", "region", "us-east-1"),
+					resource.TestCheckResourceAttr("data.minio_s3_bucket.plain", "versioning_status", ""),
+					resource.TestCheckResourceAttr("data.minio_s3_bucket.plain", "object_locking", "false"),
+					resource.TestCheckResourceAttr("data.minio_s3_bucket.locked", "versioning_status", "Enabled"),
+					resource.TestCheckResourceAttr("data.minio_s3_bucket.locked", "object_locking", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccMinioDataSourceS3Bucket_notFound(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+data "minio_s3_bucket" "bucket" {
+  bucket = "%s"
+}
+`, name),
+				ExpectError: regexp.MustCompile(fmt.Sprintf("bucket %s does not exist", name)),
+			},
+		},
+	})
+}
+
+func TestDataSourceMinioS3BucketRead_region(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		switch {
+		case query.Has("location"):
+			_, _ = w.Write([]byte(`<LocationConstraint xmlns="http://s3.amazonaws.com/doc/2006-03-01/">us-west-2</LocationConstraint>`))
+		case query.Has("versioning"):
+			_, _ = w.Write([]byte(`<VersioningConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"></VersioningConfiguration>`))
+		case query.Has("object-lock"):
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`<Error><Code>ObjectLockConfigurationNotFoundError</Code></Error>`))
+		case r.URL.Path == "/":
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`<Error><Code>AccessDenied</Code></Error>`))
+		}
+	}))
+	defer server.Close()
+
+	config := NewConfig(schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"minio_server":   strings.TrimPrefix(server.URL, "http://"),
+		"minio_region":   "eu-central-1",
+		"minio_user":     "access",
+		"minio_password": "secret",
+	}))
+	client, err := config.NewClient()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	d := schema.TestResourceDataRaw(t, dataSourceMinioS3Bucket().Schema, map[string]interface{}{"bucket": "bucket"})
+	if diags := dataSourceMinioS3BucketRead(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if region := d.Get("region").(string); region != "us-west-2" {
+		t.Fatalf("expected the location of the bucket rather than the region of the provider, got %q", region)
+	}
+}
+
+func TestBucketCreationDate(t *testing.T) {
+	created := time.Date(2023, 8, 31, 15, 31, 16, 0, time.FixedZone("", 2*60*60))
+	buckets := []minio.BucketInfo{
+		{Name: "other", CreationDate: time.Now()},
+		{Name: "bucket", CreationDate: created},
+		{Name: "undated"},
+	}
+
+	if date := bucketCreationDate(buckets, "bucket"); date != "2023-08-31T13:31:16Z" {
+		t.Fatalf("expected the creation date in UTC, got %q", date)
+	}
+	for _, bucket := range []string{"undated", "missing"} {
+		if date := bucketCreationDate(buckets, bucket); date != "" {
+			t.Fatalf("%s: expected no creation date, got %q", bucket, date)
+		}
+	}
+}
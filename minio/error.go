@@ -1,24 +1,41 @@
 package minio
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"regexp"
 	"strings"
+	"syscall"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/minio/madmin-go/v3"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/set"
 )
 
 // NewResourceError creates a new error with the given msg argument.
 func NewResourceError(msg string, resource string, err interface{}) diag.Diagnostics {
 	switch err := err.(type) {
 	case diag.Diagnostics:
-		return append(err, diag.Diagnostic{
+		// redacted in a copy, the caller's diagnostics are left untouched
+		diags := make(diag.Diagnostics, len(err), len(err)+1)
+		copy(diags, err)
+		for i := range diags {
+			diags[i].Summary = redactSecrets(diags[i].Summary)
+			diags[i].Detail = redactSecrets(diags[i].Detail)
+		}
+		return append(diags, diag.Diagnostic{
 			Severity: diag.Error,
 			Summary:  fmt.Sprintf("[FATAL] %s (%s)", msg, resource),
 		})
 	case error:
-		return diag.Errorf("[FATAL] %s (%s): %s", msg, resource, err)
+		return diag.Errorf("[FATAL] %s (%s): %s", msg, resource, redactSecrets(err.Error()))
 	default:
-		return diag.Errorf("[FATAL] %s (%s): %v", msg, resource, err)
+		return diag.Errorf("[FATAL] %s (%s): %s", msg, resource, redactSecrets(fmt.Sprint(err)))
 	}
 }
 
@@ -31,3 +48,144 @@ func NewResourceErrorStr(msg string, resource string, err interface{}) string {
 	}
 	return strings.Join(strs, ", ")
 }
+
+// secretFieldPattern matches the values of credential fields echoed in error messages, quoted or not, as in
+// "secretKey":"...", AccountKey=... or X-Amz-Signature=..., including JSON escaped within another string
+var secretFieldPattern = regexp.MustCompile(`(?i)(\b(?:secret(?:[_-]?(?:access[_-]?)?key)?|creds(?:[_-]?json)?|account[_-]?key|(?:session|security)[_-]?token|private[_-]?key|client[_-]?secret|password|(?:x-amz-)?signature)(?:\\?["'])?\s*[:=]\s*)(\\"(?:[^\\]|\\[^"])*\\"|"(?:[^"\\]|\\.)*"|[^\s"\\&,;}]+)`)
+
+// minSecretLength is the minimum length of the secret values redacted from messages, shorter values would
+// mangle the message. It is the minimum length of MinIO secret keys.
+const minSecretLength = 8
+
+const redactedSecret = "***"
+
+// redactSecrets masks the values of credential fields in msg, along with the given secret values wherever they appear
+func redactSecrets(msg string, secrets ...string) string {
+	for _, secret := range secrets {
+		if len(secret) >= minSecretLength {
+			msg = strings.ReplaceAll(msg, secret, redactedSecret)
+		}
+	}
+
+	return secretFieldPattern.ReplaceAllStringFunc(msg, func(field string) string {
+		match := secretFieldPattern.FindStringSubmatch(field)
+		switch {
+		case strings.HasPrefix(match[2], `\"`):
+			return match[1] + `\"` + redactedSecret + `\"`
+		case strings.HasPrefix(match[2], `"`):
+			return match[1] + `"` + redactedSecret + `"`
+		}
+		return match[1] + redactedSecret
+	})
+}
+
+// redactedError masks secrets in the message of an error, which is otherwise unwrapped as is
+type redactedError struct {
+	err     error
+	secrets []string
+}
+
+// redactError returns err with the given secret values and the values of credential fields masked in its message
+func redactError(err error, secrets ...string) error {
+	if err == nil {
+		return nil
+	}
+	return &redactedError{err: err, secrets: secrets}
+}
+
+func (e *redactedError) Error() string {
+	return redactSecrets(e.err.Error(), e.secrets...)
+}
+
+func (e *redactedError) Unwrap() error {
+	return e.err
+}
+
+// errorClass tells how an error returned by MinIO should be handled
+type errorClass int
+
+const (
+	// errorClassTerminal errors fail the operation, retrying won't help
+	errorClassTerminal errorClass = iota
+	// errorClassRetryable errors are transient: network failures, server errors and throttling
+	errorClassRetryable
+	// errorClassNotFound errors are terminal errors reporting that the resource doesn't exist
+	errorClassNotFound
+)
+
+// retryableErrorCodes are the S3 and admin API error codes of transient failures
+var retryableErrorCodes = set.CreateStringSet(
+	"InternalError",
+	"RequestTimeout",
+	"ServiceUnavailable",
+	"SlowDown",
+	"SlowDownRead",
+	"SlowDownWrite",
+	"Throttling",
+	"XMinioServerNotInitialized",
+)
+
+// notFoundErrorCodes are the S3 and admin API error codes of missing resources
+var notFoundErrorCodes = set.CreateStringSet(
+	"NoSuchBucket",
+	"NoSuchBucketPolicy",
+	"NoSuchKey",
+	"NoSuchLifecycleConfiguration",
+	"NoSuchTagSet",
+	"NoSuchVersion",
+	"ReplicationConfigurationNotFoundError",
+	"ServerSideEncryptionConfigurationNotFoundError",
+	"XMinioAdminNoSuchGroup",
+	"XMinioAdminNoSuchPolicy",
+	"XMinioAdminNoSuchServiceAccount",
+	"XMinioAdminNoSuchUser",
+	"XMinioAdminRemoteTargetNotFoundError",
+	"XMinioAdminTierNotFound",
+	"kms:KeyNotFound",
+)
+
+// classifyError tells whether err is worth retrying. Errors of the S3 and admin APIs are classified by code and
+// HTTP status, 5xx and 429 being retryable and other 4xx terminal. Failures to reach the server are retryable,
+// unless the context is done.
+func classifyError(err error) errorClass {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return errorClassTerminal
+	}
+
+	var minioErr minio.ErrorResponse
+	if errors.As(err, &minioErr) && (minioErr.Code != "" || minioErr.StatusCode != 0) {
+		return classifyErrorResponse(minioErr.Code, minioErr.StatusCode)
+	}
+
+	// admin API errors carry no HTTP status
+	var adminErr madmin.ErrorResponse
+	if errors.As(err, &adminErr) && adminErr.Code != "" {
+		return classifyErrorResponse(adminErr.Code, 0)
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) {
+		return errorClassRetryable
+	}
+
+	return errorClassTerminal
+}
+
+func classifyErrorResponse(code string, statusCode int) errorClass {
+	switch {
+	case retryableErrorCodes.Contains(code):
+		return errorClassRetryable
+	case notFoundErrorCodes.Contains(code):
+		return errorClassNotFound
+	case statusCode >= http.StatusInternalServerError || statusCode == http.StatusTooManyRequests:
+		return errorClassRetryable
+	case statusCode == http.StatusNotFound:
+		return errorClassNotFound
+	}
+	return errorClassTerminal
+}
+
+// isNotFoundError reports whether err says that the resource doesn't exist, so that read can clear the state
+func isNotFoundError(err error) bool {
+	return err != nil && classifyError(err) == errorClassNotFound
+}
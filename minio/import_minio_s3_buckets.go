@@ -21,10 +21,6 @@ func resourceMinioS3BucketImportState(
 
 	conn := meta.(*S3MinioClient).S3Client
 
-	bucketObjectLocking, _, _, _, err := conn.GetObjectLockConfig(ctx, d.Id())
-	object_locking := err == nil && bucketObjectLocking == "Enabled"
-	_ = d.Set("object_locking", object_locking)
-
 	pol, err := conn.GetBucketPolicy(ctx, d.Id())
 	if err != nil {
 		return nil, fmt.Errorf("error importing Minio S3 bucket policy: %s", err)
@@ -0,0 +1,61 @@
+package minio
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/minio/madmin-go/v3"
+)
+
+// minioKMSStatusGetter is the part of the admin client needed to check the KMS status
+type minioKMSStatusGetter interface {
+	KMSStatus(ctx context.Context) (madmin.KMSStatus, error)
+}
+
+// minioVerifyKMS returns an error when the server has no KMS configured or none of its
+// KMS endpoints is online. Servers using the built-in KMS report no endpoints at all.
+func minioVerifyKMS(ctx context.Context, client minioKMSStatusGetter) error {
+	status, err := client.KMSStatus(ctx)
+	if err != nil {
+		if madmin.ToErrorResponse(err).Code == "XMinioKMSNotConfigured" {
+			return fmt.Errorf("no KMS is configured on the server, configure KES or MINIO_KMS_SECRET_KEY before using SSE-KMS: %w", err)
+		}
+		return fmt.Errorf("unable to get the KMS status of the server, check that the KMS is running and reachable from MinIO: %w", err)
+	}
+
+	if len(status.Endpoints) == 0 {
+		return nil
+	}
+
+	var offline []string
+	for endpoint, state := range status.Endpoints {
+		if state == madmin.ItemOnline {
+			return nil
+		}
+		offline = append(offline, endpoint)
+	}
+	sort.Strings(offline)
+
+	return fmt.Errorf("KMS %q is not reachable from the server, check that the KMS is running at %s", status.Name, strings.Join(offline, ", "))
+}
+
+// minioCheckKMSReachable verifies the KMS before an SSE-KMS change is applied,
+// when enabled with the minio_verify_kms provider option.
+func minioCheckKMSReachable(ctx context.Context, meta interface{}, resource string) diag.Diagnostics {
+	m := meta.(*S3MinioClient)
+	if !m.VerifyKMS {
+		return nil
+	}
+
+	tflog.Debug(ctx, "Verifying KMS status before applying the change", map[string]interface{}{"resource": resource})
+
+	if err := minioVerifyKMS(ctx, m.S3Admin); err != nil {
+		return NewResourceError("KMS check failed", resource, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package minio
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/minio/madmin-go/v3"
+)
+
+type fakeKMSStatusGetter struct {
+	status madmin.KMSStatus
+	err    error
+}
+
+func (f fakeKMSStatusGetter) KMSStatus(ctx context.Context) (madmin.KMSStatus, error) {
+	return f.status, f.err
+}
+
+func TestMinioVerifyKMS(t *testing.T) {
+	cases := []struct {
+		name    string
+		client  fakeKMSStatusGetter
+		wantErr string
+	}{
+		{
+			name:   "built-in KMS",
+			client: fakeKMSStatusGetter{status: madmin.KMSStatus{Name: "Builtin"}},
+		},
+		{
+			name: "one endpoint online",
+			client: fakeKMSStatusGetter{status: madmin.KMSStatus{Name: "KES", Endpoints: map[string]madmin.ItemState{
+				"https://kes-1:7373": madmin.ItemOffline,
+				"https://kes-2:7373": madmin.ItemOnline,
+			}}},
+		},
+		{
+			name: "all endpoints offline",
+			client: fakeKMSStatusGetter{status: madmin.KMSStatus{Name: "KES", Endpoints: map[string]madmin.ItemState{
+				"https://kes-2:7373": madmin.ItemOffline,
+				"https://kes-1:7373": madmin.ItemOffline,
+			}}},
+			wantErr: `KMS "KES" is not reachable from the server, check that the KMS is running at https://kes-1:7373, https://kes-2:7373`,
+		},
+		{
+			name:    "not configured",
+			client:  fakeKMSStatusGetter{err: madmin.ErrorResponse{Code: "XMinioKMSNotConfigured"}},
+			wantErr: "no KMS is configured on the server",
+		},
+		{
+			name:    "status unavailable",
+			client:  fakeKMSStatusGetter{err: errors.New("connection refused")},
+			wantErr: "check that the KMS is running and reachable from MinIO: connection refused",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := minioVerifyKMS(context.Background(), tc.client)
+			if tc.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Fatalf("expected error containing %q, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
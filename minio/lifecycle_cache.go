@@ -0,0 +1,77 @@
+package minio
+
+import (
+	"context"
+	"sync"
+
+	"github.com/minio/minio-go/v7/pkg/lifecycle"
+)
+
+// minioLifecycleGetter is the part of the S3 client needed to read lifecycle configurations
+type minioLifecycleGetter interface {
+	GetBucketLifecycle(ctx context.Context, bucketName string) (*lifecycle.Configuration, error)
+}
+
+// lifecycleCache memoizes bucket lifecycle configurations for the lifetime of the provider,
+// which is a single Terraform operation. Concurrent reads of the same bucket share one request.
+// A nil cache reads through to the client.
+type lifecycleCache struct {
+	mu      sync.Mutex
+	entries map[string]*lifecycleCacheEntry
+}
+
+type lifecycleCacheEntry struct {
+	once   sync.Once
+	config *lifecycle.Configuration
+	err    error
+}
+
+func newLifecycleCache() *lifecycleCache {
+	return &lifecycleCache{entries: map[string]*lifecycleCacheEntry{}}
+}
+
+// Get returns the lifecycle configuration of bucket, fetching it on first use.
+// Failed reads are not cached.
+func (c *lifecycleCache) Get(ctx context.Context, client minioLifecycleGetter, bucket string) (*lifecycle.Configuration, error) {
+	if c == nil {
+		return client.GetBucketLifecycle(ctx, bucket)
+	}
+
+	c.mu.Lock()
+	entry, ok := c.entries[bucket]
+	if !ok {
+		entry = &lifecycleCacheEntry{}
+		c.entries[bucket] = entry
+	}
+	c.mu.Unlock()
+
+	entry.once.Do(func() {
+		entry.config, entry.err = client.GetBucketLifecycle(ctx, bucket)
+	})
+
+	if entry.err != nil {
+		c.mu.Lock()
+		if c.entries[bucket] == entry {
+			delete(c.entries, bucket)
+		}
+		c.mu.Unlock()
+		return nil, entry.err
+	}
+
+	// callers may modify the rules, hand out a copy
+	config := *entry.config
+	config.Rules = append([]lifecycle.Rule(nil), entry.config.Rules...)
+
+	return &config, nil
+}
+
+// Invalidate drops the cached configuration of bucket, it must be called after changing it
+func (c *lifecycleCache) Invalidate(bucket string) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	delete(c.entries, bucket)
+	c.mu.Unlock()
+}
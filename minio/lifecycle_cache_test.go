@@ -0,0 +1,126 @@
+package minio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/minio/minio-go/v7/pkg/lifecycle"
+)
+
+type fakeLifecycleGetter struct {
+	calls   int64
+	latency time.Duration
+	err     error
+}
+
+func (f *fakeLifecycleGetter) GetBucketLifecycle(ctx context.Context, bucketName string) (*lifecycle.Configuration, error) {
+	atomic.AddInt64(&f.calls, 1)
+	time.Sleep(f.latency)
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &lifecycle.Configuration{Rules: []lifecycle.Rule{{ID: bucketName, Status: "Enabled"}}}, nil
+}
+
+func TestLifecycleCache(t *testing.T) {
+	client := &fakeLifecycleGetter{}
+	cache := newLifecycleCache()
+
+	for i := 0; i < 3; i++ {
+		config, err := cache.Get(context.Background(), client, "bucket")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if len(config.Rules) != 1 || config.Rules[0].ID != "bucket" {
+			t.Fatalf("unexpected configuration %+v", config)
+		}
+		config.Rules[0].ID = "modified"
+	}
+	if client.calls != 1 {
+		t.Fatalf("expected a single request, got %d", client.calls)
+	}
+
+	cache.Invalidate("bucket")
+	if _, err := cache.Get(context.Background(), client, "bucket"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if client.calls != 2 {
+		t.Fatalf("invalidated bucket should be fetched again, got %d requests", client.calls)
+	}
+}
+
+func TestLifecycleCache_errorsNotCached(t *testing.T) {
+	client := &fakeLifecycleGetter{err: errors.New("connection reset")}
+	cache := newLifecycleCache()
+
+	if _, err := cache.Get(context.Background(), client, "bucket"); err == nil {
+		t.Fatalf("expected error")
+	}
+	client.err = nil
+	if _, err := cache.Get(context.Background(), client, "bucket"); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if client.calls != 2 {
+		t.Fatalf("failed read should be retried, got %d requests", client.calls)
+	}
+}
+
+func TestLifecycleCache_disabled(t *testing.T) {
+	client := &fakeLifecycleGetter{}
+	var cache *lifecycleCache
+
+	for i := 0; i < 2; i++ {
+		if _, err := cache.Get(context.Background(), client, "bucket"); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+	cache.Invalidate("bucket")
+	if client.calls != 2 {
+		t.Fatalf("disabled cache should read through, got %d requests", client.calls)
+	}
+}
+
+// benchmarkLifecycleReads refreshes 50 buckets each holding three minio_ilm_policy resources
+// (manage_existing_rules = false), reading them with the provider's default parallelism
+func benchmarkLifecycleReads(b *testing.B, cache func() *lifecycleCache) {
+	const buckets, policiesPerBucket, parallelism = 50, 3, 10
+
+	client := &fakeLifecycleGetter{latency: time.Millisecond}
+	for i := 0; i < b.N; i++ {
+		c := cache()
+		reads := make(chan string)
+		var wg sync.WaitGroup
+		for w := 0; w < parallelism; w++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for bucket := range reads {
+					if _, err := c.Get(context.Background(), client, bucket); err != nil {
+						b.Error(err)
+					}
+				}
+			}()
+		}
+		for p := 0; p < policiesPerBucket; p++ {
+			for bkt := 0; bkt < buckets; bkt++ {
+				reads <- fmt.Sprintf("bucket-%d", bkt)
+			}
+		}
+		close(reads)
+		wg.Wait()
+	}
+	b.ReportMetric(float64(client.calls)/float64(b.N), "requests/op")
+}
+
+func BenchmarkLifecycleReads_uncached(b *testing.B) {
+	benchmarkLifecycleReads(b, func() *lifecycleCache { return nil })
+}
+
+func BenchmarkLifecycleReads_cached(b *testing.B) {
+	benchmarkLifecycleReads(b, newLifecycleCache)
+}
@@ -1,13 +1,17 @@
 package minio
 
 import (
+	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/json"
 	"encoding/pem"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/minio/madmin-go/v3"
 	"github.com/minio/minio-go/v7"
@@ -20,26 +24,23 @@ func (config *S3MinioConfig) NewClient() (client interface{}, err error) {
 	var minioClient *minio.Client
 	var minioCredentials *credentials.Credentials
 
-	tr, err := config.customTransport()
+	if err := config.loadSharedCredentials(); err != nil {
+		return nil, err
+	}
+
+	customTransport, err := config.customTransport()
 	if err != nil {
 		log.Println("[FATAL] Error configuring S3 client transport.")
 		return nil, err
 	}
+	tr := newRequestLimiter(customTransport, config.MaxConcurrentRequests)
 
 	if config.S3APISignature == "v2" {
 		minioCredentials = credentials.NewStaticV2(config.S3UserAccess, config.S3UserSecret, config.S3SessionToken)
-		minioClient, err = minio.New(config.S3HostPort, &minio.Options{
-			Creds:     minioCredentials,
-			Secure:    config.S3SSL,
-			Transport: tr,
-		})
+		minioClient, err = minio.New(config.S3HostPort, config.clientOptions(minioCredentials, tr))
 	} else if config.S3APISignature == "v4" {
 		minioCredentials = credentials.NewStaticV4(config.S3UserAccess, config.S3UserSecret, config.S3SessionToken)
-		minioClient, err = minio.New(config.S3HostPort, &minio.Options{
-			Creds:     minioCredentials,
-			Secure:    config.S3SSL,
-			Transport: tr,
-		})
+		minioClient, err = minio.New(config.S3HostPort, config.clientOptions(minioCredentials, tr))
 	} else {
 		return nil, fmt.Errorf("unknown S3 API signature: %s, must be v2 or v4", config.S3APISignature)
 	}
@@ -48,6 +49,18 @@ func (config *S3MinioConfig) NewClient() (client interface{}, err error) {
 		return nil, err
 	}
 
+	// a client pinned to a region reports it as the location of every bucket
+	locationClient := minioClient
+	if config.S3Region != "" {
+		options := config.clientOptions(minioCredentials, tr)
+		options.Region = ""
+		if locationClient, err = minio.New(config.S3HostPort, options); err != nil {
+			log.Println("[FATAL] Error building location client for S3 server.")
+			return nil, err
+		}
+		locationClient.SetAppInfo(providerName, config.userAgentVersion())
+	}
+
 	minioAdmin, err := madmin.NewWithOptions(config.S3HostPort, &madmin.Options{
 		Creds:  minioCredentials,
 		Secure: config.S3SSL,
@@ -59,14 +72,129 @@ func (config *S3MinioConfig) NewClient() (client interface{}, err error) {
 	}
 	minioAdmin.SetCustomTransport(tr)
 
+	minioClient.SetAppInfo(providerName, config.userAgentVersion())
+	minioAdmin.SetAppInfo(providerName, config.userAgentVersion())
+
+	var cache *lifecycleCache
+	if config.LifecycleCache {
+		cache = newLifecycleCache()
+	}
+
 	return &S3MinioClient{
-		S3UserAccess: config.S3UserAccess,
-		S3Region:     config.S3Region,
-		S3Client:     minioClient,
-		S3Admin:      minioAdmin,
+		S3UserAccess:     config.S3UserAccess,
+		S3Region:         config.S3Region,
+		S3Client:         minioClient,
+		S3Admin:          minioAdmin,
+		S3LocationClient: locationClient,
+		LifecycleCache:   cache,
+		VerifyKMS:        config.VerifyKMS,
+		DefaultTags:      config.DefaultTags,
 	}, nil
 }
 
+// sharedCredentialsDefaultProfile is the profile read from the shared credentials file when none is configured
+const sharedCredentialsDefaultProfile = "default"
+
+// loadSharedCredentials replaces the static credentials by the ones of the profile of the shared credentials file,
+// the static credentials are kept when the profile has no access key
+func (config *S3MinioConfig) loadSharedCredentials() error {
+	if config.SharedCredentialsFile == "" {
+		return nil
+	}
+
+	profile := config.Profile
+	if profile == "" {
+		profile = sharedCredentialsDefaultProfile
+	}
+
+	value, err := readSharedCredentials(config.SharedCredentialsFile, profile)
+	if err != nil {
+		return fmt.Errorf("unable to read the credentials of profile %q from %s: %w", profile, config.SharedCredentialsFile, err)
+	}
+	if value.AccessKeyID == "" {
+		log.Printf("[DEBUG] Profile %q of %s has no access key, using the static credentials", profile, config.SharedCredentialsFile)
+		return nil
+	}
+
+	log.Printf("[DEBUG] Using the credentials of profile %q of %s", profile, config.SharedCredentialsFile)
+
+	config.S3UserAccess = value.AccessKeyID
+	config.S3UserSecret = value.SecretAccessKey
+	config.S3SessionToken = value.SessionToken
+
+	return nil
+}
+
+// readSharedCredentials reads the credentials of a profile from an mc configuration file, told apart by its JSON
+// format, or from an AWS shared credentials file
+func readSharedCredentials(filename, profile string) (credentials.Value, error) {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return credentials.Value{}, err
+	}
+
+	var provider credentials.Provider
+	if json.Valid(content) {
+		provider = &credentials.FileMinioClient{Filename: filename, Alias: profile}
+	} else {
+		provider = &credentials.FileAWSCredentials{Filename: filename, Profile: profile}
+	}
+
+	return provider.Retrieve()
+}
+
+// credentialsValidationTimeout bounds the time spent checking the server accepts the credentials on configure
+const credentialsValidationTimeout = 30 * time.Second
+
+// validateCredentials lists the buckets to check the server is reachable and accepts the credentials, so a wrong
+// endpoint or credentials fail the configuration instead of each resource. Users which may not list buckets
+// are accepted, the server authenticated them before denying the request.
+func (client *S3MinioClient) validateCredentials(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, credentialsValidationTimeout)
+	defer cancel()
+
+	_, err := client.S3Client.ListBuckets(ctx)
+	if err == nil {
+		return nil
+	}
+
+	errResp := minio.ToErrorResponse(err)
+	switch {
+	case errResp.Code == "AccessDenied":
+		log.Printf("[DEBUG] Credentials of %s are not allowed to list buckets, assuming they are valid", client.S3UserAccess)
+		return nil
+	case errResp.StatusCode == http.StatusForbidden || errResp.StatusCode == http.StatusUnauthorized:
+		return fmt.Errorf("the server rejected the credentials of %q, check minio_user and minio_password, or set skip_credentials_validation: %w", client.S3UserAccess, err)
+	case errResp.StatusCode != 0:
+		return fmt.Errorf("unexpected answer of the server, check minio_server is a MinIO endpoint, or set skip_credentials_validation: %w", err)
+	}
+	return fmt.Errorf("unable to reach the server, check minio_server and minio_ssl, or set skip_credentials_validation: %w", err)
+}
+
+// clientOptions returns the options of the S3 client
+func (config *S3MinioConfig) clientOptions(creds *credentials.Credentials, tr http.RoundTripper) *minio.Options {
+	return &minio.Options{
+		Creds:        creds,
+		Secure:       config.S3SSL,
+		Transport:    tr,
+		Region:       config.S3Region,
+		BucketLookup: config.S3BucketLookup,
+	}
+}
+
+// userAgentVersion returns the version appended to the User-Agent after the provider name, followed by the
+// suffix configured by the user if any
+func (config *S3MinioConfig) userAgentVersion() string {
+	version := config.ProviderVersion
+	if version == "" {
+		version = providerDevVersion
+	}
+	if suffix := strings.TrimSpace(config.UserAgentSuffix); suffix != "" {
+		version += " " + suffix
+	}
+	return version
+}
+
 func isValidCertificate(c []byte) bool {
 	p, _ := pem.Decode(c)
 	if p == nil {
@@ -94,12 +222,15 @@ func (config *S3MinioConfig) customTransport() (*http.Transport, error) {
 		return nil, err
 	}
 
+	minioCACert := []byte(config.S3SSLCACertPEM)
 	if config.S3SSLCACertFile != "" {
-		minioCACert, err := os.ReadFile(config.S3SSLCACertFile)
+		minioCACert, err = os.ReadFile(config.S3SSLCACertFile)
 		if err != nil {
 			return nil, err
 		}
+	}
 
+	if len(minioCACert) > 0 {
 		if !isValidCertificate(minioCACert) {
 			return nil, fmt.Errorf("minio CA Cert is not a valid x509 certificate")
 		}
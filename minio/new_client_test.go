@@ -0,0 +1,436 @@
+package minio
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/minio/minio-go/v7"
+)
+
+func TestCustomTransport_insecure(t *testing.T) {
+	for _, insecure := range []bool{false, true} {
+		config := &S3MinioConfig{S3SSL: true, S3SSLSkipVerify: insecure}
+
+		tr, err := config.customTransport()
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if tr.TLSClientConfig == nil {
+			t.Fatalf("TLS client config should be set")
+		}
+		if tr.TLSClientConfig.InsecureSkipVerify != insecure {
+			t.Fatalf("expected InsecureSkipVerify to be %t", insecure)
+		}
+	}
+}
+
+func TestCustomTransport_caCert(t *testing.T) {
+	caCert := testGenerateCACertificate(t)
+
+	caCertFile := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(caCertFile, caCert, 0600); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	configs := map[string]*S3MinioConfig{
+		"file": {S3SSL: true, S3SSLCACertFile: caCertFile},
+		"pem":  {S3SSL: true, S3SSLCACertPEM: string(caCert)},
+	}
+
+	for name, config := range configs {
+		tr, err := config.customTransport()
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", name, err)
+		}
+		if tr.TLSClientConfig.RootCAs == nil {
+			t.Fatalf("%s: root CAs should be set", name)
+		}
+		if tr.TLSClientConfig.InsecureSkipVerify {
+			t.Fatalf("%s: certificate verification should be enabled by default", name)
+		}
+	}
+
+	config := &S3MinioConfig{S3SSL: true, S3SSLCACertPEM: "not a certificate"}
+	if _, err := config.customTransport(); err == nil {
+		t.Fatalf("invalid CA certificate should be rejected")
+	}
+}
+
+func testGenerateCACertificate(t *testing.T) []byte {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "minio-test-ca"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+const testAWSSharedCredentials = `[default]
+aws_access_key_id = default-access
+aws_secret_access_key = default-secret
+
+[sts]
+aws_access_key_id = sts-access
+aws_secret_access_key = sts-secret
+aws_session_token = sts-token
+`
+
+const testMinioClientConfig = `{
+  "version": "10",
+  "aliases": {
+    "default": {
+      "url": "https://play.min.io",
+      "accessKey": "mc-access",
+      "secretKey": "mc-secret",
+      "api": "S3v4",
+      "path": "auto"
+    }
+  }
+}`
+
+func testWriteSharedCredentials(t *testing.T, name, content string) string {
+	filename := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(filename, []byte(content), 0600); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	return filename
+}
+
+func TestReadSharedCredentials(t *testing.T) {
+	awsFile := testWriteSharedCredentials(t, "credentials", testAWSSharedCredentials)
+	mcFile := testWriteSharedCredentials(t, "config.json", testMinioClientConfig)
+
+	for name, tc := range map[string]struct {
+		filename, profile            string
+		access, secret, sessionToken string
+	}{
+		"aws default profile":    {awsFile, "default", "default-access", "default-secret", ""},
+		"aws profile with token": {awsFile, "sts", "sts-access", "sts-secret", "sts-token"},
+		"mc alias":               {mcFile, "default", "mc-access", "mc-secret", ""},
+		"mc missing alias":       {mcFile, "other", "", "", ""},
+	} {
+		t.Run(name, func(t *testing.T) {
+			value, err := readSharedCredentials(tc.filename, tc.profile)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if value.AccessKeyID != tc.access || value.SecretAccessKey != tc.secret || value.SessionToken != tc.sessionToken {
+				t.Fatalf("expected (%q, %q, %q), got (%q, %q, %q)", tc.access, tc.secret, tc.sessionToken, value.AccessKeyID, value.SecretAccessKey, value.SessionToken)
+			}
+		})
+	}
+
+	if _, err := readSharedCredentials(awsFile, "other"); err == nil {
+		t.Fatalf("missing AWS profile should be rejected")
+	}
+	if _, err := readSharedCredentials(filepath.Join(t.TempDir(), "missing"), "default"); err == nil {
+		t.Fatalf("missing file should be rejected")
+	}
+}
+
+func TestLoadSharedCredentials(t *testing.T) {
+	awsFile := testWriteSharedCredentials(t, "credentials", testAWSSharedCredentials)
+	mcFile := testWriteSharedCredentials(t, "config.json", testMinioClientConfig)
+
+	config := &S3MinioConfig{S3UserAccess: "static-access", S3UserSecret: "static-secret", S3SessionToken: "static-token", SharedCredentialsFile: awsFile}
+	if err := config.loadSharedCredentials(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if config.S3UserAccess != "default-access" || config.S3UserSecret != "default-secret" || config.S3SessionToken != "" {
+		t.Fatalf("the default profile should replace the static credentials, got (%q, %q, %q)", config.S3UserAccess, config.S3UserSecret, config.S3SessionToken)
+	}
+
+	config = &S3MinioConfig{S3UserAccess: "static-access", S3UserSecret: "static-secret", SharedCredentialsFile: mcFile, Profile: "other"}
+	if err := config.loadSharedCredentials(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if config.S3UserAccess != "static-access" || config.S3UserSecret != "static-secret" {
+		t.Fatalf("the static credentials should be kept when the profile has none, got (%q, %q)", config.S3UserAccess, config.S3UserSecret)
+	}
+
+	config = &S3MinioConfig{SharedCredentialsFile: awsFile, Profile: "other"}
+	if err := config.loadSharedCredentials(); err == nil || !strings.Contains(err.Error(), `unable to read the credentials of profile "other"`) {
+		t.Fatalf("expected an error for the missing profile, got %v", err)
+	}
+}
+
+func TestProviderConfigure_sharedCredentialsFile(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/xml")
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`<Error><Code>InvalidAccessKeyId</Code><Message>The Access Key Id you provided does not exist in our records.</Message></Error>`))
+	}))
+	defer server.Close()
+
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"minio_server":            strings.TrimPrefix(server.URL, "http://"),
+		"minio_user":              "access",
+		"minio_password":          "secret",
+		"shared_credentials_file": testWriteSharedCredentials(t, "credentials", testAWSSharedCredentials),
+		"profile":                 "sts",
+	})
+	_, diags := providerConfigure(providerDevVersion)(context.Background(), d)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, `the server rejected the credentials of "sts-access"`) {
+		t.Fatalf("expected the credentials of the profile to be used, got %v", diags)
+	}
+}
+
+func TestNewClient_sessionToken(t *testing.T) {
+	tokens := make(chan string, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case tokens <- r.Header.Get("X-Amz-Security-Token"):
+		default:
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	config := &S3MinioConfig{
+		S3HostPort:     strings.TrimPrefix(server.URL, "http://"),
+		S3Region:       "us-east-1",
+		S3UserAccess:   "access",
+		S3UserSecret:   "secret",
+		S3SessionToken: "session-token",
+		S3APISignature: "v4",
+	}
+
+	client, err := config.NewClient()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	minioClient := client.(*S3MinioClient)
+
+	_, _ = minioClient.S3Client.BucketExists(context.Background(), "bucket")
+	if token := <-tokens; token != "session-token" {
+		t.Fatalf("S3 client should send the session token, got %q", token)
+	}
+
+	for len(tokens) > 0 {
+		<-tokens
+	}
+
+	_, _ = minioClient.S3Admin.ServerInfo(context.Background())
+	if token := <-tokens; token != "session-token" {
+		t.Fatalf("admin client should send the session token, got %q", token)
+	}
+}
+
+func TestNewClient_regionAndPathStyle(t *testing.T) {
+	requests := make(chan *http.Request, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case requests <- r:
+		default:
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"minio_server":         strings.TrimPrefix(server.URL, "http://"),
+		"minio_region":         "eu-central-1",
+		"minio_user":           "access",
+		"minio_password":       "secret",
+		"minio_use_path_style": true,
+	})
+	config := NewConfig(d)
+	if config.S3Region != "eu-central-1" || config.S3BucketLookup != minio.BucketLookupPath {
+		t.Fatalf("unexpected region %q and bucket lookup %d", config.S3Region, config.S3BucketLookup)
+	}
+
+	client, err := config.NewClient()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	_, _ = client.(*S3MinioClient).S3Client.BucketExists(context.Background(), "bucket")
+	r := <-requests
+	if _, ok := r.URL.Query()["location"]; ok {
+		t.Fatalf("the configured region should be used instead of looking up the bucket location")
+	}
+	if r.URL.Path != "/bucket/" {
+		t.Fatalf("bucket should be addressed in the path, got %s", r.URL.Path)
+	}
+	if !strings.Contains(r.Header.Get("Authorization"), "/eu-central-1/s3/") {
+		t.Fatalf("request should be signed for the configured region, got %s", r.Header.Get("Authorization"))
+	}
+
+	d = schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"minio_server":         "minio.example.com",
+		"minio_use_path_style": false,
+	})
+	config = NewConfig(d)
+	if options := config.clientOptions(nil, nil); options.BucketLookup != minio.BucketLookupDNS {
+		t.Fatalf("virtual-hosted buckets should be used when path style is disabled, got %d", options.BucketLookup)
+	}
+
+	// without a configured region, the location of the bucket is looked up and used to sign the requests
+	d = schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"minio_server":   strings.TrimPrefix(server.URL, "http://"),
+		"minio_user":     "access",
+		"minio_password": "secret",
+	})
+	config = NewConfig(d)
+	if config.S3Region != "" {
+		t.Fatalf("no region should be set unless configured, got %q", config.S3Region)
+	}
+	client, err = config.NewClient()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	for len(requests) > 0 {
+		<-requests
+	}
+	_, _ = client.(*S3MinioClient).S3Client.BucketExists(context.Background(), "bucket")
+	if _, ok := (<-requests).URL.Query()["location"]; !ok {
+		t.Fatalf("the location of the bucket should be looked up when no region is configured")
+	}
+}
+
+func TestNewClient_userAgent(t *testing.T) {
+	userAgents := make(chan string, 2)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case userAgents <- r.Header.Get("User-Agent"):
+		default:
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"minio_server":                strings.TrimPrefix(server.URL, "http://"),
+		"minio_user":                  "access",
+		"minio_password":              "secret",
+		"minio_user_agent_suffix":     "team-storage",
+		"skip_credentials_validation": true,
+	})
+
+	configure := New("1.2.3")().ConfigureContextFunc
+	client, diags := configure(context.Background(), d)
+	if diags.HasError() {
+		t.Fatalf("unexpected errors: %v", diags)
+	}
+
+	_, _ = client.(*S3MinioClient).S3Client.BucketExists(context.Background(), "bucket")
+	_, _ = client.(*S3MinioClient).S3Admin.ListUsers(context.Background())
+
+	for _, client := range []string{"S3", "admin"} {
+		if userAgent := <-userAgents; !strings.HasSuffix(userAgent, " terraform-provider-minio/1.2.3 team-storage") {
+			t.Fatalf("%s client should identify the provider and the suffix in its User-Agent, got %q", client, userAgent)
+		}
+	}
+
+	config := &S3MinioConfig{}
+	if version := config.userAgentVersion(); version != "dev" {
+		t.Fatalf("providers built without version should identify as dev, got %q", version)
+	}
+}
+
+func TestProviderConfigure_credentialsValidation(t *testing.T) {
+	for name, tc := range map[string]struct {
+		status int
+		body   string
+		skip   bool
+		err    string
+	}{
+		"valid credentials": {
+			status: http.StatusOK,
+			body:   `<ListAllMyBucketsResult><Owner><ID>minio</ID></Owner><Buckets></Buckets></ListAllMyBucketsResult>`,
+		},
+		"listing denied": {
+			status: http.StatusForbidden,
+			body:   `<Error><Code>AccessDenied</Code><Message>Access Denied.</Message></Error>`,
+		},
+		"invalid access key": {
+			status: http.StatusForbidden,
+			body:   `<Error><Code>InvalidAccessKeyId</Code><Message>The Access Key Id you provided does not exist in our records.</Message></Error>`,
+			err:    `the server rejected the credentials of "access"`,
+		},
+		"wrong secret key": {
+			status: http.StatusForbidden,
+			body:   `<Error><Code>SignatureDoesNotMatch</Code><Message>The request signature we calculated does not match the signature you provided.</Message></Error>`,
+			err:    `the server rejected the credentials of "access"`,
+		},
+		"skipped": {
+			status: http.StatusForbidden,
+			body:   `<Error><Code>InvalidAccessKeyId</Code><Message>The Access Key Id you provided does not exist in our records.</Message></Error>`,
+			skip:   true,
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			requests := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				w.Header().Set("Content-Type", "application/xml")
+				w.WriteHeader(tc.status)
+				_, _ = w.Write([]byte(tc.body))
+			}))
+			defer server.Close()
+
+			d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+				"minio_server":                strings.TrimPrefix(server.URL, "http://"),
+				"minio_user":                  "access",
+				"minio_password":              "secret",
+				"skip_credentials_validation": tc.skip,
+			})
+			_, diags := providerConfigure(providerDevVersion)(context.Background(), d)
+
+			if tc.err == "" && diags.HasError() {
+				t.Fatalf("unexpected errors: %v", diags)
+			}
+			if tc.err != "" && (!diags.HasError() || !strings.Contains(diags[0].Summary, tc.err)) {
+				t.Fatalf("expected an error containing %q, got %v", tc.err, diags)
+			}
+			if tc.skip && requests != 0 {
+				t.Fatalf("no request should be sent when skip_credentials_validation is set, got %d", requests)
+			}
+		})
+	}
+}
+
+func TestProviderConfigure_unreachableServer(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	endpoint := strings.TrimPrefix(server.URL, "http://")
+	server.Close()
+
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"minio_server":   endpoint,
+		"minio_user":     "access",
+		"minio_password": "secret",
+	})
+	_, diags := providerConfigure(providerDevVersion)(context.Background(), d)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "unable to reach the server") {
+		t.Fatalf("expected the server to be reported unreachable, got %v", diags)
+	}
+}
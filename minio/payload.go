@@ -21,9 +21,22 @@ type S3MinioConfig struct {
 	S3APISignature  string
 	S3SSL           bool
 	S3SSLCACertFile string
+	S3SSLCACertPEM  string
 	S3SSLCertFile   string
 	S3SSLKeyFile    string
 	S3SSLSkipVerify bool
+	S3BucketLookup  minio.BucketLookupType
+	LifecycleCache  bool
+	VerifyKMS       bool
+	DefaultTags     map[string]string
+	ProviderVersion string
+	UserAgentSuffix string
+	// MaxConcurrentRequests bounds the mutating requests in flight, 0 means no limit
+	MaxConcurrentRequests int
+	// SharedCredentialsFile is an mc configuration or AWS shared credentials file to read the credentials of
+	// Profile from, overriding the static credentials
+	SharedCredentialsFile string
+	Profile               string
 }
 
 // S3MinioClient defines default minio
@@ -32,6 +45,13 @@ type S3MinioClient struct {
 	S3Region     string
 	S3Client     *minio.Client
 	S3Admin      *madmin.AdminClient
+	// S3LocationClient looks up the location of buckets, unlike S3Client it isn't pinned to the configured region
+	S3LocationClient *minio.Client
+	// LifecycleCache is nil unless lifecycle caching is enabled
+	LifecycleCache *lifecycleCache
+	VerifyKMS      bool
+	// DefaultTags are merged into the tags of buckets and objects, resource tags taking precedence
+	DefaultTags map[string]string
 }
 
 // S3MinioBucket defines minio config
@@ -52,6 +72,7 @@ type S3MinioBucketPolicy struct {
 	MinioClient       *minio.Client
 	MinioBucket       string
 	MinioBucketPolicy string
+	MinioBucketACL    string
 }
 
 // S3MinioBucketVersioningConfiguration defines bucket versioning config
@@ -59,6 +80,7 @@ type S3MinioBucketVersioningConfiguration struct {
 	Status           string
 	ExcludedPrefixes []string
 	ExcludeFolders   bool
+	MFADelete        string
 }
 
 // S3PathSyle
@@ -97,6 +119,8 @@ type S3MinioBucketReplicationRule struct {
 	MetadataSync              bool
 
 	Target S3MinioBucketReplicationRuleTarget
+	// ExternalTarget is set when the rule has no target and refers by Arn to a remote target managed elsewhere
+	ExternalTarget bool
 }
 
 // S3MinioBucketReplicationRuleTarget defines bucket replication rule target
@@ -131,6 +155,13 @@ type S3MinioBucketReplication struct {
 	ReplicationRules []S3MinioBucketReplicationRule
 }
 
+// S3MinioBucketReplicationTarget defines a remote target of bucket replication
+type S3MinioBucketReplicationTarget struct {
+	MinioAdmin  *madmin.AdminClient
+	MinioBucket string
+	Target      S3MinioBucketReplicationRuleTarget
+}
+
 // S3MinioBucketNotification
 type S3MinioBucketNotification struct {
 	MinioClient   *minio.Client
@@ -145,6 +176,13 @@ type S3MinioBucketServerSideEncryption struct {
 	Configuration *sse.Configuration
 }
 
+// S3MinioBucketTags defines bucket tags
+type S3MinioBucketTags struct {
+	MinioClient *minio.Client
+	MinioBucket string
+	Tags        map[string]string
+}
+
 // S3MinioServiceAccountConfig defines service account config
 type S3MinioServiceAccountConfig struct {
 	MinioAdmin        *madmin.AdminClient
@@ -152,6 +190,7 @@ type S3MinioServiceAccountConfig struct {
 	MinioAccessKey    string
 	MinioSecretKey    string
 	MinioSAPolicy     string
+	MinioSAPolicyName string
 	MinioDisableUser  bool
 	MinioForceDestroy bool
 	MinioUpdateKey    bool
@@ -164,6 +203,7 @@ type S3MinioIAMUserConfig struct {
 	MinioIAMName      string
 	MinioSecret       string
 	MinioDisableUser  bool
+	MinioStatus       madmin.AccountStatus
 	MinioForceDestroy bool
 	MinioUpdateKey    bool
 	MinioIAMTags      map[string]string
@@ -173,7 +213,8 @@ type S3MinioIAMUserConfig struct {
 type S3MinioIAMGroupConfig struct {
 	MinioAdmin        *madmin.AdminClient
 	MinioIAMName      string
-	MinioDisableGroup bool
+	MinioStatus       madmin.GroupStatus
+	MinioPolicy       string
 	MinioForceDestroy bool
 }
 
@@ -211,8 +252,11 @@ type S3MinioIAMGroupPolicyConfig struct {
 
 // S3MinioKMSKeyConfig defines service account config
 type S3MinioKMSKeyConfig struct {
-	MinioAdmin    *madmin.AdminClient
-	MinioKMSKeyID string
+	MinioAdmin             *madmin.AdminClient
+	MinioClient            *minio.Client
+	MinioKMSKeyID          string
+	MinioKMSKeyMaterial    string
+	MinioDefaultForBuckets []*string
 }
 
 // Princ defines policy princ
@@ -280,6 +324,12 @@ type ResponseError struct {
 // Resource prefix for all aws resources.
 const awsResourcePrefix = "arn:aws:s3:::"
 
+// Resource prefix of KMS keys, as referenced by SSE-KMS bucket encryption.
+const kmsKeyResourcePrefix = "arn:aws:kms:"
+
+// Resource prefix of remote tiers. S3 has no ARN for tiers, MinIO ones are identified by their name.
+const ilmTierResourcePrefix = "arn:minio:ilm:::tier/"
+
 // All bucket actions.
 var allBucketActions = set.CreateStringSet("s3:GetBucketLocation", "s3:ListBucket", "s3:ListBucketMultipartUploads", "s3:GetObject", "s3:AbortMultipartUpload", "s3:DeleteObject", "s3:ListMultipartUploadParts", "s3:PutObject", "s3:CreateBucket", "s3:DeleteBucket", "s3:DeleteBucketPolicy", "s3:DeleteObject", "s3:GetBucketLocation", "s3:GetBucketNotification", "s3:GetBucketPolicy", "s3:GetObject", "s3:HeadBucket", "s3:ListAllMyBuckets", "s3:ListBucket", "s3:ListBucketMultipartUploads", "s3:ListenBucketNotification", "s3:ListMultipartUploadParts", "s3:PutObject", "s3:PutBucketPolicy", "s3:PutBucketNotification") //"s3:PutBucketLifecycle", "s3:GetBucketLifecycle"
 
@@ -0,0 +1,169 @@
+package minio
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/minio/minio-go/v7/pkg/set"
+)
+
+// minioS3PolicyActions lists the s3 actions MinIO evaluates in policies. The list is only used to warn about
+// likely typos, as new MinIO releases add actions.
+var minioS3PolicyActions = set.CreateStringSet(
+	"s3:AbortMultipartUpload",
+	"s3:BypassGovernanceRetention",
+	"s3:CreateBucket",
+	"s3:DeleteBucket",
+	"s3:DeleteBucketCors",
+	"s3:DeleteBucketPolicy",
+	"s3:DeleteObject",
+	"s3:DeleteObjectTagging",
+	"s3:DeleteObjectVersion",
+	"s3:DeleteObjectVersionTagging",
+	"s3:ForceDeleteBucket",
+	"s3:GetBucketCors",
+	"s3:GetBucketEncryption",
+	"s3:GetBucketLocation",
+	"s3:GetBucketNotification",
+	"s3:GetBucketObjectLockConfiguration",
+	"s3:GetBucketPolicy",
+	"s3:GetBucketPolicyStatus",
+	"s3:GetBucketTagging",
+	"s3:GetBucketVersioning",
+	"s3:GetLifecycleConfiguration",
+	"s3:GetObject",
+	"s3:GetObjectAttributes",
+	"s3:GetObjectLegalHold",
+	"s3:GetObjectRetention",
+	"s3:GetObjectTagging",
+	"s3:GetObjectVersion",
+	"s3:GetObjectVersionAttributes",
+	"s3:GetObjectVersionForReplication",
+	"s3:GetObjectVersionTagging",
+	"s3:GetReplicationConfiguration",
+	"s3:HeadBucket",
+	"s3:ListAllMyBuckets",
+	"s3:ListBucket",
+	"s3:ListBucketMultipartUploads",
+	"s3:ListBucketVersions",
+	"s3:ListenBucketNotification",
+	"s3:ListenNotification",
+	"s3:ListMultipartUploadParts",
+	"s3:PutBucketCors",
+	"s3:PutBucketEncryption",
+	"s3:PutBucketNotification",
+	"s3:PutBucketObjectLockConfiguration",
+	"s3:PutBucketPolicy",
+	"s3:PutBucketTagging",
+	"s3:PutBucketVersioning",
+	"s3:PutLifecycleConfiguration",
+	"s3:PutObject",
+	"s3:PutObjectFanOut",
+	"s3:PutObjectLegalHold",
+	"s3:PutObjectRetention",
+	"s3:PutObjectTagging",
+	"s3:PutObjectVersionTagging",
+	"s3:PutReplicationConfiguration",
+	"s3:ReplicateDelete",
+	"s3:ReplicateObject",
+	"s3:ReplicateTags",
+	"s3:ResetBucketReplicationState",
+	"s3:RestoreObject",
+)
+
+// minioPolicyActionPrefixes are the services MinIO policies grant actions for. Only s3 actions are checked by name.
+var minioPolicyActionPrefixes = set.CreateStringSet("s3", "admin", "kms", "sts")
+
+// checkIAMPolicyDocument checks the structure of a policy document. Malformed statements are returned as an error,
+// while actions MinIO is not known to support are only returned as warnings.
+func checkIAMPolicyDocument(policy string) (warnings []string, err error) {
+	var doc map[string]interface{}
+	if err := json.Unmarshal([]byte(policy), &doc); err != nil {
+		return nil, err
+	}
+
+	var statements []interface{}
+	switch s := doc["Statement"].(type) {
+	case []interface{}:
+		statements = s
+	case map[string]interface{}:
+		statements = []interface{}{s}
+	default:
+		return nil, fmt.Errorf("policy must have a Statement list")
+	}
+
+	for i, statementI := range statements {
+		statement, ok := statementI.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("statement %d must be an object", i)
+		}
+
+		if effect := statement["Effect"]; effect != "Allow" && effect != "Deny" {
+			return nil, fmt.Errorf("statement %d: Effect must be \"Allow\" or \"Deny\", got %v", i, effect)
+		}
+
+		actionKey := "Action"
+		if _, ok := statement[actionKey]; !ok {
+			actionKey = "NotAction"
+		}
+		actions, err := policyStringList(statement[actionKey])
+		if err != nil {
+			return nil, fmt.Errorf("statement %d: Action must be a string or a list of strings", i)
+		}
+		if len(actions) == 0 {
+			return nil, fmt.Errorf("statement %d must have an Action", i)
+		}
+
+		for _, action := range actions {
+			if !minioPolicyActionKnown(action) {
+				warnings = append(warnings, fmt.Sprintf("statement %d: action %q is not known to be supported by MinIO, check it for typos", i, action))
+			}
+		}
+	}
+
+	return warnings, nil
+}
+
+// minioPolicyActionKnown reports whether an action, possibly with wildcards, matches an action MinIO supports
+func minioPolicyActionKnown(action string) bool {
+	if action == "*" {
+		return true
+	}
+
+	prefix, name, ok := strings.Cut(action, ":")
+	if !ok || !minioPolicyActionPrefixes.Contains(prefix) {
+		return false
+	}
+	if prefix != "s3" || name == "*" {
+		return true
+	}
+
+	for known := range minioS3PolicyActions {
+		if matched, _ := path.Match(action, known); matched {
+			return true
+		}
+	}
+	return false
+}
+
+func policyStringList(v interface{}) ([]string, error) {
+	switch value := v.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return []string{value}, nil
+	case []interface{}:
+		list := make([]string, 0, len(value))
+		for _, item := range value {
+			s, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("expected a string, got %v", item)
+			}
+			list = append(list, s)
+		}
+		return list, nil
+	}
+	return nil, fmt.Errorf("expected a string or a list of strings, got %v", v)
+}
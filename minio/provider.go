@@ -5,13 +5,29 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+// providerName identifies the provider in the User-Agent of its requests
+const providerName = "terraform-provider-minio"
+
+// providerDevVersion is the version of providers built without the release flags
+const providerDevVersion = "dev"
+
 // Provider creates a new provider
 func Provider() *schema.Provider {
 	return newProvider()
 }
 
+// New returns the factory of the provider of the given version, sent in the User-Agent of its requests
+func New(version string) func() *schema.Provider {
+	return func() *schema.Provider {
+		p := newProvider()
+		p.ConfigureContextFunc = providerConfigure(version)
+		return p
+	}
+}
+
 func newProvider(envvarPrefixed ...string) *schema.Provider {
 	envVarPrefix := ""
 	if len(envvarPrefixed) != 0 {
@@ -30,8 +46,15 @@ func newProvider(envvarPrefixed ...string) *schema.Provider {
 			"minio_region": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				Default:     "us-east-1",
-				Description: "Minio Region (default: us-east-1)",
+				Description: "Minio Region. When set, requests are signed for it instead of looking up the location of each bucket, and buckets are created in it (default: the location of the bucket, buckets are created in us-east-1)",
+			},
+			"minio_use_path_style": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Address buckets as part of the path (http://host/bucket) instead of the host name (http://bucket.host). Disable for gateways that only accept virtual-hosted requests (default: true)",
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{
+					envVarPrefix + "MINIO_USE_PATH_STYLE",
+				}, true),
 			},
 			"minio_access_key": {
 				Type:        schema.TypeString,
@@ -72,7 +95,8 @@ func newProvider(envvarPrefixed ...string) *schema.Provider {
 			"minio_session_token": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				Description: "Minio Session Token",
+				Sensitive:   true,
+				Description: "Minio Session Token, for temporary (STS) credentials",
 				DefaultFunc: schema.MultiEnvDefaultFunc([]string{
 					envVarPrefix + "MINIO_SESSION_TOKEN",
 				}, ""),
@@ -100,12 +124,87 @@ func newProvider(envvarPrefixed ...string) *schema.Provider {
 				}, nil),
 			},
 			"minio_cacert_file": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "Path to a PEM encoded CA bundle used to verify the server certificate",
+				ConflictsWith: []string{"minio_cacert_pem"},
 				DefaultFunc: schema.MultiEnvDefaultFunc([]string{
 					envVarPrefix + "MINIO_CACERT_FILE",
 				}, nil),
 			},
+			"minio_cacert_pem": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "PEM encoded CA bundle used to verify the server certificate",
+				ConflictsWith: []string{"minio_cacert_file"},
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{
+					envVarPrefix + "MINIO_CACERT_PEM",
+				}, nil),
+			},
+			"minio_lifecycle_cache": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Cache bucket lifecycle configurations for the duration of a Terraform operation (default: false)",
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{
+					envVarPrefix + "MINIO_LIFECYCLE_CACHE",
+				}, false),
+			},
+			"minio_verify_kms": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Check that the KMS of the server is reachable before applying SSE-KMS changes (default: false)",
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{
+					envVarPrefix + "MINIO_VERIFY_KMS",
+				}, false),
+			},
+			"minio_user_agent_suffix": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Appended to the User-Agent of the requests, after the provider name and version, to identify them in the audit logs of the server",
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{
+					envVarPrefix + "MINIO_USER_AGENT_SUFFIX",
+				}, nil),
+			},
+			"max_concurrent_requests": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Maximum number of requests changing the server in flight at a time, across all resources, to avoid throttling when applying many resources in parallel. Reads are not limited (default: 0, no limit)",
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{
+					envVarPrefix + "MINIO_MAX_CONCURRENT_REQUESTS",
+				}, 0),
+			},
+			"shared_credentials_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Path to an mc configuration file (~/.mc/config.json) or an AWS shared credentials file (~/.aws/credentials) to read the access key, secret key and session token from. The static credentials and their environment variables are used when the profile has no access key",
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{
+					envVarPrefix + "MINIO_SHARED_CREDENTIALS_FILE",
+				}, nil),
+			},
+			"profile": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Alias of the mc configuration file, or profile of the AWS shared credentials file, to read the credentials from (default: `default`)",
+				RequiredWith: []string{"shared_credentials_file"},
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{
+					envVarPrefix + "MINIO_PROFILE",
+				}, nil),
+			},
+			"skip_credentials_validation": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Skip checking that the server is reachable and accepts the credentials when configuring the provider, e.g. to plan without access to the server (default: false)",
+				DefaultFunc: schema.MultiEnvDefaultFunc([]string{
+					envVarPrefix + "MINIO_SKIP_CREDENTIALS_VALIDATION",
+				}, false),
+			},
+			"default_tags": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Tags added to the bucket tags and object tags managed by the provider. Tags set on a resource override the default with the same key",
+			},
 			"minio_cert_file": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -123,7 +222,14 @@ func newProvider(envvarPrefixed ...string) *schema.Provider {
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
+			"minio_admin_info":          dataSourceMinioAdminInfo(),
+			"minio_iam_policy":          dataSourceMinioIAMPolicy(),
 			"minio_iam_policy_document": dataSourceMinioIAMPolicyDocument(),
+			"minio_ilm_tier":            dataSourceMinioILMTier(),
+			"minio_kms_key":             dataSourceMinioKMSKey(),
+			"minio_kms_keys":            dataSourceMinioKMSKeys(),
+			"minio_s3_bucket":           dataSourceMinioS3Bucket(),
+			"minio_s3_bucket_policy":    dataSourceMinioS3BucketPolicy(),
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
@@ -131,9 +237,14 @@ func newProvider(envvarPrefixed ...string) *schema.Provider {
 			"minio_s3_bucket_policy":                 resourceMinioBucketPolicy(),
 			"minio_s3_bucket_versioning":             resourceMinioBucketVersioning(),
 			"minio_s3_bucket_replication":            resourceMinioBucketReplication(),
+			"minio_s3_bucket_replication_target":     resourceMinioBucketReplicationTarget(),
 			"minio_s3_bucket_notification":           resourceMinioBucketNotification(),
 			"minio_s3_bucket_server_side_encryption": resourceMinioBucketServerSideEncryption(),
+			"minio_s3_bucket_tags":                   resourceMinioBucketTags(),
+			"minio_s3_bucket_setting":                resourceMinioBucketSetting(),
 			"minio_s3_object":                        resourceMinioObject(),
+			"minio_s3_object_legal_hold":             resourceMinioObjectLegalHold(),
+			"minio_s3_object_retention":              resourceMinioObjectRetention(),
 			"minio_iam_group":                        resourceMinioIAMGroup(),
 			"minio_iam_group_membership":             resourceMinioIAMGroupMembership(),
 			"minio_iam_user":                         resourceMinioIAMUser(),
@@ -143,21 +254,34 @@ func newProvider(envvarPrefixed ...string) *schema.Provider {
 			"minio_iam_user_policy_attachment":       resourceMinioIAMUserPolicyAttachment(),
 			"minio_iam_group_policy_attachment":      resourceMinioIAMGroupPolicyAttachment(),
 			"minio_iam_group_user_attachment":        resourceMinioIAMGroupUserAttachment(),
+			"minio_iam_ldap_policy_attachment":       resourceMinioIAMLDAPPolicyAttachment(),
 			"minio_ilm_policy":                       resourceMinioILMPolicy(),
+			"minio_ilm_policy_rule":                  resourceMinioILMPolicyRule(),
 			"minio_kms_key":                          resourceMinioKMSKey(),
 			"minio_ilm_tier":                         resourceMinioILMTier(),
+			"minio_admin_config":                     resourceMinioAdminConfig(),
+			"minio_admin_pool_decommission":          resourceMinioAdminPoolDecommission(),
 		},
 
-		ConfigureContextFunc: providerConfigure,
+		ConfigureContextFunc: providerConfigure(providerDevVersion),
 	}
 }
 
-func providerConfigure(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
-	minioConfig := NewConfig(d)
-	client, err := minioConfig.NewClient()
-	if err != nil {
-		return nil, NewResourceError("client creation failed", "client", err)
-	}
+func providerConfigure(version string) schema.ConfigureContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+		minioConfig := NewConfig(d)
+		minioConfig.ProviderVersion = version
+		client, err := minioConfig.NewClient()
+		if err != nil {
+			return nil, NewResourceError("client creation failed", "client", err)
+		}
 
-	return client, nil
+		if !d.Get("skip_credentials_validation").(bool) {
+			if err := client.(*S3MinioClient).validateCredentials(ctx); err != nil {
+				return nil, NewResourceError("credentials validation failed", minioConfig.S3HostPort, err)
+			}
+		}
+
+		return client, nil
+	}
 }
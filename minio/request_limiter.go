@@ -0,0 +1,37 @@
+package minio
+
+import (
+	"net/http"
+)
+
+// requestLimiter bounds the number of mutating requests in flight, shared by the S3 and admin clients of a provider,
+// so that applying many resources in parallel doesn't get the server to throttle. Reads are not limited.
+type requestLimiter struct {
+	next      http.RoundTripper
+	semaphore chan struct{}
+}
+
+// newRequestLimiter wraps next to send at most max mutating requests at a time. A max of 0 returns next as is.
+func newRequestLimiter(next http.RoundTripper, max int) http.RoundTripper {
+	if max <= 0 {
+		return next
+	}
+	return &requestLimiter{next: next, semaphore: make(chan struct{}, max)}
+}
+
+// RoundTrip waits for a slot before sending mutating requests, the slot is released once the response headers
+// are received
+func (l *requestLimiter) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method == http.MethodGet || req.Method == http.MethodHead {
+		return l.next.RoundTrip(req)
+	}
+
+	select {
+	case l.semaphore <- struct{}{}:
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	}
+	defer func() { <-l.semaphore }()
+
+	return l.next.RoundTrip(req)
+}
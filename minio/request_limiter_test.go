@@ -0,0 +1,60 @@
+package minio
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRequestLimiter(t *testing.T) {
+	var inFlight, maxInFlight int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			max := atomic.LoadInt32(&maxInFlight)
+			if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+	}))
+	defer server.Close()
+
+	send := func(client *http.Client, method string, count int) {
+		var wg sync.WaitGroup
+		for i := 0; i < count; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				req, _ := http.NewRequest(method, server.URL, nil)
+				resp, err := client.Do(req)
+				if err != nil {
+					t.Errorf("unexpected error: %s", err)
+					return
+				}
+				_ = resp.Body.Close()
+			}()
+		}
+		wg.Wait()
+	}
+
+	client := &http.Client{Transport: newRequestLimiter(http.DefaultTransport, 2)}
+	send(client, http.MethodPut, 10)
+	if maxInFlight != 2 {
+		t.Fatalf("expected at most 2 mutating requests in flight, got %d", maxInFlight)
+	}
+
+	maxInFlight = 0
+	send(client, http.MethodGet, 10)
+	if maxInFlight <= 2 {
+		t.Fatalf("expected reads not to be limited, got at most %d in flight", maxInFlight)
+	}
+
+	if transport := newRequestLimiter(http.DefaultTransport, 0); transport != http.DefaultTransport {
+		t.Fatalf("expected no limiter when max_concurrent_requests is 0")
+	}
+}
@@ -0,0 +1,151 @@
+package minio
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceMinioAdminConfig() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: minioSetAdminConfig,
+		ReadContext:   minioReadAdminConfig,
+		UpdateContext: minioSetAdminConfig,
+		DeleteContext: minioDeleteAdminConfig,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Description: "`minio_admin_config` manages the keys of a server config subsystem, such as `api`, `compression` or `scanner`. " +
+			"Only the keys set in `value` are managed, the others keep their current value. " +
+			"Destroying the resource resets the whole subsystem to its defaults.",
+		Schema: map[string]*schema.Schema{
+			"key": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+				Description:  "Config subsystem, optionally followed by a target (e.g. `compression` or `notify_webhook:primary`)",
+			},
+			"value": {
+				Type:             schema.TypeMap,
+				Required:         true,
+				Elem:             &schema.Schema{Type: schema.TypeString},
+				ValidateDiagFunc: validateAdminConfigValue,
+				Description:      "Values of the sub-keys of the subsystem. Multi-value settings are given as one string, e.g. `extensions = \".txt,.log\"`",
+			},
+			"restart_required": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the server must be restarted for the last change to take effect",
+			},
+		},
+	}
+}
+
+func minioSetAdminConfig(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*S3MinioClient).S3Admin
+	configKey := d.Get("key").(string)
+	values := getStringMap(d.Get("value").(map[string]interface{}))
+
+	log.Printf("[DEBUG] Setting server config %s", configKey)
+
+	restart, err := client.SetConfigKV(ctx, minioConfigKVString(configKey, values))
+	if err != nil {
+		return NewResourceError("unable to set server config", configKey, err)
+	}
+
+	d.SetId(configKey)
+	_ = d.Set("restart_required", restart)
+	if restart {
+		log.Printf("[WARN] Server config %s changed, the server must be restarted for it to take effect", configKey)
+	}
+
+	var diags diag.Diagnostics
+	if overrides, err := minioConfigEnvOverrides(ctx, client, configKey); err != nil {
+		log.Printf("[WARN] Unable to check environment overrides of server config %s: %s", configKey, err)
+	} else {
+		diags = minioConfigEnvOverrideWarnings(configKey, values, overrides)
+	}
+
+	return append(diags, minioReadAdminConfig(ctx, d, meta)...)
+}
+
+func minioReadAdminConfig(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*S3MinioClient).S3Admin
+
+	log.Printf("[DEBUG] Reading server config %s", d.Id())
+
+	effective, err := minioConfigEffectiveValues(ctx, client, d.Id())
+	if err != nil {
+		return NewResourceError("unable to read server config", d.Id(), err)
+	}
+
+	// on import every value is managed, otherwise only the keys set in the configuration
+	values := effective
+	if managed := d.Get("value").(map[string]interface{}); len(managed) > 0 {
+		values = minioConfigManagedValues(managed, effective)
+	}
+
+	_ = d.Set("key", d.Id())
+	if err := d.Set("value", values); err != nil {
+		return NewResourceError("unable to set server config value", d.Id(), err)
+	}
+
+	return nil
+}
+
+func minioDeleteAdminConfig(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*S3MinioClient).S3Admin
+
+	log.Printf("[DEBUG] Resetting server config %s", d.Id())
+
+	restart, err := client.DelConfigKV(ctx, d.Id())
+	if err != nil {
+		return NewResourceError("unable to reset server config", d.Id(), err)
+	}
+	if restart {
+		log.Printf("[WARN] Server config %s reset, the server must be restarted for it to take effect", d.Id())
+	}
+
+	return nil
+}
+
+func validateAdminConfigValue(v interface{}, p cty.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for key, value := range v.(map[string]interface{}) {
+		// values are sent quoted, the server has no way to escape a quote inside them
+		if strings.Contains(value.(string), `"`) {
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       fmt.Sprintf("invalid value for config key %s", key),
+				Detail:        "Config values cannot contain double quotes.",
+				AttributePath: p.IndexString(key),
+			})
+		}
+	}
+
+	return diags
+}
+
+// minioConfigKVString formats the values of configKey as expected by SetConfigKV
+func minioConfigKVString(configKey string, values map[string]string) string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	kvs := []string{configKey}
+	for _, key := range keys {
+		kvs = append(kvs, fmt.Sprintf(`%s="%s"`, key, values[key]))
+	}
+
+	return strings.Join(kvs, " ")
+}
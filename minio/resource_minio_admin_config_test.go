@@ -0,0 +1,147 @@
+package minio
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestMinioConfigKVString(t *testing.T) {
+	got := minioConfigKVString("compression", map[string]string{
+		"extensions": ".txt,.log",
+		"enable":     "on",
+		"mime_types": "text/*",
+	})
+	expected := `compression enable="on" extensions=".txt,.log" mime_types="text/*"`
+	if got != expected {
+		t.Fatalf("expected %s, got %s", expected, got)
+	}
+}
+
+func TestMinioConfigEffectiveValues_multiValue(t *testing.T) {
+	for _, output := range []string{
+		"compression enable=on allow_encryption=off extensions=.txt,.log,.csv mime_types=text/*,application/json\n",
+		`compression enable=on allow_encryption=off extensions=".txt,.log,.csv" mime_types="text/*,application/json"` + "\n",
+	} {
+		values, err := minioConfigEffectiveValues(context.Background(), &fakeConfigKVGetter{output: output}, "compression")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if values["extensions"] != ".txt,.log,.csv" || values["mime_types"] != "text/*,application/json" {
+			t.Fatalf("multi-value settings should be read unchanged from %q, got %v", output, values)
+		}
+	}
+}
+
+func TestValidateAdminConfigValue(t *testing.T) {
+	if diags := validateAdminConfigValue(map[string]interface{}{"extensions": ".txt,.log"}, cty.GetAttrPath("value")); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	diags := validateAdminConfigValue(map[string]interface{}{"comment": `say "hi"`}, cty.GetAttrPath("value"))
+	if len(diags) != 1 || !diags[0].AttributePath.Equals(cty.GetAttrPath("value").IndexString("comment")) {
+		t.Fatalf("expected an error at value.comment, got %v", diags)
+	}
+}
+
+func TestAccMinioAdminConfig_compression(t *testing.T) {
+	resourceName := "minio_admin_config.compression"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckMinioAdminConfigValue("compression", "enable", "off"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMinioAdminConfigCompression(".txt,.log,.csv", "text/*,application/json"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "value.extensions", ".txt,.log,.csv"),
+					resource.TestCheckResourceAttr(resourceName, "value.mime_types", "text/*,application/json"),
+					testAccCheckMinioAdminConfigValue("compression", "extensions", ".txt,.log,.csv"),
+				),
+			},
+			{
+				Config: testAccMinioAdminConfigCompression(".log,.txt", "application/json,text/*"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "value.extensions", ".log,.txt"),
+					resource.TestCheckResourceAttr(resourceName, "value.mime_types", "application/json,text/*"),
+					testAccCheckMinioAdminConfigValue("compression", "mime_types", "application/json,text/*"),
+				),
+			},
+			{
+				// re-reading must not produce a diff
+				Config:   testAccMinioAdminConfigCompression(".log,.txt", "application/json,text/*"),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccMinioAdminConfig_basic(t *testing.T) {
+	resourceName := "minio_admin_config.scanner"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckMinioAdminConfigValue("scanner", "speed", "default"),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMinioAdminConfigScanner("slow"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "value.speed", "slow"),
+					resource.TestCheckResourceAttr(resourceName, "restart_required", "false"),
+					testAccCheckMinioAdminConfigValue("scanner", "speed", "slow"),
+				),
+			},
+			{
+				Config: testAccMinioAdminConfigScanner("fast"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "value.speed", "fast"),
+					testAccCheckMinioAdminConfigValue("scanner", "speed", "fast"),
+				),
+			},
+		},
+	})
+}
+
+func testAccMinioAdminConfigScanner(speed string) string {
+	return fmt.Sprintf(`
+resource "minio_admin_config" "scanner" {
+  key = "scanner"
+  value = {
+    speed = "%s"
+  }
+}
+`, speed)
+}
+
+func testAccMinioAdminConfigCompression(extensions, mimeTypes string) string {
+	return fmt.Sprintf(`
+resource "minio_admin_config" "compression" {
+  key = "compression"
+  value = {
+    enable     = "on"
+    extensions = "%s"
+    mime_types = "%s"
+  }
+}
+`, extensions, mimeTypes)
+}
+
+func testAccCheckMinioAdminConfigValue(configKey, key, expected string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*S3MinioClient).S3Admin
+		values, err := minioConfigEffectiveValues(context.Background(), client, configKey)
+		if err != nil {
+			return fmt.Errorf("error reading server config %s: %s", configKey, err)
+		}
+		if values[key] != expected {
+			return fmt.Errorf("expected %s %s to be %q, got %q", configKey, key, expected, values[key])
+		}
+		return nil
+	}
+}
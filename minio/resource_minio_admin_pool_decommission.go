@@ -0,0 +1,184 @@
+package minio
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/minio/madmin-go/v3"
+)
+
+const poolDecommissionPollInterval = 10 * time.Second
+
+// minioPoolDecommissioner is the subset of the admin API needed to drive a pool decommission
+type minioPoolDecommissioner interface {
+	DecommissionPool(ctx context.Context, pool string) error
+	StatusPool(ctx context.Context, pool string) (madmin.PoolStatus, error)
+}
+
+func resourceMinioAdminPoolDecommission() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: minioCreatePoolDecommission,
+		ReadContext:   minioReadPoolDecommission,
+		DeleteContext: minioDeletePoolDecommission,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(2 * time.Hour),
+		},
+		Description: "`minio_admin_pool_decommission` starts the decommissioning of a server pool and waits for it to complete. " +
+			"**This is destructive**: once complete, all data has been drained from the pool and it must be removed from the server command line. " +
+			"Decommissioning cannot be undone by destroying this resource.",
+		Schema: map[string]*schema.Schema{
+			"pool": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+				Description:  "Pool to decommission, as given on the server command line (e.g. `http://server{1...4}/disk{1...4}`)",
+			},
+			"confirm": {
+				Type:             schema.TypeBool,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validatePoolDecommissionConfirm,
+				Description:      "Must be set to `true` to acknowledge that the pool will be drained",
+			},
+			"triggers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Arbitrary map of values that, when changed, will start a new decommission",
+			},
+			"complete": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"objects_decommissioned": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"bytes_decommissioned": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func validatePoolDecommissionConfirm(v interface{}, p cty.Path) diag.Diagnostics {
+	if !v.(bool) {
+		return diag.Errorf("confirm must be set to true to decommission a pool")
+	}
+
+	return nil
+}
+
+func minioCreatePoolDecommission(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	minioAdmin := meta.(*S3MinioClient).S3Admin
+	pool := d.Get("pool").(string)
+
+	if !d.Get("confirm").(bool) {
+		return NewResourceError("decommission not confirmed", pool, errors.New("confirm must be set to true"))
+	}
+
+	log.Printf("[DEBUG] Starting decommission of pool %s", pool)
+
+	if err := minioAdmin.DecommissionPool(ctx, pool); err != nil {
+		return NewResourceError("unable to start pool decommission", pool, err)
+	}
+
+	d.SetId(pool)
+
+	waitCtx, cancel := context.WithTimeout(ctx, d.Timeout(schema.TimeoutCreate))
+	defer cancel()
+
+	if _, err := waitForPoolDecommission(waitCtx, minioAdmin, pool, poolDecommissionPollInterval); err != nil {
+		return NewResourceError("pool decommission failed", pool, err)
+	}
+
+	log.Printf("[DEBUG] Decommission of pool %s completed", pool)
+
+	return minioReadPoolDecommission(ctx, d, meta)
+}
+
+func minioReadPoolDecommission(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return readPoolDecommission(ctx, meta.(*S3MinioClient).S3Admin, d)
+}
+
+func readPoolDecommission(ctx context.Context, client minioPoolDecommissioner, d *schema.ResourceData) diag.Diagnostics {
+	status, err := client.StatusPool(ctx, d.Id())
+	if err != nil {
+		if isPoolNotFoundError(err) {
+			log.Printf("[WARN] Pool %s is no longer part of the deployment, removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return NewResourceError("unable to read pool status", d.Id(), err)
+	}
+
+	// the state is kept when the server reports no decommission, e.g. after it was canceled, so that the plan
+	// does not start a new one
+	_ = d.Set("pool", d.Id())
+	if status.Decommission == nil {
+		log.Printf("[WARN] Pool %s reports no decommission", d.Id())
+		_ = d.Set("complete", false)
+		return nil
+	}
+
+	_ = d.Set("complete", status.Decommission.Complete)
+	_ = d.Set("objects_decommissioned", status.Decommission.ObjectsDecommissioned)
+	_ = d.Set("bytes_decommissioned", status.Decommission.BytesDone)
+
+	return nil
+}
+
+// isPoolNotFoundError reports whether err says the pool is not part of the deployment, as once it is removed from
+// the server command line after its decommission
+func isPoolNotFoundError(err error) bool {
+	return isNotFoundError(err) || madmin.ToErrorResponse(err).Code == "XMinioAdminInvalidArgument"
+}
+
+func minioDeletePoolDecommission(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("[DEBUG] Removing decommission of pool %s from state, the pool itself is left untouched", d.Id())
+
+	d.SetId("")
+
+	return nil
+}
+
+// waitForPoolDecommission polls the pool status until the decommission completes, fails or the context expires
+func waitForPoolDecommission(ctx context.Context, client minioPoolDecommissioner, pool string, interval time.Duration) (madmin.PoolStatus, error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		status, err := client.StatusPool(ctx, pool)
+		if err != nil {
+			return status, err
+		}
+
+		if info := status.Decommission; info != nil {
+			switch {
+			case info.Complete:
+				return status, nil
+			case info.Failed:
+				return status, fmt.Errorf("decommission of pool %s failed", pool)
+			case info.Canceled:
+				return status, fmt.Errorf("decommission of pool %s was canceled", pool)
+			}
+			log.Printf("[DEBUG] Decommission of pool %s in progress: %d objects moved", pool, info.ObjectsDecommissioned)
+		}
+
+		select {
+		case <-ctx.Done():
+			return status, fmt.Errorf("timeout while waiting for decommission of pool %s: %w", pool, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
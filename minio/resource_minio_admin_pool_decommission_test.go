@@ -0,0 +1,153 @@
+package minio
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/minio/madmin-go/v3"
+)
+
+type fakePoolDecommissioner struct {
+	statuses []*madmin.PoolDecommissionInfo
+	err      error
+	calls    int
+}
+
+func (f *fakePoolDecommissioner) DecommissionPool(ctx context.Context, pool string) error {
+	return nil
+}
+
+func (f *fakePoolDecommissioner) StatusPool(ctx context.Context, pool string) (madmin.PoolStatus, error) {
+	if f.err != nil {
+		return madmin.PoolStatus{}, f.err
+	}
+	info := f.statuses[len(f.statuses)-1]
+	if f.calls < len(f.statuses) {
+		info = f.statuses[f.calls]
+	}
+	f.calls++
+	return madmin.PoolStatus{CmdLine: pool, Decommission: info}, nil
+}
+
+func TestWaitForPoolDecommission(t *testing.T) {
+	client := &fakePoolDecommissioner{
+		statuses: []*madmin.PoolDecommissionInfo{
+			{ObjectsDecommissioned: 10},
+			{ObjectsDecommissioned: 50},
+			{ObjectsDecommissioned: 100, Complete: true},
+		},
+	}
+
+	status, err := waitForPoolDecommission(context.Background(), client, "http://server{1...4}/disk{1...4}", time.Millisecond)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !status.Decommission.Complete {
+		t.Fatalf("decommission should be complete")
+	}
+	if client.calls != 3 {
+		t.Fatalf("expected 3 status calls, got %d", client.calls)
+	}
+}
+
+func TestWaitForPoolDecommission_failed(t *testing.T) {
+	client := &fakePoolDecommissioner{
+		statuses: []*madmin.PoolDecommissionInfo{
+			{ObjectsDecommissioned: 10},
+			{Failed: true},
+		},
+	}
+
+	_, err := waitForPoolDecommission(context.Background(), client, "pool", time.Millisecond)
+	if err == nil || !strings.Contains(err.Error(), "failed") {
+		t.Fatalf("expected failure, got %v", err)
+	}
+}
+
+func TestWaitForPoolDecommission_timeout(t *testing.T) {
+	client := &fakePoolDecommissioner{
+		statuses: []*madmin.PoolDecommissionInfo{
+			{ObjectsDecommissioned: 10},
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err := waitForPoolDecommission(ctx, client, "pool", time.Millisecond)
+	if err == nil || !strings.Contains(err.Error(), "timeout") {
+		t.Fatalf("expected timeout, got %v", err)
+	}
+}
+
+func TestValidatePoolDecommissionConfirm(t *testing.T) {
+	if diags := validatePoolDecommissionConfirm(false, nil); !diags.HasError() {
+		t.Fatalf("confirm = false should be rejected")
+	}
+	if diags := validatePoolDecommissionConfirm(true, nil); diags.HasError() {
+		t.Fatalf("confirm = true should be accepted: %v", diags)
+	}
+}
+
+func TestReadPoolDecommission(t *testing.T) {
+	pool := "http://server{1...4}/disk{1...4}"
+
+	for name, tc := range map[string]struct {
+		client   *fakePoolDecommissioner
+		err      string
+		removed  bool
+		complete bool
+	}{
+		"complete": {
+			client:   &fakePoolDecommissioner{statuses: []*madmin.PoolDecommissionInfo{{ObjectsDecommissioned: 100, Complete: true}}},
+			complete: true,
+		},
+		"no decommission": {
+			client: &fakePoolDecommissioner{statuses: []*madmin.PoolDecommissionInfo{nil}},
+		},
+		"pool removed": {
+			client:  &fakePoolDecommissioner{err: madmin.ErrorResponse{Code: "XMinioAdminInvalidArgument"}},
+			removed: true,
+		},
+		"server error": {
+			client: &fakePoolDecommissioner{err: madmin.ErrorResponse{Code: "InternalError", Message: "We encountered an internal error"}},
+			err:    "unable to read pool status",
+		},
+		"unreachable": {
+			client: &fakePoolDecommissioner{err: errors.New("connection refused")},
+			err:    "unable to read pool status",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resourceMinioAdminPoolDecommission().Schema, map[string]interface{}{
+				"pool":    pool,
+				"confirm": true,
+			})
+			d.SetId(pool)
+
+			diags := readPoolDecommission(context.Background(), tc.client, d)
+			if tc.err != "" {
+				if !diags.HasError() || !strings.Contains(diags[0].Summary, tc.err) {
+					t.Fatalf("expected an error containing %q, got %v", tc.err, diags)
+				}
+				if d.Id() == "" {
+					t.Fatalf("the state should be kept on errors")
+				}
+				return
+			}
+			if diags.HasError() {
+				t.Fatalf("unexpected errors: %v", diags)
+			}
+			if removed := d.Id() == ""; removed != tc.removed {
+				t.Fatalf("expected removed from state %t, got %t", tc.removed, removed)
+			}
+			if !tc.removed && d.Get("complete").(bool) != tc.complete {
+				t.Fatalf("expected complete %t, got %t", tc.complete, d.Get("complete").(bool))
+			}
+		})
+	}
+}
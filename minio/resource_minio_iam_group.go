@@ -4,13 +4,17 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"reflect"
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/minio/madmin-go/v3"
+	"golang.org/x/exp/slices"
 )
 
 var (
@@ -24,6 +28,7 @@ func resourceMinioIAMGroup() *schema.Resource {
 		ReadContext:   minioReadGroup,
 		UpdateContext: minioUpdateGroup,
 		DeleteContext: minioDeleteGroup,
+		CustomizeDiff: minioIAMGroupStatusDiff,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -50,6 +55,27 @@ func resourceMinioIAMGroup() *schema.Resource {
 				Default:     false,
 				Description: "Disable group",
 			},
+			"status": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ValidateFunc:  validation.StringInSlice([]string{string(madmin.GroupEnabled), string(madmin.GroupDisabled)}, false),
+				ConflictsWith: []string{"disable_group"},
+				Description:   "Status of the group, either enabled or disabled. Defaults to the status implied by disable_group",
+			},
+			"policy": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: suppressEquivalentGroupPolicies,
+				Description:      "Comma separated names of the policies attached to the group. Policies are not managed when unset",
+			},
+			"members": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
+				Description: "Users that are members of the group. Members are not managed when unset",
+			},
 		},
 	}
 }
@@ -60,13 +86,13 @@ func minioCreateGroup(ctx context.Context, d *schema.ResourceData, meta interfac
 
 	groupAddRemove := madmin.GroupAddRemove{
 		Group:    iamGroupConfig.MinioIAMName,
-		Members:  []string{},
+		Members:  aws.StringValueSlice(getStringList(d.Get("members").(*schema.Set).List())),
 		IsRemove: false,
 	}
 
 	err := iamGroupConfig.MinioAdmin.UpdateGroupMembers(ctx, groupAddRemove)
 	if err != nil {
-		return NewResourceError("creating group failed", d.Id(), err)
+		return NewResourceError("creating group failed", iamGroupConfig.MinioIAMName, err)
 	}
 
 	err = minioStatusGroup(ctx, d, meta)
@@ -76,6 +102,12 @@ func minioCreateGroup(ctx context.Context, d *schema.ResourceData, meta interfac
 
 	d.SetId(aws.StringValue(&iamGroupConfig.MinioIAMName))
 
+	if iamGroupConfig.MinioPolicy != "" {
+		if err := minioSetGroupPolicy(ctx, iamGroupConfig); err != nil {
+			return NewResourceError("error attaching policy to IAM Group", d.Id(), err)
+		}
+	}
+
 	return minioReadGroup(ctx, d, meta)
 }
 
@@ -102,11 +134,23 @@ func minioUpdateGroup(ctx context.Context, d *schema.ResourceData, meta interfac
 		d.SetId(nn.(string))
 	}
 
+	if d.HasChange("members") {
+		if err := minioUpdateGroupMembers(ctx, d, iamGroupConfig); err != nil {
+			return NewResourceError("error updating IAM Group members", d.Id(), err)
+		}
+	}
+
 	err := minioStatusGroup(ctx, d, meta)
 	if err != nil {
 		return NewResourceError("error updating IAM Group %s: %s", d.Id(), err)
 	}
 
+	if d.HasChange("policy") {
+		if err := minioSetGroupPolicy(ctx, iamGroupConfig); err != nil {
+			return NewResourceError("error updating IAM Group policy", d.Id(), err)
+		}
+	}
+
 	if iamGroupConfig.MinioForceDestroy {
 		err := minioDeleteGroup(ctx, d, meta)
 		if err != nil {
@@ -137,10 +181,23 @@ func minioReadGroup(ctx context.Context, d *schema.ResourceData, meta interface{
 		return NewResourceError("error reading IAM Group %s: %s", d.Id(), err)
 	}
 
-	if err := d.Set("disable_group", output.Status == string(madmin.GroupDisabled)); err != nil {
+	if err := d.Set("status", output.Status); err != nil {
 		return NewResourceError("error reading IAM Group %s: %s", d.Id(), err)
 	}
 
+	// the policies of disabled groups are not reported, the configured ones are kept
+	if minioGroupPolicyManaged(d) && output.Status != string(madmin.GroupDisabled) {
+		if err := d.Set("policy", output.Policy); err != nil {
+			return NewResourceError("error reading IAM Group %s: %s", d.Id(), err)
+		}
+	}
+
+	if minioGroupMembersManaged(d) {
+		if err := d.Set("members", output.Members); err != nil {
+			return NewResourceError("error reading IAM Group %s: %s", d.Id(), err)
+		}
+	}
+
 	return nil
 }
 
@@ -163,6 +220,15 @@ func minioDeleteGroup(ctx context.Context, d *schema.ResourceData, meta interfac
 		return nil
 	}
 
+	// members managed by this resource are removed first, so the group can be deleted
+	if members := minioGroupManagedMembers(d, groupDesc.Members); len(members) > 0 {
+		log.Printf("[DEBUG] Removing members %v of IAM Group %s", members, d.Id())
+		if err := deleteMinioGroup(ctx, iamGroupConfig, members); err != nil {
+			return NewResourceError("error removing members of IAM Group %s: %s", d.Id(), err)
+		}
+		groupDesc.Members = minioGroupRemainingMembers(groupDesc.Members, members)
+	}
+
 	if len(groupDesc.Policy) == 0 {
 		//delete group requires to set policy if it doesn't exist
 		_ = iamGroupConfig.MinioAdmin.SetPolicy(ctx, "readonly", d.Id(), true)
@@ -200,7 +266,7 @@ func minioStatusGroup(ctx context.Context, d *schema.ResourceData, meta interfac
 
 	log.Println("[DEBUG] Disabling IAM Group request:", iamGroupConfig.MinioIAMName)
 
-	if iamGroupConfig.MinioDisableGroup {
+	if iamGroupConfig.MinioStatus == madmin.GroupDisabled {
 		minioGroupStatus = madmin.GroupDisabled
 	} else {
 		minioGroupStatus = madmin.GroupEnabled
@@ -232,6 +298,121 @@ func deleteMinioGroup(ctx context.Context, iamGroupConfig *S3MinioIAMGroupConfig
 	return nil
 }
 
+// minioIAMGroupStatusDiff plans the status implied by disable_group when status is not set explicitly,
+// so that toggling disable_group or a status changed outside of Terraform shows up as an in-place update.
+func minioIAMGroupStatusDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	rawConfig := d.GetRawConfig()
+	if rawConfig.IsNull() || !rawConfig.GetAttr("status").IsNull() {
+		return nil
+	}
+
+	status := madmin.GroupEnabled
+	if d.Get("disable_group").(bool) {
+		status = madmin.GroupDisabled
+	}
+
+	if d.Get("status").(string) != string(status) {
+		return d.SetNew("status", string(status))
+	}
+
+	return nil
+}
+
+// minioSetGroupPolicy attaches the configured policies to the group, replacing the attached ones.
+// An empty policy detaches all of them.
+func minioSetGroupPolicy(ctx context.Context, iamGroupConfig *S3MinioIAMGroupConfig) error {
+	groupPolicyAttachmentLock.Lock(iamGroupConfig.MinioIAMName)
+	defer groupPolicyAttachmentLock.Unlock(iamGroupConfig.MinioIAMName)
+
+	log.Printf("[DEBUG] Setting policy of IAM Group %s to %q", iamGroupConfig.MinioIAMName, iamGroupConfig.MinioPolicy)
+	return iamGroupConfig.MinioAdmin.SetPolicy(ctx, iamGroupConfig.MinioPolicy, iamGroupConfig.MinioIAMName, true)
+}
+
+// minioGroupPolicyManaged reports whether the policies of the group are managed by the resource, so groups
+// whose policies are attached with minio_iam_group_policy_attachment don't show a diff
+func minioGroupPolicyManaged(d *schema.ResourceData) bool {
+	if d.Get("policy").(string) != "" {
+		return true
+	}
+	config := d.GetRawConfig()
+	return !config.IsNull() && config.IsKnown() && !config.GetAttr("policy").IsNull()
+}
+
+// suppressEquivalentGroupPolicies ignores the order of the comma separated policy names
+func suppressEquivalentGroupPolicies(k, old, new string, d *schema.ResourceData) bool {
+	oldPolicies := strings.Split(old, ",")
+	newPolicies := strings.Split(new, ",")
+	sort.Strings(oldPolicies)
+	sort.Strings(newPolicies)
+	return reflect.DeepEqual(oldPolicies, newPolicies)
+}
+
+// minioGroupMembersManaged reports whether the members of the group are managed by the resource, so groups
+// whose members are set with minio_iam_group_membership or minio_iam_group_user_attachment don't show a diff
+func minioGroupMembersManaged(d *schema.ResourceData) bool {
+	if d.Get("members").(*schema.Set).Len() > 0 {
+		return true
+	}
+	config := d.GetRawConfig()
+	return !config.IsNull() && config.IsKnown() && !config.GetAttr("members").IsNull()
+}
+
+// minioUpdateGroupMembers adds and removes the members that changed in the configuration
+func minioUpdateGroupMembers(ctx context.Context, d *schema.ResourceData, iamGroupConfig *S3MinioIAMGroupConfig) error {
+	o, n := d.GetChange("members")
+	os := o.(*schema.Set)
+	ns := n.(*schema.Set)
+
+	if usersToAdd := aws.StringValueSlice(getStringList(ns.Difference(os).List())); len(usersToAdd) > 0 {
+		log.Printf("[DEBUG] Adding members %v to IAM Group %s", usersToAdd, d.Id())
+		err := iamGroupConfig.MinioAdmin.UpdateGroupMembers(ctx, madmin.GroupAddRemove{
+			Group:    d.Id(),
+			Members:  usersToAdd,
+			IsRemove: false,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	// removing an empty member list deletes the group, so only call it with members to remove
+	if usersToRemove := aws.StringValueSlice(getStringList(os.Difference(ns).List())); len(usersToRemove) > 0 {
+		log.Printf("[DEBUG] Removing members %v from IAM Group %s", usersToRemove, d.Id())
+		err := iamGroupConfig.MinioAdmin.UpdateGroupMembers(ctx, madmin.GroupAddRemove{
+			Group:    d.Id(),
+			Members:  usersToRemove,
+			IsRemove: true,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// minioGroupManagedMembers returns the current members of the group that are managed by the resource
+func minioGroupManagedMembers(d *schema.ResourceData, current []string) []string {
+	managed := d.Get("members").(*schema.Set)
+	var members []string
+	for _, member := range current {
+		if managed.Contains(member) {
+			members = append(members, member)
+		}
+	}
+	return members
+}
+
+func minioGroupRemainingMembers(current []string, removed []string) []string {
+	var remaining []string
+	for _, member := range current {
+		if !slices.Contains(removed, member) {
+			remaining = append(remaining, member)
+		}
+	}
+	return remaining
+}
+
 func validateMinioIamGroupName(v interface{}, k string) (ws []string, errors []error) {
 	value := v.(string)
 	if !StaticGroupNamePattern.MatchString(value) && !LDAPGroupDistinguishedNamePattern.MatchString(value) {
@@ -3,6 +3,8 @@ package minio
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"sort"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
@@ -172,3 +174,181 @@ func testAccCheckMinioGroupDisable(group *madmin.GroupDesc, name string, status
 		return nil
 	}
 }
+
+func TestAccAWSGroup_Members(t *testing.T) {
+	var conf madmin.GroupDesc
+
+	groupName := fmt.Sprintf("tf-acc-group-members-%d", acctest.RandInt())
+	userName := fmt.Sprintf("tf-acc-user-members-%d", acctest.RandInt())
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckMinioGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMinioGroupConfigMembers(groupName, userName, `[minio_iam_user.test[0].name]`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMinioGroupExists("minio_iam_group.test", &conf),
+					testAccCheckMinioGroupMembers(&conf, userName+"-0"),
+					resource.TestCheckResourceAttr("minio_iam_group.test", "members.#", "1"),
+				),
+			},
+			{
+				Config: testAccMinioGroupConfigMembers(groupName, userName, `minio_iam_user.test[*].name`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMinioGroupExists("minio_iam_group.test", &conf),
+					testAccCheckMinioGroupMembers(&conf, userName+"-0", userName+"-1"),
+					resource.TestCheckResourceAttr("minio_iam_group.test", "members.#", "2"),
+				),
+			},
+			{
+				Config: testAccMinioGroupConfigMembers(groupName, userName, `[minio_iam_user.test[1].name]`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMinioGroupExists("minio_iam_group.test", &conf),
+					testAccCheckMinioGroupMembers(&conf, userName+"-1"),
+					resource.TestCheckResourceAttr("minio_iam_group.test", "members.#", "1"),
+				),
+			},
+			{
+				Config: testAccMinioGroupConfigMembers(groupName, userName, `[minio_iam_user.test[1].name]`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMinioGroupDeleted(groupName),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testAccMinioGroupConfigMembers(groupName string, userName string, members string) string {
+	return fmt.Sprintf(`
+resource "minio_iam_user" "test" {
+  count = 2
+  name  = "%s-${count.index}"
+}
+
+resource "minio_iam_group" "test" {
+  name    = "%s"
+  members = %s
+}
+`, userName, groupName, members)
+}
+
+func testAccCheckMinioGroupMembers(group *madmin.GroupDesc, members ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		got := append([]string{}, group.Members...)
+		sort.Strings(got)
+		sort.Strings(members)
+		if !reflect.DeepEqual(got, members) {
+			return fmt.Errorf("bad members: expected %v, got %v", members, got)
+		}
+		return nil
+	}
+}
+
+// testAccCheckMinioGroupDeleted deletes the group outside of Terraform, which should plan to create it again
+func testAccCheckMinioGroupDeleted(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		minioIam := testAccProvider.Meta().(*S3MinioClient).S3Admin
+
+		resp, err := minioIam.GetGroupDescription(context.Background(), name)
+		if err != nil {
+			return err
+		}
+
+		if len(resp.Members) > 0 {
+			err := minioIam.UpdateGroupMembers(context.Background(), madmin.GroupAddRemove{Group: name, Members: resp.Members, IsRemove: true})
+			if err != nil {
+				return err
+			}
+		}
+
+		return minioIam.UpdateGroupMembers(context.Background(), madmin.GroupAddRemove{Group: name, Members: []string{}, IsRemove: true})
+	}
+}
+
+func testAccCheckMinioGroupDestroy(s *terraform.State) error {
+	minioIam := testAccProvider.Meta().(*S3MinioClient).S3Admin
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "minio_iam_group" {
+			continue
+		}
+
+		if _, err := minioIam.GetGroupDescription(context.Background(), rs.Primary.ID); err == nil {
+			return fmt.Errorf("group %s still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func TestAccAWSGroup_StatusAndPolicy(t *testing.T) {
+	var conf madmin.GroupDesc
+
+	groupName := fmt.Sprintf("tf-acc-group-status-%d", acctest.RandInt())
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckMinioGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMinioGroupConfigStatusAndPolicy(groupName, "disabled", "readonly"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMinioGroupExists("minio_iam_group.test", &conf),
+					testAccCheckMinioGroupAttributes(&conf, groupName, "disabled"),
+					resource.TestCheckResourceAttr("minio_iam_group.test", "policy", "readonly"),
+					resource.TestCheckResourceAttr("minio_iam_group.test", "status", "disabled"),
+				),
+			},
+			{
+				Config: testAccMinioGroupConfigStatusAndPolicy(groupName, "enabled", "readonly,writeonly"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMinioGroupExists("minio_iam_group.test", &conf),
+					testAccCheckMinioGroupAttributes(&conf, groupName, "enabled"),
+					testAccCheckMinioGroupPolicy(&conf, "readonly,writeonly"),
+					resource.TestCheckResourceAttr("minio_iam_group.test", "status", "enabled"),
+				),
+			},
+			{
+				Config:   testAccMinioGroupConfigStatusAndPolicy(groupName, "enabled", "writeonly,readonly"),
+				PlanOnly: true,
+			},
+			{
+				Config: testAccMinioGroupConfig(groupName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMinioGroupExists("minio_iam_group.test", &conf),
+					testAccCheckMinioGroupAttributes(&conf, groupName, "enabled"),
+					testAccCheckMinioGroupPolicy(&conf, ""),
+				),
+			},
+			{
+				ResourceName:            "minio_iam_group.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"disable_group", "force_destroy", "name", "policy"},
+			},
+		},
+	})
+}
+
+func testAccMinioGroupConfigStatusAndPolicy(groupName string, status string, policy string) string {
+	return fmt.Sprintf(`
+resource "minio_iam_group" "test" {
+  name   = "%s"
+  status = "%s"
+  policy = "%s"
+}
+`, groupName, status, policy)
+}
+
+func testAccCheckMinioGroupPolicy(group *madmin.GroupDesc, policy string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		if group.Policy != policy {
+			return fmt.Errorf("bad policy: expected %q, got %q", policy, group.Policy)
+		}
+		return nil
+	}
+}
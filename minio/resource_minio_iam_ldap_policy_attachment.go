@@ -0,0 +1,195 @@
+package minio
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/minio/madmin-go/v3"
+)
+
+var ldapPolicyAttachmentLock = NewMutexKV()
+
+func resourceMinioIAMLDAPPolicyAttachment() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: minioCreateLDAPPolicyAttachment,
+		ReadContext:   minioReadLDAPPolicyAttachment,
+		DeleteContext: minioDeleteLDAPPolicyAttachment,
+		Description:   "`minio_iam_ldap_policy_attachment` attaches a policy to an LDAP user or group distinguished name (DN).",
+		Schema: map[string]*schema.Schema{
+			"policy_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateIAMNamePolicy,
+			},
+			"user_dn": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"user_dn", "group_dn"},
+				ValidateFunc: validateMinioLDAPUserDN,
+				Description:  "Distinguished name of the LDAP user the policy is attached to",
+			},
+			"group_dn": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"user_dn", "group_dn"},
+				ValidateFunc: validateMinioLDAPGroupDN,
+				Description:  "Distinguished name of the LDAP group the policy is attached to",
+			},
+		},
+	}
+}
+
+func minioCreateLDAPPolicyAttachment(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	minioAdmin := meta.(*S3MinioClient).S3Admin
+
+	var policyName = d.Get("policy_name").(string)
+	userDN, groupDN, entity := ldapPolicyAttachmentEntity(d)
+
+	ldapPolicyAttachmentLock.Lock(entity)
+	defer ldapPolicyAttachmentLock.Unlock(entity)
+
+	policies, err := minioReadLDAPPolicies(ctx, minioAdmin, userDN, groupDN)
+	if err != nil {
+		return err
+	}
+	if !Contains(policies, policyName) {
+		log.Printf("[DEBUG] Attaching policy %s to LDAP entity: %s", policyName, entity)
+		_, errAttach := minioAdmin.AttachPolicyLDAP(ctx, madmin.PolicyAssociationReq{
+			Policies: []string{policyName},
+			User:     userDN,
+			Group:    groupDN,
+		})
+		if errAttach != nil {
+			return NewResourceError("unable to attach LDAP policy", entity+" "+policyName, errAttach)
+		}
+	}
+
+	d.SetId(id.PrefixedUniqueId(fmt.Sprintf("%s-", policyName)))
+
+	return doMinioReadLDAPPolicyAttachment(ctx, d, meta, userDN, groupDN, policyName)
+}
+
+func minioReadLDAPPolicyAttachment(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var policyName = d.Get("policy_name").(string)
+	userDN, groupDN, entity := ldapPolicyAttachmentEntity(d)
+
+	ldapPolicyAttachmentLock.Lock(entity)
+	defer ldapPolicyAttachmentLock.Unlock(entity)
+
+	return doMinioReadLDAPPolicyAttachment(ctx, d, meta, userDN, groupDN, policyName)
+}
+
+func doMinioReadLDAPPolicyAttachment(ctx context.Context, d *schema.ResourceData, meta interface{}, userDN, groupDN, policyName string) diag.Diagnostics {
+	minioAdmin := meta.(*S3MinioClient).S3Admin
+
+	policies, err := minioReadLDAPPolicies(ctx, minioAdmin, userDN, groupDN)
+	if err != nil {
+		return err
+	}
+
+	if !Contains(policies, policyName) {
+		log.Printf("[WARN] No such LDAP policy attachment (%s) found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if err := d.Set("policy_name", policyName); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func minioDeleteLDAPPolicyAttachment(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	minioAdmin := meta.(*S3MinioClient).S3Admin
+
+	var policyName = d.Get("policy_name").(string)
+	userDN, groupDN, entity := ldapPolicyAttachmentEntity(d)
+
+	ldapPolicyAttachmentLock.Lock(entity)
+	defer ldapPolicyAttachmentLock.Unlock(entity)
+
+	policies, err := minioReadLDAPPolicies(ctx, minioAdmin, userDN, groupDN)
+	if err != nil {
+		return err
+	}
+
+	if !Contains(policies, policyName) {
+		return nil
+	}
+
+	log.Printf("[DEBUG] Detaching policy %s from LDAP entity: %s", policyName, entity)
+	_, errDetach := minioAdmin.DetachPolicyLDAP(ctx, madmin.PolicyAssociationReq{
+		Policies: []string{policyName},
+		User:     userDN,
+		Group:    groupDN,
+	})
+	if errDetach != nil {
+		return NewResourceError("unable to detach LDAP policy", entity, errDetach)
+	}
+
+	return nil
+}
+
+// ldapPolicyAttachmentEntity returns the configured DNs along with the one in use, which is used as lock key
+func ldapPolicyAttachmentEntity(d *schema.ResourceData) (userDN, groupDN, entity string) {
+	userDN = d.Get("user_dn").(string)
+	groupDN = d.Get("group_dn").(string)
+	entity = userDN
+	if entity == "" {
+		entity = groupDN
+	}
+	return
+}
+
+func minioReadLDAPPolicies(ctx context.Context, minioAdmin *madmin.AdminClient, userDN, groupDN string) ([]string, diag.Diagnostics) {
+	query := madmin.PolicyEntitiesQuery{}
+	if userDN != "" {
+		query.Users = []string{userDN}
+	} else {
+		query.Groups = []string{groupDN}
+	}
+
+	entities, err := minioAdmin.GetLDAPPolicyEntities(ctx, query)
+	if err != nil {
+		return nil, NewResourceError("failed to load LDAP policy entities", userDN+groupDN, err)
+	}
+
+	var policies []string
+	for _, mapping := range entities.UserMappings {
+		if userDN != "" && strings.EqualFold(mapping.User, userDN) {
+			policies = append(policies, mapping.Policies...)
+		}
+	}
+	for _, mapping := range entities.GroupMappings {
+		if groupDN != "" && strings.EqualFold(mapping.Group, groupDN) {
+			policies = append(policies, mapping.Policies...)
+		}
+	}
+
+	return policies, nil
+}
+
+func validateMinioLDAPUserDN(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if !LDAPUserDistinguishedNamePattern.MatchString(value) {
+		errors = append(errors, fmt.Errorf("%q must be a valid LDAP Distinguished Name (DN): %q", k, value))
+	}
+	return
+}
+
+func validateMinioLDAPGroupDN(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if !LDAPGroupDistinguishedNamePattern.MatchString(value) {
+		errors = append(errors, fmt.Errorf("%q must be a valid LDAP Distinguished Name (DN): %q", k, value))
+	}
+	return
+}
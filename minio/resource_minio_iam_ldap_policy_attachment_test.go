@@ -0,0 +1,78 @@
+package minio
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestValidateMinioLDAPDN(t *testing.T) {
+	validUserDNs := []string{
+		"cn=dillon,ou=people,ou=swengg,dc=min,dc=io",
+		"CN=Backup Operators,CN=Builtin,DC=gr-u,DC=it",
+	}
+
+	for _, dn := range validUserDNs {
+		if _, err := validateMinioLDAPUserDN(dn, "user_dn"); len(err) != 0 {
+			t.Fatalf("%q should be a valid LDAP user DN: %q", dn, err)
+		}
+	}
+
+	validGroupDNs := []string{
+		"ou=swengg,dc=min,dc=io",
+		"cn=project.c,ou=groups,ou=swengg,dc=min,dc=io",
+	}
+
+	for _, dn := range validGroupDNs {
+		if _, err := validateMinioLDAPGroupDN(dn, "group_dn"); len(err) != 0 {
+			t.Fatalf("%q should be a valid LDAP group DN: %q", dn, err)
+		}
+	}
+
+	invalidDNs := []string{
+		"dillon",
+		"test-user",
+		"ou=swengg",
+	}
+
+	for _, dn := range invalidDNs {
+		if _, err := validateMinioLDAPUserDN(dn, "user_dn"); len(err) == 0 {
+			t.Fatalf("%q should be an invalid LDAP user DN", dn)
+		}
+		if _, err := validateMinioLDAPGroupDN(dn, "group_dn"); len(err) == 0 {
+			t.Fatalf("%q should be an invalid LDAP group DN", dn)
+		}
+	}
+}
+
+func TestAccMinioLDAPPolicyAttachment_exactlyOneDN(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccMinioLDAPPolicyAttachmentBothDNs,
+				ExpectError: regexp.MustCompile(`only one of .group_dn,user_dn. can be specified`),
+			},
+			{
+				Config:      testAccMinioLDAPPolicyAttachmentNoDN,
+				ExpectError: regexp.MustCompile(`one of .group_dn,user_dn. must be specified`),
+			},
+		},
+	})
+}
+
+const testAccMinioLDAPPolicyAttachmentBothDNs = `
+resource "minio_iam_ldap_policy_attachment" "test" {
+  policy_name = "readwrite"
+  user_dn     = "cn=dillon,ou=people,ou=swengg,dc=min,dc=io"
+  group_dn    = "ou=swengg,dc=min,dc=io"
+}
+`
+
+const testAccMinioLDAPPolicyAttachmentNoDN = `
+resource "minio_iam_ldap_policy_attachment" "test" {
+  policy_name = "readwrite"
+}
+`
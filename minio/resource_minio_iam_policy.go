@@ -13,8 +13,13 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/minio/minio-go/v7/pkg/set"
 )
 
+// minioBuiltinPolicies are the canned policies every MinIO server ships with
+var minioBuiltinPolicies = set.CreateStringSet("consoleAdmin", "readwrite", "readonly", "writeonly", "diagnostics")
+
 func resourceMinioIAMPolicy() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: minioCreatePolicy,
@@ -22,7 +27,7 @@ func resourceMinioIAMPolicy() *schema.Resource {
 		UpdateContext: minioUpdatePolicy,
 		DeleteContext: minioDeletePolicy,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: minioImportPolicy,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -31,6 +36,7 @@ func resourceMinioIAMPolicy() *schema.Resource {
 				Required:         true,
 				ValidateFunc:     validateIAMPolicyJSON,
 				DiffSuppressFunc: suppressEquivalentAwsPolicyDiffs,
+				Description:      "Policy document in JSON. Malformed statements are rejected, and actions MinIO does not know are reported as warnings",
 			},
 			"name": {
 				Type:          schema.TypeString,
@@ -38,7 +44,7 @@ func resourceMinioIAMPolicy() *schema.Resource {
 				Computed:      true,
 				ForceNew:      true,
 				ConflictsWith: []string{"name_prefix"},
-				ValidateFunc:  validateIAMNamePolicy,
+				ValidateFunc:  validation.All(validateIAMNamePolicy, validateIAMPolicyNotBuiltin),
 			},
 			"name_prefix": {
 				Type:          schema.TypeString,
@@ -77,6 +83,14 @@ func minioCreatePolicy(ctx context.Context, d *schema.ResourceData, meta interfa
 	return minioReadPolicy(ctx, d, meta)
 }
 
+func minioImportPolicy(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if minioBuiltinPolicies.Contains(d.Id()) {
+		return nil, fmt.Errorf("%s is a built-in policy and cannot be managed, use the minio_iam_policy data source to read it", d.Id())
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
 func minioReadPolicy(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
 	iamPolicyConfig := IAMPolicyConfig(d, meta)
@@ -144,6 +158,13 @@ func validateIAMNamePolicy(v interface{}, k string) (ws []string, errors []error
 	return
 }
 
+func validateIAMPolicyNotBuiltin(v interface{}, k string) (ws []string, errors []error) {
+	if minioBuiltinPolicies.Contains(v.(string)) {
+		errors = append(errors, fmt.Errorf("%q is a built-in policy and cannot be managed, use the minio_iam_policy data source to read it", v.(string)))
+	}
+	return
+}
+
 func validateIAMPolicyJSON(v interface{}, k string) (ws []string, errors []error) {
 	value := v.(string)
 	if len(value) < 1 {
@@ -156,6 +177,15 @@ func validateIAMPolicyJSON(v interface{}, k string) (ws []string, errors []error
 	}
 	if _, err := structure.NormalizeJsonString(v); err != nil {
 		errors = append(errors, fmt.Errorf("%q contains an invalid JSON: %s", k, err))
+		return
+	}
+	warnings, err := checkIAMPolicyDocument(value)
+	if err != nil {
+		errors = append(errors, fmt.Errorf("%q contains an invalid policy: %s", k, err))
+		return
+	}
+	for _, warning := range warnings {
+		ws = append(ws, fmt.Sprintf("%q: %s", k, warning))
 	}
 	return
 }
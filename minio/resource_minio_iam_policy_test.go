@@ -101,6 +101,10 @@ func TestAccMinioIAMPolicy_policy(t *testing.T) {
 				Config:      testAccMinioIAMPolicyConfigPolicy(rName1, "not-json"),
 				ExpectError: regexp.MustCompile("invalid JSON"),
 			},
+			{
+				Config:      testAccMinioIAMPolicyConfigPolicy(rName1, "{\"Version\":\"2012-10-17\",\"Statement\":[{\"Effect\":\"Permit\",\"Action\":[\"s3:ListBucket\"]}]}"),
+				ExpectError: regexp.MustCompile("Effect must be"),
+			},
 			{
 				Config: testAccMinioIAMPolicyConfigPolicy(rName1, policy1),
 				Check: resource.ComposeTestCheckFunc(
@@ -124,6 +128,52 @@ func TestAccMinioIAMPolicy_policy(t *testing.T) {
 	})
 }
 
+func TestValidateIAMPolicyJSON(t *testing.T) {
+	valid := map[string]int{
+		`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["s3:GetObject","s3:List*"],"Resource":["arn:aws:s3:::*"]}]}`: 0,
+		`{"Version":"2012-10-17","Statement":{"Effect":"Deny","NotAction":"s3:*","Resource":"*"}}`:                                     0,
+		`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["admin:ServerInfo","kms:*","*"]}]}`:                          0,
+		`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["s3:GetObjects"],"Resource":["arn:aws:s3:::*"]}]}`:           1,
+		`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["s4:GetObject","s3:Foo*"]}]}`:                                2,
+	}
+	for policy, expectedWarnings := range valid {
+		ws, errs := validateIAMPolicyJSON(policy, "policy")
+		if len(errs) != 0 {
+			t.Fatalf("%s: unexpected errors: %v", policy, errs)
+		}
+		if len(ws) != expectedWarnings {
+			t.Fatalf("%s: expected %d warnings, got %v", policy, expectedWarnings, ws)
+		}
+	}
+
+	invalid := []string{
+		`{"Version":"2012-10-17"}`,
+		`{"Version":"2012-10-17","Statement":[{"Action":["s3:GetObject"]}]}`,
+		`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Resource":["arn:aws:s3:::*"]}]}`,
+		`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":[1]}]}`,
+		`{"Version":"2012-10-17","Statement":["s3:GetObject"]}`,
+	}
+	for _, policy := range invalid {
+		if _, errs := validateIAMPolicyJSON(policy, "policy"); len(errs) == 0 {
+			t.Fatalf("%s: expected an error", policy)
+		}
+	}
+}
+
+func TestAccMinioIAMPolicy_builtin(t *testing.T) {
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckMinioIAMPolicyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccMinioIAMPolicyConfigName("readwrite"),
+				ExpectError: regexp.MustCompile("is a built-in policy and cannot be managed"),
+			},
+		},
+	})
+}
+
 func testAccCheckMinioIAMPolicyExists(resource string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[resource]
@@ -11,6 +11,7 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/minio/madmin-go/v3"
 )
 
@@ -28,6 +29,7 @@ func resourceMinioIAMUser() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: minioIAMUserStatusDiff,
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -55,14 +57,19 @@ func resourceMinioIAMUser() *schema.Resource {
 				Description: "Rotate Minio User Secret Key",
 			},
 			"status": {
-				Type:     schema.TypeString,
-				Computed: true,
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ValidateFunc:  validation.StringInSlice([]string{string(madmin.AccountEnabled), string(madmin.AccountDisabled)}, false),
+				ConflictsWith: []string{"disable_user"},
+				Description:   "Status of the user, either enabled or disabled. Defaults to the status implied by disable_user",
 			},
 			"secret": {
-				Type:      schema.TypeString,
-				Computed:  true,
-				Optional:  true,
-				Sensitive: true,
+				Type:        schema.TypeString,
+				Computed:    true,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Secret key of the user, generated when omitted. Changing it updates the secret in place, the user and its access key are kept",
 			},
 			"tags": tagsSchema(),
 		},
@@ -91,7 +98,7 @@ func minioCreateUser(ctx context.Context, d *schema.ResourceData, meta interface
 	d.SetId(aws.StringValue(&accessKey))
 	_ = d.Set("secret", secretKey)
 
-	if iamUserConfig.MinioDisableUser {
+	if iamUserConfig.MinioStatus == madmin.AccountDisabled {
 		err = iamUserConfig.MinioAdmin.SetUserStatus(ctx, accessKey, madmin.AccountDisabled)
 		if err != nil {
 			return NewResourceError("error disabling IAM User %s: %s", d.Id(), err)
@@ -105,10 +112,7 @@ func minioUpdateUser(ctx context.Context, d *schema.ResourceData, meta interface
 
 	iamUserConfig := IAMUserConfig(d, meta)
 
-	wantedStatus := madmin.AccountEnabled
-	if iamUserConfig.MinioDisableUser {
-		wantedStatus = madmin.AccountDisabled
-	}
+	wantedStatus := iamUserConfig.MinioStatus
 
 	if iamUserConfig.MinioForceDestroy {
 		return minioDeleteUser(ctx, d, meta)
@@ -118,7 +122,7 @@ func minioUpdateUser(ctx context.Context, d *schema.ResourceData, meta interface
 	if userServerInfo.Status != wantedStatus {
 		err := iamUserConfig.MinioAdmin.SetUserStatus(ctx, iamUserConfig.MinioIAMName, wantedStatus)
 		if err != nil {
-			return NewResourceError("error to disable IAM User %s: %s", d.Id(), err)
+			return NewResourceError("error updating IAM User status", d.Id(), err)
 		}
 	}
 
@@ -199,6 +203,26 @@ func minioDeleteUser(ctx context.Context, d *schema.ResourceData, meta interface
 	return nil
 }
 
+// minioIAMUserStatusDiff plans the status implied by disable_user when status is not set explicitly,
+// so that toggling disable_user or a status changed outside of Terraform shows up as an in-place update.
+func minioIAMUserStatusDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	rawConfig := d.GetRawConfig()
+	if rawConfig.IsNull() || !rawConfig.GetAttr("status").IsNull() {
+		return nil
+	}
+
+	status := madmin.AccountEnabled
+	if d.Get("disable_user").(bool) {
+		status = madmin.AccountDisabled
+	}
+
+	if d.Get("status").(string) != string(status) {
+		return d.SetNew("status", string(status))
+	}
+
+	return nil
+}
+
 func validateMinioIamUserName(v interface{}, k string) (ws []string, errors []error) {
 	value := v.(string)
 	if !StaticUserNamePattern.MatchString(value) && !LDAPUserDistinguishedNamePattern.MatchString(value) {
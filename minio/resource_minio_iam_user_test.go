@@ -133,6 +133,49 @@ func TestAccAWSUser_DisableUser(t *testing.T) {
 	})
 }
 
+func TestAccAWSUser_Status(t *testing.T) {
+	var user madmin.UserInfo
+
+	name := fmt.Sprintf("test-user-%d", acctest.RandInt())
+	resourceName := "minio_iam_user.test6"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckMinioUserDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMinioUserConfigStatus(name, "enabled"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMinioUserExists(resourceName, &user),
+					testAccCheckMinioUserAttributes(resourceName, name, "enabled"),
+				),
+			},
+			{
+				Config: testAccMinioUserConfigStatus(name, "disabled"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMinioUserExists(resourceName, &user),
+					testAccCheckMinioUserDisabled(resourceName),
+					testAccCheckMinioUserAttributes(resourceName, name, "disabled"),
+				),
+			},
+			{
+				Config: testAccMinioUserConfigStatus(name, "enabled"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMinioUserExists(resourceName, &user),
+					testAccCheckMinioUserAttributes(resourceName, name, "enabled"),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"secret", "force_destroy", "update_secret", "disable_user"},
+			},
+		},
+	})
+}
+
 func TestAccAWSUser_RotateAccessKey(t *testing.T) {
 	var user madmin.UserInfo
 	var oldAccessKey string
@@ -190,6 +233,7 @@ func TestAccAWSUser_SettingAccessKey(t *testing.T) {
 func TestAccAWSUser_UpdateAccessKey(t *testing.T) {
 	var user madmin.UserInfo
 	var oldAccessKey string
+	var accessKey string
 
 	name := fmt.Sprintf("test-user-%d", acctest.RandInt())
 	resourceName := "minio_iam_user.test5"
@@ -204,6 +248,7 @@ func TestAccAWSUser_UpdateAccessKey(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckMinioUserExists(resourceName, &user),
 					testAccCheckMinioUserExfiltrateAccessKey(resourceName, &oldAccessKey),
+					testAccCheckMinioUserKeepsAccessKey(resourceName, &accessKey),
 					testAccCheckMinioUserCanLogIn(resourceName),
 				),
 			},
@@ -212,6 +257,8 @@ func TestAccAWSUser_UpdateAccessKey(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					testAccCheckMinioUserExists(resourceName, &user),
 					testAccCheckMinioUserRotatesAccessKey(resourceName, &oldAccessKey),
+					testAccCheckMinioUserKeepsAccessKey(resourceName, &accessKey),
+					resource.TestCheckResourceAttr(resourceName, "name", name),
 					testAccCheckMinioUserCanLogIn(resourceName),
 				),
 			},
@@ -288,6 +335,15 @@ func testAccMinioUserConfigDisabled(rName string) string {
 		}`, rName)
 }
 
+func testAccMinioUserConfigStatus(rName string, status string) string {
+	return fmt.Sprintf(`
+	resource "minio_iam_user" "test6" {
+	  name   = %q
+	  status = %q
+	}
+	`, rName, status)
+}
+
 func testAccMinioUserConfigWithoutSecret(rName string) string {
 	return fmt.Sprintf(`
 resource "minio_iam_user" "test3" {
@@ -429,6 +485,23 @@ func testAccCheckMinioUserCanLogIn(n string) resource.TestCheckFunc {
 	}
 }
 
+// testAccCheckMinioUserKeepsAccessKey records the access key of the user on first call, and checks it is unchanged on later calls
+func testAccCheckMinioUserKeepsAccessKey(n string, accessKey *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs := s.RootModule().Resources[n]
+
+		if *accessKey == "" {
+			*accessKey = rs.Primary.ID
+			return nil
+		}
+		if rs.Primary.ID != *accessKey {
+			return fmt.Errorf("access key changed from %s to %s", *accessKey, rs.Primary.ID)
+		}
+
+		return nil
+	}
+}
+
 func testAccCheckMinioUserRotatesAccessKey(n string, oldAccessKey *string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs := s.RootModule().Resources[n]
@@ -2,17 +2,27 @@ package minio
 
 import (
 	"context"
+	"encoding/xml"
+	"errors"
 	"fmt"
-	"log"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/minio/madmin-go/v3"
+	"github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/lifecycle"
 )
 
+var ilmPolicyLock = NewMutexKV()
+
 func resourceMinioILMPolicy() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: minioCreateILMPolicy,
@@ -20,19 +30,85 @@ func resourceMinioILMPolicy() *schema.Resource {
 		DeleteContext: minioDeleteILMPolicy,
 		UpdateContext: minioUpdateILMPolicy,
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: minioImportILMPolicy,
 		},
-		Description: "`minio_ilm_policy` handles lifecycle settings for a given `minio_s3_bucket`.",
+		CustomizeDiff: minioRenderILMPolicy,
+		Description:   "`minio_ilm_policy` handles lifecycle settings for a given `minio_s3_bucket`.",
 		Schema: map[string]*schema.Schema{
 			"bucket": {
-				Type:         schema.TypeString,
-				Required:     true,
-				ForceNew:     true,
-				ValidateFunc: validation.StringLenBetween(0, 63),
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateBucketName,
+			},
+			"manage_existing_rules": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether this resource owns the whole lifecycle configuration of the bucket. When `false`, only the rules declared here (matched by `id`) are managed and any other rule on the bucket is preserved",
+			},
+			"preserve_unmanaged_rules": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "On destroy, only remove the rules this resource wrote (see `managed_rule_ids`) and keep any other rule on the bucket, instead of deleting the whole lifecycle configuration. Always the case when `manage_existing_rules` is `false`",
+			},
+			"managed_rule_ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "IDs of the rules written by the last apply of this resource, or of all the rules of the bucket when imported",
+			},
+			"enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the rules are applied. Set to `false` to pause every rule of the policy while keeping them in the configuration",
+			},
+			"default_expiration": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Description:      "Expiration applied to the rules that do not set their own `expiration`",
+				ValidateDiagFunc: validateILMExpiration,
+			},
+			"default_transition": func() *schema.Schema {
+				s := ilmTransitionSchema()
+				s.Description = "Transition applied to the rules that do not set their own `transition`"
+				return s
+			}(),
+			"arn": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ARN of the bucket the lifecycle configuration applies to, in the form `arn:aws:s3:::<bucket>`",
+			},
+			"rendered_configuration": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Lifecycle configuration XML sent for the rules of this resource, rendered during plan for review. Rules not managed by the resource are not included",
+			},
+			"rule_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of lifecycle rules managed by this resource",
+			},
+			"summary": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Rule IDs of this resource with their primary action, ordered by ID, e.g. `archive: transition to WARM after 30d; expire-7d: expire after 7d`",
 			},
 			"rule": {
-				Type:     schema.TypeList,
-				Required: true,
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Lifecycle rules of the policy. A policy without rules removes the rules it manages from the bucket, so that modules can include rules conditionally",
+				// lifecycle rules are not ordered, so reordering them in the configuration is not a change
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					// an emptied list is not read from the configuration, which keeps the rules of the state
+					if k == "rule.#" && new == "0" {
+						return false
+					}
+					o, n := d.GetChange("rule")
+					return ilmRulesEqualIgnoringOrder(o.([]interface{}), n.([]interface{}))
+				},
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"id": {
@@ -42,31 +118,22 @@ func resourceMinioILMPolicy() *schema.Resource {
 						"expiration": {
 							Type:             schema.TypeString,
 							Optional:         true,
-							Description:      "Value may be duration (5d), date (1970-01-01), or \"DeleteMarker\" to expire delete markers if `noncurrent_version_expiration_days` is used",
+							Description:      "Value may be a duration in whole days (5d), date (1970-01-01) expiring objects at midnight UTC, or \"DeleteMarker\" to expire delete markers, which requires `noncurrent_version_expiration_days` on the same rule",
 							ValidateDiagFunc: validateILMExpiration,
 						},
-
-						"transition": {
-							Type:     schema.TypeList,
-							MaxItems: 1,
+						"expire_delete_marker": {
+							Type:     schema.TypeBool,
 							Optional: true,
-							Elem: &schema.Resource{
-								Schema: map[string]*schema.Schema{
-									"days": {
-										Type:     schema.TypeString,
-										Optional: true,
-									},
-									"date": {
-										Type:     schema.TypeString,
-										Optional: true,
-									},
-									"storage_class": {
-										Type:     schema.TypeString,
-										Required: true,
-									},
-								},
-							},
+							Description: "Remove delete markers once no noncurrent version is left behind them, like `expiration = \"DeleteMarker\"`. " +
+								"Requires `noncurrent_version_expiration_days` on the same rule. MinIO rejects it next to a day or date based `expiration`, " +
+								"expire current versions in a separate rule instead",
 						},
+						"expire_all_versions": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Description: "Also expire noncurrent versions after the same number of days as the day based `expiration`, instead of setting `noncurrent_version_expiration_days`",
+						},
+						"transition": ilmTransitionSchema(),
 						"noncurrent_version_expiration_days": {
 							Type:             schema.TypeInt,
 							Optional:         true,
@@ -77,17 +144,75 @@ func resourceMinioILMPolicy() *schema.Resource {
 							Optional:         true,
 							ValidateDiagFunc: validateILMNoncurrentVersionTransition,
 						},
+						"noncurrent_version_transition_storage_class": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Name of the remote tier noncurrent versions are transitioned to. Defaults to the `storage_class` of the rule's transition",
+						},
+						"noncurrent_version_transition_newer_versions": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							ValidateFunc: validation.IntAtLeast(1),
+							Description:  "Number of the most recent noncurrent versions kept on the bucket, only older noncurrent versions are transitioned after `noncurrent_version_transition_days`. Servers ignoring it, as MinIO up to at least RELEASE.2023-08-31, are reported with a warning",
+						},
 						"status": {
 							Type:     schema.TypeString,
 							Computed: true,
 						},
 						"filter": {
-							Type:     schema.TypeString,
-							Optional: true,
+							Type:       schema.TypeString,
+							Optional:   true,
+							Deprecated: "use the `rule_filter` block and its `prefix` attribute instead",
 						},
 						"tags": {
-							Type:     schema.TypeMap,
+							Type:             schema.TypeMap,
+							Optional:         true,
+							Deprecated:       "use the `rule_filter` block and its `tags` attribute instead",
+							DiffSuppressFunc: suppressILMTagWhitespace,
+						},
+						"prefixes": {
+							Type:     schema.TypeList,
 							Optional: true,
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringIsNotEmpty,
+							},
+							Description: "Prefixes the rule applies to, objects matching any of them are selected. Since lifecycle rules only take one prefix, " +
+								"the rule is written as one lifecycle rule per prefix with the same actions and the position of the prefix appended to its ID (`<id>-1`, `<id>-2`, ...). " +
+								"Conflicts with `filter` and the `prefix` of `rule_filter`",
+						},
+						"rule_filter": {
+							Type:        schema.TypeList,
+							Optional:    true,
+							MaxItems:    1,
+							Description: "Objects the rule applies to. All conditions must match",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"prefix": {
+										Type:     schema.TypeString,
+										Optional: true,
+									},
+									"tags": {
+										Type:             schema.TypeMap,
+										Optional:         true,
+										Elem:             &schema.Schema{Type: schema.TypeString},
+										DiffSuppressFunc: suppressILMTagWhitespace,
+										Description:      "Object tags that must all match. A single tag without any other condition is sent as a `Tag` filter, otherwise the conditions are combined under `And`. Whitespace around keys and values is trimmed",
+									},
+									"object_size_greater_than": {
+										Type:         schema.TypeInt,
+										Optional:     true,
+										ValidateFunc: validation.IntAtLeast(0),
+										Description:  "Minimum object size in bytes, exclusive. Must be lower than `object_size_less_than` when both are set",
+									},
+									"object_size_less_than": {
+										Type:         schema.TypeInt,
+										Optional:     true,
+										ValidateFunc: validation.IntAtLeast(0),
+										Description:  "Maximum object size in bytes, exclusive",
+									},
+								},
+							},
 						},
 					},
 				},
@@ -96,12 +221,66 @@ func resourceMinioILMPolicy() *schema.Resource {
 	}
 }
 
+func ilmTransitionSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		MaxItems: 1,
+		Optional: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"days": {
+					Type:             schema.TypeString,
+					Optional:         true,
+					Description:      "Number of days (5d) after which objects are transitioned. A plain number (5) is accepted too and read back as 5d without showing up as a change. Conflicts with `date`, one of them must be set. Must be lower than the days of the expiration of the rule",
+					ValidateDiagFunc: validateILMTransitionDays,
+					DiffSuppressFunc: suppressILMTransitionDays,
+				},
+				"date": {
+					Type:             schema.TypeString,
+					Optional:         true,
+					Description:      "Date (1970-01-01) from which objects are transitioned, at midnight UTC. A date in the past transitions existing objects right away. Conflicts with `days`, one of them must be set",
+					ValidateDiagFunc: validateILMTransitionDate,
+				},
+				"storage_class": {
+					Type:         schema.TypeString,
+					Required:     true,
+					ValidateFunc: validation.StringIsNotEmpty,
+					Description:  "Name of the remote tier objects are transitioned to. When the tier is managed in the same configuration, reference the `name` of its `minio_ilm_tier` resource so it is created first",
+				},
+			},
+		},
+	}
+}
+
 func validateILMExpiration(v interface{}, p cty.Path) (errors diag.Diagnostics) {
 	value := v.(string)
-	exp := parseILMExpiration(value)
+	exp, err := parseILMExpiration(value)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	if (lifecycle.Expiration{}) == exp {
-		return diag.Errorf("expiration must be a duration (5d), date (1970-01-01), or \"DeleteMarker\"")
+		return diag.FromErr(errILMExpirationFormat)
+	}
+
+	return
+}
+
+func validateILMTransitionDays(v interface{}, p cty.Path) (errors diag.Diagnostics) {
+	value := v.(string)
+
+	if _, err := parseILMDays(value); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return
+}
+
+func validateILMTransitionDate(v interface{}, p cty.Path) (errors diag.Diagnostics) {
+	value := v.(string)
+
+	if _, err := parseILMDate(value); err != nil {
+		return diag.Errorf("transition date must be formatted as 1970-01-01")
 	}
 
 	return
@@ -133,136 +312,337 @@ func minioCreateILMPolicy(ctx context.Context, d *schema.ResourceData, meta inte
 	config := lifecycle.NewConfiguration()
 
 	bucket := d.Get("bucket").(string)
-	rules := d.Get("rule").([]interface{})
-	for _, ruleI := range rules {
-		rule := ruleI.(map[string]interface{})
+	rules, diags := ilmPolicyRules(d)
+	if diags.HasError() {
+		return diags
+	}
+	config.Rules = rules
 
-		var filter lifecycle.Filter
+	diags = append(diags, minioCheckILMPolicyTiers(ctx, d, meta.(*S3MinioClient).S3Admin)...)
 
-		noncurrentVersionExpirationDays := lifecycle.NoncurrentVersionExpiration{NoncurrentDays: lifecycle.ExpirationDays(rule["noncurrent_version_expiration_days"].(int))}
-		noncurrentVersionTransitionDays := lifecycle.NoncurrentVersionTransition{NoncurrentDays: lifecycle.ExpirationDays(rule["noncurrent_version_transition_days"].(int))}
-		tags := map[string]string{}
-		for k, v := range rule["tags"].(map[string]interface{}) {
-			tags[k] = v.(string)
-		}
+	ilmPolicyLock.Lock(bucket)
+	defer ilmPolicyLock.Unlock(bucket)
 
-		if len(tags) > 0 {
-			filter.And.Prefix = rule["filter"].(string)
-			for k, v := range tags {
-				filter.And.Tags = append(filter.And.Tags, lifecycle.Tag{Key: k, Value: v})
-			}
-		} else {
-			filter.Prefix = rule["filter"].(string)
-		}
+	existing, err := minioGetBucketLifecycleRules(ctx, c, bucket)
+	if err != nil {
+		return append(diags, NewResourceError("reading existing bucket lifecycle failed", bucket, err)...)
+	}
 
-		r := lifecycle.Rule{
-			ID:                          rule["id"].(string),
-			Expiration:                  parseILMExpiration(rule["expiration"].(string)),
-			Transition:                  parseILMTransition(rule["transition"].([]interface{})),
-			NoncurrentVersionExpiration: noncurrentVersionExpirationDays,
-			NoncurrentVersionTransition: noncurrentVersionTransitionDays,
-			Status:                      "Enabled",
-			RuleFilter:                  filter,
+	managedIDs := ilmPolicyManagedRuleIDs(d)
+	if d.Get("manage_existing_rules").(bool) {
+		for _, r := range existing {
+			if !managedIDs[r.ID] {
+				tflog.Warn(ctx, "Lifecycle rule is not managed by this resource and will be removed", map[string]interface{}{
+					"bucket":  bucket,
+					"rule_id": r.ID,
+				})
+			}
 		}
-
-		config.Rules = append(config.Rules, r)
+	} else {
+		config.Rules = mergeILMRules(existing, config.Rules, managedIDs)
 	}
 
-	if err := c.SetBucketLifecycle(ctx, bucket, config); err != nil {
-		return NewResourceError("creating bucket lifecycle failed", bucket, err)
+	err = retryOnError(ctx, retryTimeout, func() error {
+		return c.SetBucketLifecycle(ctx, bucket, config)
+	})
+	meta.(*S3MinioClient).LifecycleCache.Invalidate(bucket)
+	if err != nil {
+		return append(diags, NewResourceError("creating bucket lifecycle failed", bucket, err)...)
 	}
 
 	d.SetId(bucket)
 
-	return minioReadILMPolicy(ctx, d, meta)
+	if err := d.Set("managed_rule_ids", ilmRuleIDs(rules)); err != nil {
+		return NewResourceError("setting managed_rule_ids failed", bucket, err)
+	}
+
+	return append(diags, minioReadILMPolicy(ctx, d, meta)...)
 }
 
 func minioReadILMPolicy(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	c := meta.(*S3MinioClient).S3Client
+	m := meta.(*S3MinioClient)
 
 	rules := make([]map[string]interface{}, 0)
-	config, err := c.GetBucketLifecycle(ctx, d.Id())
+	config, err := minioGetILMPolicyConfiguration(ctx, m.LifecycleCache, m.S3Client, d.Id())
 	if err != nil {
-		// TODO: distinguish between error and 404 not found
-		log.Println(NewResourceErrorStr("reading lifecycle configuration failed", d.Id(), err))
-		d.SetId("")
-		return nil
+		return NewResourceError("reading lifecycle configuration failed", d.Id(), err)
+	}
+	if config == nil {
+		// a lifecycle configuration removed outside of Terraform, or never written by a policy without rules,
+		// is read as having no rules so that the rules are planned to be written again. The policy is only gone
+		// with its bucket.
+		exists, err := m.S3Client.BucketExists(ctx, d.Id())
+		if err != nil {
+			return NewResourceError("reading bucket failed", d.Id(), err)
+		}
+		if !exists {
+			tflog.Warn(ctx, "Bucket no longer exists, removing from state", map[string]interface{}{"bucket": d.Id()})
+			d.SetId("")
+			return nil
+		}
+		config = lifecycle.NewConfiguration()
+	}
+	// a configuration left without rules is still read, so that the rules are planned to be written again
+	if len(config.Rules) == 0 && len(d.Get("rule").([]interface{})) > 0 {
+		tflog.Warn(ctx, "Lifecycle configuration has no rules", map[string]interface{}{"bucket": d.Id()})
 	}
 
 	if err = d.Set("bucket", d.Id()); err != nil {
 		return NewResourceError("setting bucket failed", d.Id(), err)
 	}
 
+	if err = d.Set("arn", bucketArn(d.Id())); err != nil {
+		return NewResourceError("setting arn failed", d.Id(), err)
+	}
+
+	manageExistingRules := d.Get("manage_existing_rules").(bool)
+	if err = d.Set("manage_existing_rules", manageExistingRules); err != nil {
+		return NewResourceError("setting manage_existing_rules failed", d.Id(), err)
+	}
+	if err = d.Set("preserve_unmanaged_rules", d.Get("preserve_unmanaged_rules").(bool)); err != nil {
+		return NewResourceError("setting preserve_unmanaged_rules failed", d.Id(), err)
+	}
+
+	managedIDs := ilmPolicyManagedRuleIDs(d)
+	legacyFilterRuleIDs := ilmPolicyLegacyFilterRuleIDs(d)
+	priorRules := ilmPolicyRulesByID(d)
+	expandedRules := ilmPolicyExpandedRules(d)
+	expandedPrefixes := map[string]map[int]string{}
+
+	var defaultExpiration string
+	if v := d.Get("default_expiration").(string); v != "" {
+		if exp, err := parseILMExpiration(v); err == nil {
+			defaultExpiration = flattenILMExpiration(exp)
+		}
+	}
+	var defaultTransition []map[string]string
+	if t, err := parseILMTransition(d.Get("default_transition").([]interface{})); err == nil {
+		defaultTransition = flattenILMTransition(t)
+	}
+
+	var diags diag.Diagnostics
+	enabled := false
+	var readRules []lifecycle.Rule
 	for _, r := range config.Rules {
-		var expiration string
+		if !manageExistingRules && !managedIDs[r.ID] {
+			continue
+		}
+		readRules = append(readRules, r)
+		if r.Status == "Enabled" {
+			enabled = true
+		}
 
-		if r.Expiration.DeleteMarker {
-			expiration = "DeleteMarker"
-		} else if r.Expiration.Days != 0 {
-			expiration = fmt.Sprintf("%dd", r.Expiration.Days)
-		} else if !r.Expiration.IsNull() {
-			expiration = r.Expiration.Date.Format("2006-01-02")
+		// the rules written for each prefix of a rule are read back as that rule
+		ruleID := r.ID
+		ruleFilter := flattenILMRuleFilter(r.RuleFilter)
+		expanded, isExpanded := expandedRules[r.ID]
+		if isExpanded {
+			ruleID = expanded.id
+			prefixes, seen := expandedPrefixes[ruleID]
+			if !seen {
+				prefixes = map[int]string{}
+				expandedPrefixes[ruleID] = prefixes
+			}
+			prefixes[expanded.index] = ruleFilter["prefix"].(string)
+			if seen {
+				continue
+			}
+			ruleFilter["prefix"] = ""
 		}
 
-		transitions := make([]map[string]string, 0)
+		priorRule := priorRules[ruleID]
+		rule, ruleDiags := flattenILMRule(ruleID, r, priorRule)
+		diags = append(diags, ruleDiags...)
 
-		if !r.Transition.IsNull() {
-			transition := map[string]string{}
-			if !r.Transition.IsDaysNull() {
-				transition["days"] = fmt.Sprintf("%dd", r.Transition.Days)
-			} else if !r.Transition.IsDateNull() {
-				transition["date"] = r.Transition.Date.Format("2006-01-02")
+		// values inherited from the defaults are not reported on the rules omitting them
+		if priorRule != nil {
+			if priorRule["expiration"].(string) == "" && defaultExpiration != "" && rule["expiration"] == defaultExpiration {
+				rule["expiration"] = ""
+			}
+			if len(priorRule["transition"].([]interface{})) == 0 && len(defaultTransition) > 0 && reflect.DeepEqual(rule["transition"], defaultTransition) {
+				rule["transition"] = make([]map[string]string, 0)
 			}
-			transition["storage_class"] = r.Transition.StorageClass
-			transitions = append(transitions, transition)
+		}
 
+		if legacyFilterRuleIDs[ruleID] {
+			rule["filter"] = ruleFilter["prefix"]
+			rule["tags"] = ruleFilter["tags"]
+		} else if isExpanded {
+			// the prefixes are reported on their own, the block is only kept for the other conditions
+			if len(ruleFilter["tags"].(map[string]string)) > 0 || ruleFilter["object_size_greater_than"].(int) > 0 || ruleFilter["object_size_less_than"].(int) > 0 {
+				rule["rule_filter"] = []map[string]interface{}{ruleFilter}
+			}
+		} else if !r.RuleFilter.IsNull() {
+			rule["rule_filter"] = []map[string]interface{}{ruleFilter}
 		}
 
-		var noncurrentVersionExpirationDays int
-		if r.NoncurrentVersionExpiration.NoncurrentDays != 0 {
-			noncurrentVersionExpirationDays = int(r.NoncurrentVersionExpiration.NoncurrentDays)
+		rules = append(rules, rule)
+	}
+
+	for _, rule := range rules {
+		if prefixes, ok := expandedPrefixes[rule["id"].(string)]; ok {
+			rule["prefixes"] = ilmOrderedPrefixes(prefixes)
 		}
+	}
+
+	sortILMRulesByPriorOrder(rules, d.Get("rule").([]interface{}))
 
-		var noncurrentVersionTransitionDays int
-		if r.NoncurrentVersionTransition.NoncurrentDays != 0 {
-			noncurrentVersionTransitionDays = int(r.NoncurrentVersionTransition.NoncurrentDays)
+	// the policy is paused when none of its rules is enabled
+	if len(rules) > 0 {
+		if err := d.Set("enabled", enabled); err != nil {
+			return NewResourceError("setting enabled failed", d.Id(), err)
 		}
+	}
 
-		var prefix string
-		tags := map[string]string{}
-		if len(r.RuleFilter.And.Tags) > 0 {
-			prefix = r.RuleFilter.And.Prefix
-			for _, tag := range r.RuleFilter.And.Tags {
-				tags[tag.Key] = tag.Value
+	// without managed rules recorded, as in states written before them, only the rules of the resource are managed.
+	// Imported policies take over all the rules of the bucket on import instead.
+	if len(d.Get("managed_rule_ids").([]interface{})) == 0 {
+		var ownRules []lifecycle.Rule
+		for _, r := range readRules {
+			if managedIDs[r.ID] {
+				ownRules = append(ownRules, r)
 			}
-		} else {
-			prefix = r.RuleFilter.Prefix
 		}
-
-		rule := map[string]interface{}{
-			"id":                                 r.ID,
-			"expiration":                         expiration,
-			"transition":                         transitions,
-			"noncurrent_version_expiration_days": noncurrentVersionExpirationDays,
-			"noncurrent_version_transition_days": noncurrentVersionTransitionDays,
-			"status":                             r.Status,
-			"filter":                             prefix,
-			"tags":                               tags,
+		if err := d.Set("managed_rule_ids", ilmRuleIDs(ownRules)); err != nil {
+			return NewResourceError("setting managed_rule_ids failed", d.Id(), err)
 		}
+	}
 
-		rules = append(rules, rule)
+	if err := d.Set("rule_count", len(readRules)); err != nil {
+		return NewResourceError("setting rule_count failed", d.Id(), err)
+	}
+	if err := d.Set("summary", summarizeILMRules(readRules)); err != nil {
+		return NewResourceError("setting summary failed", d.Id(), err)
 	}
 
 	if err := d.Set("rule", rules); err != nil {
 		return NewResourceError("reading lifecycle configuration failed", d.Id(), err)
 	}
 
-	return nil
+	// rendered from the rules just read, so that imported policies render them too
+	if managedRules, diags := ilmPolicyRules(d); !diags.HasError() {
+		rendered, err := renderILMConfiguration(managedRules)
+		if err != nil {
+			return NewResourceError("rendering lifecycle configuration failed", d.Id(), err)
+		}
+		if err := d.Set("rendered_configuration", rendered); err != nil {
+			return NewResourceError("setting rendered_configuration failed", d.Id(), err)
+		}
+	}
+
+	return diags
+}
+
+// flattenILMRule returns the attributes of a lifecycle rule read from the server. The rule previously in state, nil
+// when there is none, tells how the rule was configured where several configurations are written the same way.
+func flattenILMRule(ruleID string, r lifecycle.Rule, priorRule map[string]interface{}) (map[string]interface{}, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	expiration := flattenILMExpiration(r.Expiration)
+	transitions := flattenILMTransition(r.Transition)
+
+	// delete marker expiration is reported the way the rule sets it, as expiration "DeleteMarker" on import
+	var expireDeleteMarker bool
+	if r.Expiration.DeleteMarker.IsEnabled() {
+		if priorRule != nil && priorRule["expire_delete_marker"].(bool) {
+			expireDeleteMarker = true
+			if priorRule["expiration"].(string) == "" {
+				expiration = ""
+			}
+		}
+	}
+
+	var noncurrentVersionExpirationDays int
+	if r.NoncurrentVersionExpiration.NoncurrentDays != 0 {
+		noncurrentVersionExpirationDays = int(r.NoncurrentVersionExpiration.NoncurrentDays)
+	}
+
+	// matching day counts are reported as expire_all_versions unless the rule sets them separately
+	var expireAllVersions bool
+	if r.Expiration.Days != 0 && r.NoncurrentVersionExpiration.NoncurrentDays == r.Expiration.Days {
+		if priorRule == nil || priorRule["noncurrent_version_expiration_days"].(int) == 0 {
+			expireAllVersions = true
+			noncurrentVersionExpirationDays = 0
+		}
+	}
+
+	var noncurrentVersionTransitionDays int
+	var noncurrentVersionTransitionStorageClass string
+	var noncurrentVersionTransitionNewerVersions int
+	if r.NoncurrentVersionTransition.NoncurrentDays != 0 {
+		noncurrentVersionTransitionDays = int(r.NoncurrentVersionTransition.NoncurrentDays)
+		noncurrentVersionTransitionStorageClass = r.NoncurrentVersionTransition.StorageClass
+		noncurrentVersionTransitionNewerVersions = r.NoncurrentVersionTransition.NewerNoncurrentVersions
+	}
+
+	// MinIO releases up to at least RELEASE.2023-08-31 silently drop the newer versions count of transitions
+	if priorRule != nil && noncurrentVersionTransitionDays != 0 && noncurrentVersionTransitionNewerVersions == 0 {
+		if newerVersions, _ := priorRule["noncurrent_version_transition_newer_versions"].(int); newerVersions != 0 {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary: fmt.Sprintf("lifecycle rule %s: the server ignored noncurrent_version_transition_newer_versions, "+
+					"all noncurrent versions are transitioned after %d days", ruleID, noncurrentVersionTransitionDays),
+			})
+		}
+	}
+
+	// a storage class inherited from the transition is not reported unless the rule sets it
+	if noncurrentVersionTransitionStorageClass == r.Transition.StorageClass {
+		if priorRule != nil && priorRule["noncurrent_version_transition_storage_class"].(string) == "" {
+			noncurrentVersionTransitionStorageClass = ""
+		}
+	}
+
+	return map[string]interface{}{
+		"id":                                 ruleID,
+		"expiration":                         expiration,
+		"expire_delete_marker":               expireDeleteMarker,
+		"expire_all_versions":                expireAllVersions,
+		"transition":                         transitions,
+		"noncurrent_version_expiration_days": noncurrentVersionExpirationDays,
+		"noncurrent_version_transition_days": noncurrentVersionTransitionDays,
+		"noncurrent_version_transition_storage_class":  noncurrentVersionTransitionStorageClass,
+		"noncurrent_version_transition_newer_versions": noncurrentVersionTransitionNewerVersions,
+		"status": r.Status,
+	}, diags
+}
+
+// minioImportILMPolicy sets the defaults read relies on, as they are not applied to imported resources, and takes
+// over the rules of the bucket
+func minioImportILMPolicy(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	for key, value := range map[string]bool{"manage_existing_rules": true, "preserve_unmanaged_rules": false, "enabled": true} {
+		if err := d.Set(key, value); err != nil {
+			return nil, err
+		}
+	}
+
+	m := meta.(*S3MinioClient)
+	config, err := minioGetILMPolicyConfiguration(ctx, m.LifecycleCache, m.S3Client, d.Id())
+	if err != nil {
+		return nil, fmt.Errorf("reading lifecycle configuration of %s failed: %w", d.Id(), redactError(err))
+	}
+	if config != nil {
+		if err := d.Set("managed_rule_ids", ilmRuleIDs(config.Rules)); err != nil {
+			return nil, err
+		}
+	}
+
+	return []*schema.ResourceData{d}, nil
 }
 
 func minioUpdateILMPolicy(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	if d.HasChange("rule") {
-		minioCreateILMPolicy(ctx, d, meta)
+	if d.HasChanges("rule", "enabled", "default_expiration", "default_transition", "manage_existing_rules") {
+		// the whole configuration is written again, the changed rules are only logged for review
+		oldRules, newRules := d.GetChange("rule")
+		added, removed, modified := ilmRuleChanges(oldRules.([]interface{}), newRules.([]interface{}))
+		tflog.Debug(ctx, "Updating lifecycle rules", map[string]interface{}{
+			"bucket":   d.Id(),
+			"removed":  removed,
+			"added":    added,
+			"modified": modified,
+		})
+
+		return minioCreateILMPolicy(ctx, d, meta)
 	}
 
 	return minioReadILMPolicy(ctx, d, meta)
@@ -273,7 +653,38 @@ func minioDeleteILMPolicy(ctx context.Context, d *schema.ResourceData, meta inte
 
 	config := lifecycle.NewConfiguration()
 
-	if err := c.SetBucketLifecycle(ctx, d.Id(), config); err != nil {
+	ilmPolicyLock.Lock(d.Id())
+	defer ilmPolicyLock.Unlock(d.Id())
+
+	if !d.Get("manage_existing_rules").(bool) || d.Get("preserve_unmanaged_rules").(bool) {
+		existing, err := minioGetBucketLifecycleRules(ctx, c, d.Id())
+		if err != nil {
+			if minio.ToErrorResponse(err).Code == "NoSuchBucket" {
+				tflog.Warn(ctx, "Bucket no longer exists, its lifecycle configuration is gone with it", map[string]interface{}{"bucket": d.Id()})
+				d.SetId("")
+				return nil
+			}
+			return NewResourceError("reading existing bucket lifecycle failed", d.Id(), err)
+		}
+		managedIDs := ilmPolicyManagedRuleIDs(d)
+		// the rules in state also include the ones read from the bucket when the resource owns the whole configuration
+		if ruleIDs := d.Get("managed_rule_ids").([]interface{}); len(ruleIDs) > 0 {
+			managedIDs = map[string]bool{}
+			for _, id := range ruleIDs {
+				managedIDs[id.(string)] = true
+			}
+		}
+		config.Rules = mergeILMRules(existing, nil, managedIDs)
+	}
+
+	err := c.SetBucketLifecycle(ctx, d.Id(), config)
+	meta.(*S3MinioClient).LifecycleCache.Invalidate(d.Id())
+	if err != nil {
+		if minio.ToErrorResponse(err).Code == "NoSuchBucket" {
+			tflog.Warn(ctx, "Bucket no longer exists, its lifecycle configuration is gone with it", map[string]interface{}{"bucket": d.Id()})
+			d.SetId("")
+			return nil
+		}
 		return NewResourceError("deleting lifecycle configuration failed", d.Id(), err)
 	}
 
@@ -282,34 +693,888 @@ func minioDeleteILMPolicy(ctx context.Context, d *schema.ResourceData, meta inte
 	return nil
 }
 
-func parseILMExpiration(s string) lifecycle.Expiration {
+// ilmPolicyGetter reads the configuration from either a schema.ResourceData or a schema.ResourceDiff
+type ilmPolicyGetter interface {
+	Get(key string) interface{}
+}
+
+// ilmPolicyRules builds the lifecycle rules of the configuration, reporting invalid rules at their own path
+func ilmPolicyRules(d ilmPolicyGetter) ([]lifecycle.Rule, diag.Diagnostics) {
+	var rules []lifecycle.Rule
+	var diags diag.Diagnostics
+
+	ruleIDs := map[string]bool{}
+	for i, ruleI := range d.Get("rule").([]interface{}) {
+		rule := ruleI.(map[string]interface{})
+		id := rule["id"].(string)
+		path := cty.GetAttrPath("rule").IndexInt(i)
+
+		filters, err := parseILMRuleFilters(rule)
+		if err != nil {
+			diags = append(diags, ilmRuleDiagnostic(path, id, "invalid lifecycle rule filter", err))
+		}
+
+		expandedIDs := ilmExpandedRuleIDs(rule)
+		for _, expandedID := range expandedIDs {
+			if ruleIDs[expandedID] {
+				diags = append(diags, ilmRuleDiagnostic(path.GetAttr("id"), expandedID, "duplicate lifecycle rule id", nil))
+			}
+			ruleIDs[expandedID] = true
+		}
+
+		ruleTransition := rule["transition"].([]interface{})
+		transitionPath := path.GetAttr("transition")
+		if len(ruleTransition) == 0 {
+			ruleTransition = d.Get("default_transition").([]interface{})
+			transitionPath = cty.GetAttrPath("default_transition")
+		}
+		transition, transitionErr := parseILMTransition(ruleTransition)
+		if transitionErr != nil {
+			diags = append(diags, ilmRuleDiagnostic(transitionPath, id, "invalid lifecycle rule transition", transitionErr))
+		}
+
+		// a rule expiring delete markers does not inherit the default expiration, which could not be combined with it
+		expireDeleteMarker, _ := rule["expire_delete_marker"].(bool)
+		expiration := rule["expiration"].(string)
+		expirationPath := path.GetAttr("expiration")
+		if expiration == "" && !expireDeleteMarker {
+			expiration = d.Get("default_expiration").(string)
+			expirationPath = cty.GetAttrPath("default_expiration")
+		}
+
+		parsedExpiration, err := parseILMExpiration(expiration)
+		if err != nil {
+			diags = append(diags, ilmRuleDiagnostic(expirationPath, id, "invalid lifecycle rule expiration", err))
+		}
+		deleteMarkerSetting := `expiration "DeleteMarker"`
+		if expireDeleteMarker {
+			if !parsedExpiration.IsDaysNull() || !parsedExpiration.IsDateNull() {
+				diags = append(diags, ilmRuleDiagnostic(path.GetAttr("expire_delete_marker"), id, "invalid lifecycle rule expiration",
+					errors.New("expire_delete_marker conflicts with a day or date based expiration on the same rule, "+
+						"expire current versions in a separate rule")))
+			}
+			parsedExpiration.DeleteMarker = true
+			expirationPath = path.GetAttr("expire_delete_marker")
+			deleteMarkerSetting = "expire_delete_marker"
+		}
+
+		// objects would expire before they are transitioned, rules with a date based transition or expiration are not checked
+		if transition.Days != 0 && parsedExpiration.Days != 0 && transition.Days >= parsedExpiration.Days {
+			diags = append(diags, ilmRuleDiagnostic(transitionPath, id, "invalid lifecycle rule transition",
+				fmt.Errorf("transition days (%dd) must be lower than expiration days (%dd), objects would expire before they are transitioned",
+					transition.Days, parsedExpiration.Days)))
+		}
+
+		noncurrentVersionExpirationDays := lifecycle.NoncurrentVersionExpiration{NoncurrentDays: lifecycle.ExpirationDays(rule["noncurrent_version_expiration_days"].(int))}
+		if expireAllVersions, _ := rule["expire_all_versions"].(bool); expireAllVersions {
+			if days := parsedExpiration.Days; days != 0 && noncurrentVersionExpirationDays.IsDaysNull() {
+				noncurrentVersionExpirationDays.NoncurrentDays = days
+			} else if days == 0 {
+				diags = append(diags, ilmRuleDiagnostic(path.GetAttr("expire_all_versions"), id, "invalid lifecycle rule expiration",
+					errors.New("expire_all_versions requires a day based expiration (5d)")))
+			} else {
+				diags = append(diags, ilmRuleDiagnostic(path.GetAttr("expire_all_versions"), id, "invalid lifecycle rule expiration",
+					errors.New("expire_all_versions conflicts with noncurrent_version_expiration_days")))
+			}
+		}
+		if parsedExpiration.DeleteMarker.IsEnabled() && noncurrentVersionExpirationDays.IsDaysNull() {
+			// a delete marker is only expired once no noncurrent version is left behind it
+			diags = append(diags, ilmRuleDiagnostic(expirationPath, id, "invalid lifecycle rule expiration",
+				fmt.Errorf("%s requires noncurrent_version_expiration_days on the same rule, "+
+					"delete markers are only removed once the noncurrent versions they hide are expired", deleteMarkerSetting)))
+		}
+		noncurrentVersionTransitionDays := lifecycle.NoncurrentVersionTransition{NoncurrentDays: lifecycle.ExpirationDays(rule["noncurrent_version_transition_days"].(int))}
+		newerVersions, _ := rule["noncurrent_version_transition_newer_versions"].(int)
+		if !noncurrentVersionTransitionDays.IsDaysNull() {
+			noncurrentVersionTransitionDays.NewerNoncurrentVersions = newerVersions
+			noncurrentVersionTransitionDays.StorageClass, _ = rule["noncurrent_version_transition_storage_class"].(string)
+			if noncurrentVersionTransitionDays.StorageClass == "" {
+				noncurrentVersionTransitionDays.StorageClass = transition.StorageClass
+			}
+			if noncurrentVersionTransitionDays.StorageClass == "" && transitionErr == nil {
+				diags = append(diags, ilmRuleDiagnostic(path.GetAttr("noncurrent_version_transition_storage_class"), id, "invalid lifecycle rule noncurrent version transition",
+					errors.New("noncurrent_version_transition_days requires noncurrent_version_transition_storage_class or a transition storage_class")))
+			}
+		} else if newerVersions != 0 {
+			diags = append(diags, ilmRuleDiagnostic(path.GetAttr("noncurrent_version_transition_newer_versions"), id, "invalid lifecycle rule noncurrent version transition",
+				errors.New("noncurrent_version_transition_newer_versions requires noncurrent_version_transition_days")))
+		}
+
+		r := lifecycle.Rule{
+			ID:                          id,
+			Expiration:                  parsedExpiration,
+			Transition:                  transition,
+			NoncurrentVersionExpiration: noncurrentVersionExpirationDays,
+			NoncurrentVersionTransition: noncurrentVersionTransitionDays,
+			Status:                      ilmRuleStatus(d.Get("enabled").(bool)),
+		}
+
+		if transitionErr == nil && r.Expiration.IsNull() && r.Transition.IsNull() &&
+			r.NoncurrentVersionExpiration.IsDaysNull() && r.NoncurrentVersionTransition.IsDaysNull() {
+			diags = append(diags, ilmRuleDiagnostic(path, id, "lifecycle rule has no action",
+				errors.New("set at least one of expiration, transition, noncurrent_version_expiration_days or noncurrent_version_transition_days")))
+		}
+
+		// rules with several prefixes are written as one rule per prefix
+		for n, filter := range filters {
+			if n < len(expandedIDs) {
+				r.ID = expandedIDs[n]
+			}
+			r.RuleFilter = filter
+			rules = append(rules, r)
+		}
+	}
+
+	return rules, diags
+}
+
+// ilmExpandedRuleIDs returns the IDs of the lifecycle rules a rule of the configuration is written as
+func ilmExpandedRuleIDs(rule map[string]interface{}) []string {
+	id := rule["id"].(string)
+	prefixes, _ := rule["prefixes"].([]interface{})
+	if len(prefixes) == 0 {
+		return []string{id}
+	}
+
+	ids := make([]string, 0, len(prefixes))
+	for n := range prefixes {
+		ids = append(ids, fmt.Sprintf("%s-%d", id, n+1))
+	}
+	return ids
+}
+
+func ilmRuleStatus(enabled bool) string {
+	if enabled {
+		return "Enabled"
+	}
+	return "Disabled"
+}
+
+func ilmRuleDiagnostic(path cty.Path, id, msg string, err error) diag.Diagnostic {
+	summary := fmt.Sprintf("[FATAL] %s (%s)", msg, id)
+	if err != nil {
+		summary = fmt.Sprintf("%s: %s", summary, err)
+	}
+
+	return diag.Diagnostic{
+		Severity:      diag.Error,
+		Summary:       summary,
+		AttributePath: path,
+	}
+}
+
+// minioGetILMPolicyConfiguration reads the lifecycle configuration of the bucket. It is nil when the bucket or its
+// configuration doesn't exist, unlike a configuration without rules.
+func minioGetILMPolicyConfiguration(ctx context.Context, cache *lifecycleCache, client minioLifecycleGetter, bucket string) (*lifecycle.Configuration, error) {
+	var config *lifecycle.Configuration
+	err := retryOnError(ctx, retryTimeout, func() (err error) {
+		config, err = cache.Get(ctx, client, bucket)
+		return err
+	})
+	if err != nil {
+		if isNotFoundError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return config, nil
+}
+
+// minioGetBucketLifecycleRules returns the current lifecycle rules of a bucket, or none if it has no lifecycle configuration
+func minioGetBucketLifecycleRules(ctx context.Context, c *minio.Client, bucket string) ([]lifecycle.Rule, error) {
+	var config *lifecycle.Configuration
+	err := retryOnError(ctx, retryTimeout, func() (err error) {
+		config, err = c.GetBucketLifecycle(ctx, bucket)
+		return err
+	})
+	if err != nil {
+		if minio.ToErrorResponse(err).Code == "NoSuchLifecycleConfiguration" {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return config.Rules, nil
+}
+
+// ilmPolicyManagedRuleIDs returns the IDs of the rules in the configuration and in the prior state
+func ilmPolicyManagedRuleIDs(d *schema.ResourceData) map[string]bool {
+	ids := map[string]bool{}
+
+	oldRules, newRules := d.GetChange("rule")
+	for _, rules := range []interface{}{oldRules, newRules} {
+		for _, ruleI := range rules.([]interface{}) {
+			if rule, ok := ruleI.(map[string]interface{}); ok {
+				for _, id := range ilmExpandedRuleIDs(rule) {
+					ids[id] = true
+				}
+			}
+		}
+	}
+
+	return ids
+}
+
+// ilmExpandedRule locates a lifecycle rule written for one of the prefixes of a rule
+type ilmExpandedRule struct {
+	id    string
+	index int
+}
+
+// ilmPolicyExpandedRules maps the IDs of the lifecycle rules written for rules with prefixes to the rule they come from
+func ilmPolicyExpandedRules(d *schema.ResourceData) map[string]ilmExpandedRule {
+	expanded := map[string]ilmExpandedRule{}
+
+	for id, rule := range ilmPolicyRulesByID(d) {
+		if prefixes, _ := rule["prefixes"].([]interface{}); len(prefixes) == 0 {
+			continue
+		}
+		for n, expandedID := range ilmExpandedRuleIDs(rule) {
+			expanded[expandedID] = ilmExpandedRule{id: id, index: n}
+		}
+	}
+
+	return expanded
+}
+
+func ilmRuleIDs(rules []lifecycle.Rule) []string {
+	ids := make([]string, 0, len(rules))
+	for _, r := range rules {
+		ids = append(ids, r.ID)
+	}
+	return ids
+}
+
+// mergeILMRules keeps the existing rules not managed by the resource and appends the managed ones
+func mergeILMRules(existing []lifecycle.Rule, managed []lifecycle.Rule, managedIDs map[string]bool) []lifecycle.Rule {
+	rules := make([]lifecycle.Rule, 0, len(existing)+len(managed))
+	for _, r := range existing {
+		if !managedIDs[r.ID] {
+			rules = append(rules, r)
+		}
+	}
+
+	return append(rules, managed...)
+}
+
+// ilmOrderedPrefixes lists the prefixes read for a rule by their position, skipping the ones whose rule is missing
+func ilmOrderedPrefixes(prefixes map[int]string) []string {
+	indexes := make([]int, 0, len(prefixes))
+	for index := range prefixes {
+		indexes = append(indexes, index)
+	}
+	sort.Ints(indexes)
+
+	ordered := make([]string, 0, len(indexes))
+	for _, index := range indexes {
+		ordered = append(ordered, prefixes[index])
+	}
+	return ordered
+}
+
+// ilmPolicyRulesByID returns the rules of the configuration, or of the prior state when refreshing, keyed by ID
+func ilmPolicyRulesByID(d *schema.ResourceData) map[string]map[string]interface{} {
+	rules := map[string]map[string]interface{}{}
+
+	for _, ruleI := range d.Get("rule").([]interface{}) {
+		if rule, ok := ruleI.(map[string]interface{}); ok {
+			rules[rule["id"].(string)] = rule
+		}
+	}
+
+	return rules
+}
+
+// ilmRuleChanges compares two rule lists by ID and returns the sorted IDs of the added, removed and modified rules
+func ilmRuleChanges(old, new []interface{}) (added, removed, modified []string) {
+	oldRules := map[string]interface{}{}
+	for _, r := range old {
+		if rule, ok := r.(map[string]interface{}); ok {
+			oldRules[rule["id"].(string)] = rule
+		}
+	}
+
+	newIDs := map[string]bool{}
+	for _, r := range new {
+		rule, ok := r.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		id := rule["id"].(string)
+		newIDs[id] = true
+
+		if oldRule, ok := oldRules[id]; !ok {
+			added = append(added, id)
+		} else if !reflect.DeepEqual(oldRule, rule) {
+			modified = append(modified, id)
+		}
+	}
+
+	for id := range oldRules {
+		if !newIDs[id] {
+			removed = append(removed, id)
+		}
+	}
+
+	sort.Strings(added)
+	sort.Strings(removed)
+	sort.Strings(modified)
+	return added, removed, modified
+}
+
+// ilmRulesEqualIgnoringOrder reports whether both rule lists hold the same rules, matched by ID
+func ilmRulesEqualIgnoringOrder(old, new []interface{}) bool {
+	if len(old) != len(new) {
+		return false
+	}
+
+	oldRules := map[string]interface{}{}
+	for _, r := range old {
+		rule, ok := r.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		oldRules[rule["id"].(string)] = rule
+	}
+	if len(oldRules) != len(old) {
+		return false
+	}
+
+	for _, r := range new {
+		rule, ok := r.(map[string]interface{})
+		if !ok || !reflect.DeepEqual(oldRules[rule["id"].(string)], rule) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// sortILMRulesByPriorOrder keeps the rules read from the server in the order they had in state,
+// rules not in state go last
+func sortILMRulesByPriorOrder(rules []map[string]interface{}, prior []interface{}) {
+	position := map[string]int{}
+	for i, r := range prior {
+		if rule, ok := r.(map[string]interface{}); ok {
+			position[rule["id"].(string)] = i
+		}
+	}
+
+	sort.SliceStable(rules, func(i, j int) bool {
+		pi, ok := position[rules[i]["id"].(string)]
+		if !ok {
+			pi = len(prior)
+		}
+		pj, ok := position[rules[j]["id"].(string)]
+		if !ok {
+			pj = len(prior)
+		}
+		return pi < pj
+	})
+}
+
+// ilmPolicyLegacyFilterRuleIDs returns the IDs of the rules still using the deprecated filter and tags attributes
+func ilmPolicyLegacyFilterRuleIDs(d *schema.ResourceData) map[string]bool {
+	ids := map[string]bool{}
+
+	for id, rule := range ilmPolicyRulesByID(d) {
+		if rule["filter"].(string) != "" || len(rule["tags"].(map[string]interface{})) > 0 {
+			ids[id] = true
+		}
+	}
+
+	return ids
+}
+
+// parseILMRuleFilters builds the lifecycle filters of a rule, one per prefix when the rule sets prefixes
+func parseILMRuleFilters(rule map[string]interface{}) ([]lifecycle.Filter, error) {
+	prefixes, _ := rule["prefixes"].([]interface{})
+	if len(prefixes) == 0 {
+		filter, err := parseILMRuleFilter(rule)
+		return []lifecycle.Filter{filter}, err
+	}
+
+	if rule["filter"].(string) != "" || len(rule["tags"].(map[string]interface{})) > 0 {
+		return nil, errors.New("prefixes cannot be used together with the deprecated filter and tags attributes")
+	}
+
+	block := map[string]interface{}{
+		"prefix":                   "",
+		"tags":                     map[string]interface{}{},
+		"object_size_greater_than": 0,
+		"object_size_less_than":    0,
+	}
+	if blocks := rule["rule_filter"].([]interface{}); len(blocks) > 0 {
+		if b, ok := blocks[0].(map[string]interface{}); ok {
+			if b["prefix"].(string) != "" {
+				return nil, errors.New("prefixes cannot be used together with the prefix of rule_filter")
+			}
+			for k, v := range b {
+				block[k] = v
+			}
+		}
+	}
+
+	seen := map[string]bool{}
+	filters := make([]lifecycle.Filter, 0, len(prefixes))
+	for _, p := range prefixes {
+		prefix, _ := p.(string)
+		if seen[prefix] {
+			return nil, fmt.Errorf("duplicate prefix %q", prefix)
+		}
+		seen[prefix] = true
+
+		block["prefix"] = prefix
+		expanded := map[string]interface{}{}
+		for k, v := range rule {
+			expanded[k] = v
+		}
+		expanded["rule_filter"] = []interface{}{block}
+
+		filter, err := parseILMRuleFilter(expanded)
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, filter)
+	}
+
+	return filters, nil
+}
+
+// parseILMRuleFilter builds the lifecycle filter of a rule from either the rule_filter block or the deprecated filter and tags attributes
+func parseILMRuleFilter(rule map[string]interface{}) (lifecycle.Filter, error) {
+	var prefix string
+	var sizeGreaterThan, sizeLessThan int64
+	tags := map[string]interface{}{}
+
+	legacyPrefix := rule["filter"].(string)
+	legacyTags := rule["tags"].(map[string]interface{})
+
+	if blocks := rule["rule_filter"].([]interface{}); len(blocks) > 0 {
+		if legacyPrefix != "" || len(legacyTags) > 0 {
+			return lifecycle.Filter{}, errors.New("rule_filter cannot be used together with the deprecated filter and tags attributes")
+		}
+		if block, ok := blocks[0].(map[string]interface{}); ok {
+			prefix = block["prefix"].(string)
+			tags = block["tags"].(map[string]interface{})
+			sizeGreaterThan = int64(block["object_size_greater_than"].(int))
+			sizeLessThan = int64(block["object_size_less_than"].(int))
+		}
+	} else {
+		prefix = legacyPrefix
+		tags = legacyTags
+	}
+
+	if sizeLessThan > 0 && sizeGreaterThan >= sizeLessThan {
+		return lifecycle.Filter{}, fmt.Errorf("object_size_greater_than (%d) must be lower than object_size_less_than (%d)", sizeGreaterThan, sizeLessThan)
+	}
+
+	conditions := len(tags)
+	for _, set := range []bool{prefix != "", sizeGreaterThan > 0, sizeLessThan > 0} {
+		if set {
+			conditions++
+		}
+	}
+
+	// S3 only combines conditions under And, a lone tag is its own Tag element so it reads back the same way
+	var filter lifecycle.Filter
+	if conditions > 1 {
+		filter.And.Prefix = prefix
+		filter.And.ObjectSizeGreaterThan = sizeGreaterThan
+		filter.And.ObjectSizeLessThan = sizeLessThan
+		for k, v := range tags {
+			filter.And.Tags = append(filter.And.Tags, lifecycle.Tag{Key: strings.TrimSpace(k), Value: strings.TrimSpace(v.(string))})
+		}
+		sort.Slice(filter.And.Tags, func(i, j int) bool { return filter.And.Tags[i].Key < filter.And.Tags[j].Key })
+	} else if len(tags) == 1 {
+		for k, v := range tags {
+			filter.Tag = lifecycle.Tag{Key: strings.TrimSpace(k), Value: strings.TrimSpace(v.(string))}
+		}
+	} else {
+		filter.Prefix = prefix
+		filter.ObjectSizeGreaterThan = sizeGreaterThan
+		filter.ObjectSizeLessThan = sizeLessThan
+	}
+
+	return filter, nil
+}
+
+// flattenILMRuleFilter returns the rule_filter block of a lifecycle filter. Tags are trimmed like when they are
+// written, so that rules written by other clients with surrounding whitespace, e.g. when imported, read back
+// the same as the configuration.
+func flattenILMRuleFilter(filter lifecycle.Filter) map[string]interface{} {
+	prefix := filter.Prefix
+	sizeGreaterThan := filter.ObjectSizeGreaterThan
+	sizeLessThan := filter.ObjectSizeLessThan
+	tags := map[string]string{}
+
+	if !filter.And.IsEmpty() {
+		prefix = filter.And.Prefix
+		sizeGreaterThan = filter.And.ObjectSizeGreaterThan
+		sizeLessThan = filter.And.ObjectSizeLessThan
+		for _, tag := range filter.And.Tags {
+			tags[strings.TrimSpace(tag.Key)] = strings.TrimSpace(tag.Value)
+		}
+	}
+	if !filter.Tag.IsEmpty() {
+		tags[strings.TrimSpace(filter.Tag.Key)] = strings.TrimSpace(filter.Tag.Value)
+	}
+
+	return map[string]interface{}{
+		"prefix":                   prefix,
+		"tags":                     tags,
+		"object_size_greater_than": int(sizeGreaterThan),
+		"object_size_less_than":    int(sizeLessThan),
+	}
+}
+
+// suppressILMTagWhitespace ignores whitespace around tag values, which is trimmed when the tags are written
+func suppressILMTagWhitespace(k, oldValue, newValue string, d *schema.ResourceData) bool {
+	return strings.TrimSpace(oldValue) == strings.TrimSpace(newValue)
+}
+
+func flattenILMExpiration(expiration lifecycle.Expiration) string {
+	switch {
+	case expiration.DeleteMarker.IsEnabled():
+		return "DeleteMarker"
+	case expiration.Days != 0:
+		return fmt.Sprintf("%dd", expiration.Days)
+	case !expiration.IsNull():
+		return formatILMDate(expiration.Date.Time)
+	}
+	return ""
+}
+
+func flattenILMTransition(t lifecycle.Transition) []map[string]string {
+	transitions := make([]map[string]string, 0)
+
+	if !t.IsNull() {
+		transition := map[string]string{}
+		if !t.IsDaysNull() {
+			transition["days"] = fmt.Sprintf("%dd", t.Days)
+		} else if !t.IsDateNull() {
+			transition["date"] = formatILMDate(t.Date.Time)
+		}
+		transition["storage_class"] = t.StorageClass
+		transitions = append(transitions, transition)
+	}
+
+	return transitions
+}
+
+// ilmDateLayout is the format of the expiration and transition dates
+const ilmDateLayout = "2006-01-02"
+
+// parseILMDate parses a lifecycle date as midnight UTC, the time lifecycle dates are evaluated at, so that
+// the written configuration doesn't depend on the timezone Terraform runs in
+func parseILMDate(s string) (time.Time, error) {
+	return time.ParseInLocation(ilmDateLayout, s, time.UTC)
+}
+
+// formatILMDate formats a lifecycle date read from the server in UTC
+func formatILMDate(t time.Time) string {
+	return t.UTC().Format(ilmDateLayout)
+}
+
+// parseILMDays parses the days of a transition, written either as 5d, the format they are read back in, or as 5.
+// 0d is rejected as it drops the transition.
+func parseILMDays(s string) (int, error) {
+	days, err := strconv.Atoi(strings.TrimSuffix(s, "d"))
+	if err != nil || days < 1 {
+		return 0, fmt.Errorf("transition days must be a positive number of days such as 5d, got %q", s)
+	}
+	return days, nil
+}
+
+// suppressILMTransitionDays ignores the format of the transition days, 5 is read back as 5d
+func suppressILMTransitionDays(k, oldValue, newValue string, d *schema.ResourceData) bool {
+	oldDays, err := parseILMDays(oldValue)
+	if err != nil {
+		return false
+	}
+	newDays, err := parseILMDays(newValue)
+	return err == nil && oldDays == newDays
+}
+
+var errILMExpirationFormat = errors.New("expiration must be a duration (5d), date (1970-01-01), or \"DeleteMarker\"")
+
+// parseILMExpiration parses the expiration of a rule, an empty value is no expiration. Durations in hours, minutes
+// or seconds are rejected, as lifecycle rules are evaluated in whole days.
+func parseILMExpiration(s string) (lifecycle.Expiration, error) {
 	var days int
+	if s == "" {
+		return lifecycle.Expiration{}, nil
+	}
 	if s == "DeleteMarker" {
-		return lifecycle.Expiration{DeleteMarker: true}
+		return lifecycle.Expiration{DeleteMarker: true}, nil
 	}
 	if _, err := fmt.Sscanf(s, "%dd", &days); err == nil {
-		return lifecycle.Expiration{Days: lifecycle.ExpirationDays(days)}
+		return lifecycle.Expiration{Days: lifecycle.ExpirationDays(days)}, nil
 	}
-	if date, err := time.Parse("2006-01-02", s); err == nil {
-		return lifecycle.Expiration{Date: lifecycle.ExpirationDate{Time: date}}
+	if date, err := parseILMDate(s); err == nil {
+		return lifecycle.Expiration{Date: lifecycle.ExpirationDate{Time: date}}, nil
+	}
+	if duration, err := time.ParseDuration(s); err == nil {
+		if duration > 0 && duration%(24*time.Hour) == 0 {
+			return lifecycle.Expiration{}, fmt.Errorf("expiration %q: MinIO lifecycle supports whole days only, use %dd instead", s, duration/(24*time.Hour))
+		}
+		return lifecycle.Expiration{}, fmt.Errorf("expiration %q: MinIO lifecycle supports whole days only, use a number of days such as 1d", s)
 	}
 
-	return lifecycle.Expiration{}
+	return lifecycle.Expiration{}, errILMExpirationFormat
 }
 
-func parseILMTransition(transition interface{}) lifecycle.Transition {
+func parseILMTransition(transition interface{}) (lifecycle.Transition, error) {
 	transitions := transition.([]interface{})
-	if len(transitions) == 0 {
-		return lifecycle.Transition{}
+	if len(transitions) == 0 || transitions[0] == nil {
+		return lifecycle.Transition{}, nil
 	}
 	t := transitions[0].(map[string]interface{})
-	var days int
-	if _, err := fmt.Sscanf(t["days"].(string), "%dd", &days); err == nil {
-		return lifecycle.Transition{Days: lifecycle.ExpirationDays(days), StorageClass: t["storage_class"].(string)}
+
+	// an empty storage class would make the transition silently disappear from the configuration
+	storageClass := t["storage_class"].(string)
+	if storageClass == "" {
+		return lifecycle.Transition{}, errors.New("transition storage_class must be set")
+	}
+
+	// the server would keep only one of them, the other being silently dropped
+	if t["days"].(string) != "" && t["date"].(string) != "" {
+		return lifecycle.Transition{}, fmt.Errorf("transition requires exactly one of days (5d) or date (1970-01-01), got days %q and date %q", t["days"], t["date"])
+	}
+
+	if t["days"].(string) != "" {
+		days, err := parseILMDays(t["days"].(string))
+		if err != nil {
+			return lifecycle.Transition{}, err
+		}
+		return lifecycle.Transition{Days: lifecycle.ExpirationDays(days), StorageClass: storageClass}, nil
+	}
+	if date, err := parseILMDate(t["date"].(string)); err == nil {
+		// dates in the past are kept as is, MinIO then transitions the existing objects right away
+		return lifecycle.Transition{Date: lifecycle.ExpirationDate{Time: date}, StorageClass: storageClass}, nil
+	}
+
+	return lifecycle.Transition{}, fmt.Errorf("transition requires either days (5d) or date (1970-01-01), got days %q and date %q", t["days"], t["date"])
+}
+
+// minioRenderILMPolicy renders the lifecycle configuration of the plan into rendered_configuration, rule_count, summary
+// and managed_rule_ids.
+// It is only rendered when the policy changes, so that it never shows up as a change of its own.
+func minioRenderILMPolicy(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	keys := []string{"rule", "enabled", "default_expiration", "default_transition"}
+	if d.Id() != "" {
+		oldRules, newRules := d.GetChange("rule")
+		rulesChanged := !ilmRulesEqualIgnoringOrder(oldRules.([]interface{}), newRules.([]interface{}))
+		if !rulesChanged && !d.HasChanges(keys[1:]...) {
+			return nil
+		}
+	}
+
+	setComputed := func() error {
+		for _, key := range []string{"rendered_configuration", "rule_count", "summary", "managed_rule_ids"} {
+			if err := d.SetNewComputed(key); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	// unknown values nested in the rules, e.g. an id taken from another resource, would be rendered as empty strings
+	if !ilmPolicyConfigKnown(d.GetRawConfig(), keys) {
+		return setComputed()
+	}
+	for _, key := range keys {
+		if !d.NewValueKnown(key) {
+			return setComputed()
+		}
+	}
+
+	rules, diags := ilmPolicyRules(d)
+	if diags.HasError() {
+		// reported with their attribute path on apply
+		return setComputed()
+	}
+
+	rendered, err := renderILMConfiguration(rules)
+	if err != nil {
+		return err
+	}
+
+	if err := d.SetNew("rendered_configuration", rendered); err != nil {
+		return err
+	}
+	if err := d.SetNew("rule_count", len(rules)); err != nil {
+		return err
 	}
-	if date, err := time.Parse("2006-01-02", t["date"].(string)); err == nil {
-		return lifecycle.Transition{Date: lifecycle.ExpirationDate{Time: date}, StorageClass: t["storage_class"].(string)}
+	if err := d.SetNew("managed_rule_ids", ilmRuleIDs(rules)); err != nil {
+		return err
+	}
+	return d.SetNew("summary", summarizeILMRules(rules))
+}
+
+// ilmPolicyConfigKnown reports whether the attributes of the raw configuration are wholly known, a null configuration
+// being considered known
+func ilmPolicyConfigKnown(rawConfig cty.Value, keys []string) bool {
+	if rawConfig.IsNull() {
+		return true
+	}
+	if !rawConfig.IsKnown() {
+		return false
+	}
+	for _, key := range keys {
+		if rawConfig.Type().HasAttribute(key) && !rawConfig.GetAttr(key).IsWhollyKnown() {
+			return false
+		}
+	}
+	return true
+}
+
+func renderILMConfiguration(rules []lifecycle.Rule) (string, error) {
+	config := lifecycle.NewConfiguration()
+	config.Rules = rules
+
+	rendered, err := xml.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("unable to render lifecycle configuration: %w", err)
+	}
+
+	return string(rendered), nil
+}
+
+// summarizeILMRules describes each rule by its ID and primary action, ordered by ID so the summary does not depend on
+// the order MinIO returns the rules in
+func summarizeILMRules(rules []lifecycle.Rule) string {
+	sorted := append([]lifecycle.Rule(nil), rules...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].ID < sorted[j].ID })
+
+	summaries := make([]string, 0, len(sorted))
+	for _, r := range sorted {
+		summary := fmt.Sprintf("%s: %s", r.ID, ilmRulePrimaryAction(r))
+		if r.Status == "Disabled" {
+			summary += " (disabled)"
+		}
+		summaries = append(summaries, summary)
+	}
+
+	return strings.Join(summaries, "; ")
+}
+
+func ilmRulePrimaryAction(r lifecycle.Rule) string {
+	switch {
+	case r.Expiration.DeleteMarker.IsEnabled():
+		return "expire delete markers"
+	case r.Expiration.Days != 0:
+		return fmt.Sprintf("expire after %dd", r.Expiration.Days)
+	case !r.Expiration.Date.IsZero():
+		return fmt.Sprintf("expire on %s", formatILMDate(r.Expiration.Date.Time))
+	case r.Transition.Days != 0:
+		return fmt.Sprintf("transition to %s after %dd", r.Transition.StorageClass, r.Transition.Days)
+	case !r.Transition.Date.IsZero():
+		return fmt.Sprintf("transition to %s on %s", r.Transition.StorageClass, formatILMDate(r.Transition.Date.Time))
+	case r.NoncurrentVersionExpiration.NoncurrentDays != 0:
+		return fmt.Sprintf("expire noncurrent versions after %dd", r.NoncurrentVersionExpiration.NoncurrentDays)
+	case r.NoncurrentVersionTransition.NoncurrentDays != 0 && r.NoncurrentVersionTransition.NewerNoncurrentVersions != 0:
+		return fmt.Sprintf("transition noncurrent versions to %s after %dd, keeping %d newer", r.NoncurrentVersionTransition.StorageClass,
+			r.NoncurrentVersionTransition.NoncurrentDays, r.NoncurrentVersionTransition.NewerNoncurrentVersions)
+	case r.NoncurrentVersionTransition.NoncurrentDays != 0:
+		return fmt.Sprintf("transition noncurrent versions to %s after %dd", r.NoncurrentVersionTransition.StorageClass, r.NoncurrentVersionTransition.NoncurrentDays)
+	}
+
+	return "no action"
+}
+
+// minioTierLister is the part of the admin client needed to look up remote tiers
+type minioTierLister interface {
+	ListTiers(ctx context.Context) ([]*madmin.TierConfig, error)
+}
+
+// ilmPolicyChangeGetter reads the prior and planned configuration from a schema.ResourceData
+type ilmPolicyChangeGetter interface {
+	GetChange(key string) (interface{}, interface{})
+}
+
+// minioCheckILMPolicyTiers warns when a transition targets a storage class that is not a tier on the server yet.
+// This is not an error since the tier may be created by a minio_ilm_tier resource in the same apply, in which case
+// the transition has to reference that resource so the tier is created first. Only the storage classes added by
+// the change are checked, so the tiers are not listed when the transitions are unchanged.
+// It runs on apply rather than in CustomizeDiff, which can only return errors.
+func minioCheckILMPolicyTiers(ctx context.Context, d ilmPolicyChangeGetter, client minioTierLister) (diags diag.Diagnostics) {
+	oldRules, newRules := d.GetChange("rule")
+	oldDefault, newDefault := d.GetChange("default_transition")
+	existing := ilmPolicyStorageClasses(oldDefault.([]interface{}), oldRules.([]interface{}))
+
+	var storageClasses []string
+	for storageClass := range ilmPolicyStorageClasses(newDefault.([]interface{}), newRules.([]interface{})) {
+		if !existing[storageClass] {
+			storageClasses = append(storageClasses, storageClass)
+		}
+	}
+	if len(storageClasses) == 0 {
+		return nil
+	}
+	sort.Strings(storageClasses)
+
+	missing, err := ilmPolicyMissingTiers(ctx, client, storageClasses)
+	if err != nil {
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  "Unable to list remote tiers to check the transitions",
+			Detail:   redactSecrets(err.Error()),
+		}}
+	}
+	for _, storageClass := range missing {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Transition storage class %s does not match any remote tier", storageClass),
+			Detail:   "If the tier is managed by a minio_ilm_tier resource, set storage_class to its name attribute so it is created first.",
+		})
+	}
+
+	return
+}
+
+// ilmPolicyStorageClasses returns the storage classes the transitions of the policy target, ignoring empty ones
+func ilmPolicyStorageClasses(defaultTransition, rules []interface{}) map[string]bool {
+	storageClasses := map[string]bool{}
+	transitions := defaultTransition
+	for _, r := range rules {
+		if rule, ok := r.(map[string]interface{}); ok {
+			transitions = append(transitions, rule["transition"].([]interface{})...)
+			if storageClass, _ := rule["noncurrent_version_transition_storage_class"].(string); storageClass != "" {
+				storageClasses[storageClass] = true
+			}
+		}
+	}
+	for _, t := range transitions {
+		if transition, ok := t.(map[string]interface{}); ok {
+			if storageClass, _ := transition["storage_class"].(string); storageClass != "" {
+				storageClasses[storageClass] = true
+			}
+		}
+	}
+
+	return storageClasses
+}
+
+// ilmPolicyMissingTiers returns the storage classes that do not match a tier configured on the server
+func ilmPolicyMissingTiers(ctx context.Context, client minioTierLister, storageClasses []string) ([]string, error) {
+	tiers, err := client.ListTiers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	existing := map[string]bool{}
+	for _, tier := range tiers {
+		existing[tier.Name] = true
+	}
+
+	var missing []string
+	for _, storageClass := range storageClasses {
+		if !existing[storageClass] {
+			missing = append(missing, storageClass)
+		}
 	}
 
-	return lifecycle.Transition{}
+	return missing, nil
 }
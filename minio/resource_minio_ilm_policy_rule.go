@@ -0,0 +1,251 @@
+package minio
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/minio/minio-go/v7/pkg/lifecycle"
+)
+
+// ilmPolicyRuleAttributes are the attributes of a minio_ilm_policy_rule shared with the rules of minio_ilm_policy.
+// The deprecated filter attributes and prefixes, which write several lifecycle rules, are left out.
+var ilmPolicyRuleAttributes = []string{
+	"expiration",
+	"expire_delete_marker",
+	"expire_all_versions",
+	"transition",
+	"noncurrent_version_expiration_days",
+	"noncurrent_version_transition_days",
+	"noncurrent_version_transition_storage_class",
+	"noncurrent_version_transition_newer_versions",
+	"status",
+	"rule_filter",
+}
+
+func resourceMinioILMPolicyRule() *schema.Resource {
+	ruleSchema := resourceMinioILMPolicy().Schema["rule"].Elem.(*schema.Resource).Schema
+
+	s := map[string]*schema.Schema{
+		"bucket": {
+			Type:             schema.TypeString,
+			Required:         true,
+			ForceNew:         true,
+			ValidateDiagFunc: validateBucketName,
+		},
+		"rule_id": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+			Description:  "ID of the lifecycle rule, unique within the bucket",
+		},
+		"enabled": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     true,
+			Description: "Whether the rule is applied",
+		},
+	}
+	for _, attribute := range ilmPolicyRuleAttributes {
+		s[attribute] = ruleSchema[attribute]
+	}
+
+	return &schema.Resource{
+		CreateContext: minioPutILMPolicyRule,
+		ReadContext:   minioReadILMPolicyRule,
+		UpdateContext: minioPutILMPolicyRule,
+		DeleteContext: minioDeleteILMPolicyRule,
+		Importer: &schema.ResourceImporter{
+			StateContext: minioImportILMPolicyRule,
+		},
+		Description: "`minio_ilm_policy_rule` handles a single lifecycle rule of a bucket, keeping the other rules of the bucket. " +
+			"Several rules of the same bucket can be managed with `for_each`. Do not combine it with a `minio_ilm_policy` of the bucket " +
+			"unless the policy sets `manage_existing_rules = false`, as the policy otherwise removes the rules it does not declare",
+		Schema: s,
+	}
+}
+
+func minioPutILMPolicyRule(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	m := meta.(*S3MinioClient)
+	bucket := d.Get("bucket").(string)
+	ruleID := d.Get("rule_id").(string)
+
+	rules, diags := ilmPolicyRules(ilmPolicyRuleAsPolicy{d})
+	if diags.HasError() {
+		return ilmPolicyRuleDiagnostics(diags)
+	}
+
+	ilmPolicyLock.Lock(bucket)
+	defer ilmPolicyLock.Unlock(bucket)
+
+	existing, err := minioGetBucketLifecycleRules(ctx, m.S3Client, bucket)
+	if err != nil {
+		return NewResourceError("reading existing bucket lifecycle failed", bucket, err)
+	}
+
+	tflog.Debug(ctx, "Writing lifecycle rule", map[string]interface{}{"bucket": bucket, "rule_id": ruleID})
+
+	config := lifecycle.NewConfiguration()
+	config.Rules = mergeILMRules(existing, rules, map[string]bool{ruleID: true})
+	err = retryOnError(ctx, retryTimeout, func() error {
+		return m.S3Client.SetBucketLifecycle(ctx, bucket, config)
+	})
+	m.LifecycleCache.Invalidate(bucket)
+	if err != nil {
+		return NewResourceError("writing lifecycle rule failed", ilmPolicyRuleID(bucket, ruleID), err)
+	}
+
+	d.SetId(ilmPolicyRuleID(bucket, ruleID))
+
+	return minioReadILMPolicyRule(ctx, d, meta)
+}
+
+func minioReadILMPolicyRule(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	m := meta.(*S3MinioClient)
+	bucket := d.Get("bucket").(string)
+	ruleID := d.Get("rule_id").(string)
+
+	config, err := minioGetILMPolicyConfiguration(ctx, m.LifecycleCache, m.S3Client, bucket)
+	if err != nil {
+		return NewResourceError("reading lifecycle configuration failed", d.Id(), err)
+	}
+
+	var rule *lifecycle.Rule
+	if config != nil {
+		for i := range config.Rules {
+			if config.Rules[i].ID == ruleID {
+				rule = &config.Rules[i]
+				break
+			}
+		}
+	}
+	if rule == nil {
+		tflog.Warn(ctx, "Lifecycle rule not found, removing from state", map[string]interface{}{"bucket": bucket, "rule_id": ruleID})
+		d.SetId("")
+		return nil
+	}
+
+	priorRule := map[string]interface{}{}
+	for _, attribute := range ilmPolicyRuleAttributes {
+		priorRule[attribute] = d.Get(attribute)
+	}
+	// imported rules have no prior configuration
+	if d.Get("status").(string) == "" {
+		priorRule = nil
+	}
+
+	flattened, diags := flattenILMRule(ruleID, *rule, priorRule)
+	flattened["rule_filter"] = []map[string]interface{}{}
+	if !rule.RuleFilter.IsNull() {
+		flattened["rule_filter"] = []map[string]interface{}{flattenILMRuleFilter(rule.RuleFilter)}
+	}
+
+	for _, attribute := range ilmPolicyRuleAttributes {
+		if err := d.Set(attribute, flattened[attribute]); err != nil {
+			return NewResourceError(fmt.Sprintf("setting %s failed", attribute), d.Id(), err)
+		}
+	}
+	if err := d.Set("enabled", rule.Status == "Enabled"); err != nil {
+		return NewResourceError("setting enabled failed", d.Id(), err)
+	}
+
+	return diags
+}
+
+func minioDeleteILMPolicyRule(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	m := meta.(*S3MinioClient)
+	bucket := d.Get("bucket").(string)
+	ruleID := d.Get("rule_id").(string)
+
+	ilmPolicyLock.Lock(bucket)
+	defer ilmPolicyLock.Unlock(bucket)
+
+	existing, err := minioGetBucketLifecycleRules(ctx, m.S3Client, bucket)
+	if err != nil {
+		if isNotFoundError(err) {
+			tflog.Warn(ctx, "Bucket no longer exists, its lifecycle rules are gone with it", map[string]interface{}{"bucket": bucket})
+			d.SetId("")
+			return nil
+		}
+		return NewResourceError("reading existing bucket lifecycle failed", bucket, err)
+	}
+
+	// an empty configuration removes the lifecycle configuration of the bucket
+	config := lifecycle.NewConfiguration()
+	config.Rules = mergeILMRules(existing, nil, map[string]bool{ruleID: true})
+	err = retryOnError(ctx, retryTimeout, func() error {
+		return m.S3Client.SetBucketLifecycle(ctx, bucket, config)
+	})
+	m.LifecycleCache.Invalidate(bucket)
+	if err != nil && !isNotFoundError(err) {
+		return NewResourceError("deleting lifecycle rule failed", d.Id(), err)
+	}
+
+	d.SetId("")
+
+	return nil
+}
+
+// minioImportILMPolicyRule imports a rule by its ID, <bucket>/<rule_id>
+func minioImportILMPolicyRule(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	bucket, ruleID, ok := strings.Cut(d.Id(), "/")
+	if !ok || bucket == "" || ruleID == "" {
+		return nil, fmt.Errorf("unexpected ID %q, expected <bucket>/<rule_id>", d.Id())
+	}
+
+	for key, value := range map[string]interface{}{"bucket": bucket, "rule_id": ruleID, "enabled": true} {
+		if err := d.Set(key, value); err != nil {
+			return nil, err
+		}
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func ilmPolicyRuleID(bucket, ruleID string) string {
+	return bucket + "/" + ruleID
+}
+
+// ilmPolicyRuleAsPolicy presents a minio_ilm_policy_rule as a policy with this single rule, so that the rule is
+// built and checked like the rules of minio_ilm_policy
+type ilmPolicyRuleAsPolicy struct {
+	d ilmPolicyGetter
+}
+
+func (p ilmPolicyRuleAsPolicy) Get(key string) interface{} {
+	switch key {
+	case "rule":
+		rule := map[string]interface{}{
+			"id":       p.d.Get("rule_id"),
+			"filter":   "",
+			"tags":     map[string]interface{}{},
+			"prefixes": []interface{}{},
+		}
+		for _, attribute := range ilmPolicyRuleAttributes {
+			rule[attribute] = p.d.Get(attribute)
+		}
+		return []interface{}{rule}
+	case "default_expiration":
+		return ""
+	case "default_transition":
+		return []interface{}{}
+	}
+	return p.d.Get(key)
+}
+
+// ilmPolicyRuleDiagnostics reports the diagnostics of the single rule at the attributes of the resource
+func ilmPolicyRuleDiagnostics(diags diag.Diagnostics) diag.Diagnostics {
+	rulePath := cty.GetAttrPath("rule").IndexInt(0)
+	for i := range diags {
+		if path := diags[i].AttributePath; len(path) >= len(rulePath) && path[:len(rulePath)].Equals(rulePath) {
+			diags[i].AttributePath = path[len(rulePath):]
+		}
+	}
+	return diags
+}
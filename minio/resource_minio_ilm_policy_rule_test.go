@@ -0,0 +1,115 @@
+package minio
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestAccILMPolicyRule_basic(t *testing.T) {
+	name := fmt.Sprintf("test-ilm-policy-rule-%d", acctest.RandInt())
+	resourceName := "minio_ilm_policy_rule.logs"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckMinioS3BucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMinioILMPolicyRuleConfig(name, map[string]string{"logs": "5d", "temp": "1d"}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMinioBucketLifecycleRuleIDs(name, "logs", "temp"),
+					resource.TestCheckResourceAttr(resourceName, "id", name+"/logs"),
+					resource.TestCheckResourceAttr(resourceName, "expiration", "5d"),
+					resource.TestCheckResourceAttr(resourceName, "status", "Enabled"),
+					resource.TestCheckResourceAttr(resourceName, "rule_filter.0.prefix", "logs/"),
+				),
+			},
+			{
+				PreConfig: func() {
+					if err := testAccAddExternalLifecycleRule(name, "external"); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: testAccMinioILMPolicyRuleConfig(name, map[string]string{"logs": "10d"}),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMinioBucketLifecycleRuleIDs(name, "logs", "external"),
+					resource.TestCheckResourceAttr(resourceName, "expiration", "10d"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestILMPolicyRuleAsPolicy(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceMinioILMPolicyRule().Schema, map[string]interface{}{
+		"bucket":     "bucket",
+		"rule_id":    "logs",
+		"expiration": "5d",
+		"rule_filter": []interface{}{
+			map[string]interface{}{"prefix": "logs/"},
+		},
+	})
+
+	rules, diags := ilmPolicyRules(ilmPolicyRuleAsPolicy{d})
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if len(rules) != 1 {
+		t.Fatalf("expected a single rule, got %d", len(rules))
+	}
+	rule := rules[0]
+	if rule.ID != "logs" || rule.Status != "Enabled" || rule.Expiration.Days != 5 || rule.RuleFilter.Prefix != "logs/" {
+		t.Errorf("unexpected rule %+v", rule)
+	}
+}
+
+func TestILMPolicyRuleDiagnostics(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceMinioILMPolicyRule().Schema, map[string]interface{}{
+		"bucket":     "bucket",
+		"rule_id":    "bad-transition",
+		"transition": []interface{}{map[string]interface{}{"days": "1d", "storage_class": ""}},
+	})
+
+	_, diags := ilmPolicyRules(ilmPolicyRuleAsPolicy{d})
+	diags = ilmPolicyRuleDiagnostics(diags)
+	if !diags.HasError() {
+		t.Fatal("expected an error for a transition without storage class")
+	}
+	if expected := cty.GetAttrPath("transition"); !diags[0].AttributePath.Equals(expected) {
+		t.Errorf("diagnostic should point at %#v, got %#v", expected, diags[0].AttributePath)
+	}
+}
+
+func testAccMinioILMPolicyRuleConfig(name string, expirations map[string]string) string {
+	config := fmt.Sprintf(`
+resource "minio_s3_bucket" "bucket" {
+  bucket        = "%s"
+  force_destroy = true
+}
+`, name)
+	for id, expiration := range expirations {
+		config += fmt.Sprintf(`
+resource "minio_ilm_policy_rule" "%[1]s" {
+  bucket     = minio_s3_bucket.bucket.id
+  rule_id    = "%[1]s"
+  expiration = "%[2]s"
+
+  rule_filter {
+    prefix = "%[1]s/"
+  }
+}
+`, id, expiration)
+	}
+
+	return config
+}
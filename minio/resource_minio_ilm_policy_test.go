@@ -2,13 +2,26 @@ package minio
 
 import (
 	"context"
+	"encoding/xml"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"reflect"
+	"regexp"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/minio/madmin-go/v3"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
 	"github.com/minio/minio-go/v7/pkg/lifecycle"
 )
 
@@ -28,7 +41,10 @@ func TestAccILMPolicy_basic(t *testing.T) {
 					testAccCheckMinioS3BucketExists("minio_s3_bucket.bucket"),
 					testAccCheckMinioILMPolicyExists(resourceName, &lifecycleConfig),
 					resource.TestCheckResourceAttr(resourceName, "bucket", name),
+					resource.TestCheckResourceAttr(resourceName, "arn", "arn:aws:s3:::"+name),
 					testAccCheckMinioLifecycleConfigurationValid(&lifecycleConfig),
+					resource.TestCheckResourceAttr(resourceName, "rule_count", "1"),
+					resource.TestCheckResourceAttr(resourceName, "summary", "asdf: expire on 2022-01-01"),
 				),
 			},
 		},
@@ -91,6 +107,319 @@ func TestAccILMPolicy_filterTags(t *testing.T) {
 	})
 }
 
+func TestAccILMPolicy_ruleFilter(t *testing.T) {
+	var lifecycleConfig lifecycle.Configuration
+	name := fmt.Sprintf("test-ilm-rule7-%d", acctest.RandInt())
+	resourceName := "minio_ilm_policy.rule7"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckMinioS3BucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMinioILMPolicyRuleFilter(name),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMinioILMPolicyExists(resourceName, &lifecycleConfig),
+					testAccCheckMinioLifecycleConfigurationValid(&lifecycleConfig),
+					resource.TestCheckResourceAttr(resourceName, "rule.0.rule_filter.0.prefix", "temp/"),
+					resource.TestCheckResourceAttr(resourceName, "rule.0.rule_filter.0.tags.app", "test"),
+					resource.TestCheckResourceAttr(resourceName, "rule.0.rule_filter.0.object_size_greater_than", "1024"),
+					resource.TestCheckResourceAttr(resourceName, "rule.0.rule_filter.0.object_size_less_than", "1048576"),
+					resource.TestCheckResourceAttr(resourceName, "rule.0.filter", ""),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccILMPolicy_singleTagFilter(t *testing.T) {
+	var lifecycleConfig lifecycle.Configuration
+	name := fmt.Sprintf("test-ilm-rule10-%d", acctest.RandInt())
+	resourceName := "minio_ilm_policy.rule10"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckMinioS3BucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMinioILMPolicySingleTagFilter(name),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMinioILMPolicyExists(resourceName, &lifecycleConfig),
+					testAccCheckMinioLifecycleConfigurationValid(&lifecycleConfig),
+					resource.TestCheckResourceAttr(resourceName, "rule.0.rule_filter.0.tags.app", "test"),
+					resource.TestCheckResourceAttr(resourceName, "rule.0.rule_filter.0.prefix", ""),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccILMPolicy_tagOnlyExpiration(t *testing.T) {
+	var lifecycleConfig lifecycle.Configuration
+	name := fmt.Sprintf("test-ilm-tag-only-%d", acctest.RandInt())
+	resourceName := "minio_ilm_policy.tag_only"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckMinioS3BucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMinioILMPolicyTagOnlyExpiration(name),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMinioILMPolicyExists(resourceName, &lifecycleConfig),
+					testAccCheckMinioLifecycleConfigurationValid(&lifecycleConfig),
+					testAccCheckMinioLifecycleRuleTag(&lifecycleConfig, "temp", lifecycle.Tag{Key: "temp", Value: "true"}),
+					testAccCheckMinioLifecycleRuleTag(&lifecycleConfig, "scratch", lifecycle.Tag{Key: "scratch", Value: "yes"}),
+					testAccCheckMinioLifecycleRuleExpirationDays(&lifecycleConfig, "temp", 7),
+					testAccCheckMinioLifecycleRuleExpirationDays(&lifecycleConfig, "scratch", 7),
+					resource.TestCheckResourceAttr(resourceName, "rule.0.expiration", "7d"),
+					resource.TestCheckResourceAttr(resourceName, "rule.0.rule_filter.0.tags.temp", "true"),
+					resource.TestCheckResourceAttr(resourceName, "rule.0.rule_filter.0.prefix", ""),
+					resource.TestCheckResourceAttr(resourceName, "rule.1.expiration", "7d"),
+					resource.TestCheckResourceAttr(resourceName, "rule.1.tags.scratch", "yes"),
+					resource.TestCheckResourceAttr(resourceName, "rule.1.filter", ""),
+				),
+			},
+			{
+				// the rules read back must match the configuration
+				Config:   testAccMinioILMPolicyTagOnlyExpiration(name),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccILMPolicy_prefixes(t *testing.T) {
+	var lifecycleConfig lifecycle.Configuration
+	name := fmt.Sprintf("test-ilm-rule12-%d", acctest.RandInt())
+	resourceName := "minio_ilm_policy.rule12"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckMinioS3BucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMinioILMPolicyPrefixes(name, `"logs/", "tmp/", "cache/"`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMinioILMPolicyExists(resourceName, &lifecycleConfig),
+					testAccCheckMinioLifecycleConfigurationValid(&lifecycleConfig),
+					testAccCheckMinioLifecycleConfigurationRuleIDs(&lifecycleConfig, "cleanup-1", "cleanup-2", "cleanup-3"),
+					resource.TestCheckResourceAttr(resourceName, "rule.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "rule.0.prefixes.#", "3"),
+					resource.TestCheckResourceAttr(resourceName, "rule.0.prefixes.2", "cache/"),
+					resource.TestCheckResourceAttr(resourceName, "rule.0.rule_filter.0.tags.app", "test"),
+					resource.TestCheckResourceAttr(resourceName, "rule_count", "3"),
+				),
+			},
+			{
+				Config: testAccMinioILMPolicyPrefixes(name, `"logs/", "tmp/"`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMinioILMPolicyExists(resourceName, &lifecycleConfig),
+					testAccCheckMinioLifecycleConfigurationRuleIDs(&lifecycleConfig, "cleanup-1", "cleanup-2"),
+					resource.TestCheckResourceAttr(resourceName, "rule.0.prefixes.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "managed_rule_ids.#", "2"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccILMPolicy_defaults(t *testing.T) {
+	var lifecycleConfig lifecycle.Configuration
+	name := fmt.Sprintf("test-ilm-rule8-%d", acctest.RandInt())
+	resourceName := "minio_ilm_policy.rule8"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckMinioS3BucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMinioILMPolicyDefaults(name, "7d"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMinioILMPolicyExists(resourceName, &lifecycleConfig),
+					testAccCheckMinioLifecycleRuleExpirationDays(&lifecycleConfig, "inherited", 7),
+					testAccCheckMinioLifecycleRuleExpirationDays(&lifecycleConfig, "overridden", 14),
+					resource.TestCheckResourceAttr(resourceName, "default_expiration", "7d"),
+					resource.TestCheckResourceAttr(resourceName, "rule.0.expiration", ""),
+					resource.TestCheckResourceAttr(resourceName, "rule.1.expiration", "14d"),
+				),
+			},
+			{
+				// only the defaults change, the rules inheriting them must be written again
+				Config: testAccMinioILMPolicyDefaults(name, "30d"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMinioILMPolicyExists(resourceName, &lifecycleConfig),
+					testAccCheckMinioLifecycleRuleExpirationDays(&lifecycleConfig, "inherited", 30),
+					testAccCheckMinioLifecycleRuleExpirationDays(&lifecycleConfig, "overridden", 14),
+					resource.TestCheckResourceAttr(resourceName, "default_expiration", "30d"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckMinioLifecycleRuleExpirationDays(config *lifecycle.Configuration, id string, days int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, r := range config.Rules {
+			if r.ID == id {
+				if int(r.Expiration.Days) != days {
+					return fmt.Errorf("lifecycle rule %s expires after %d days, expected %d", id, r.Expiration.Days, days)
+				}
+				return nil
+			}
+		}
+		return fmt.Errorf("lifecycle rule %s not found", id)
+	}
+}
+
+// testAccCheckMinioLifecycleRuleTag checks the rule is filtered by a single Tag element, not by an And element
+func testAccCheckMinioLifecycleRuleTag(config *lifecycle.Configuration, id string, tag lifecycle.Tag) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, r := range config.Rules {
+			if r.ID == id {
+				if r.RuleFilter.Tag.Key != tag.Key || r.RuleFilter.Tag.Value != tag.Value || !r.RuleFilter.And.IsEmpty() || r.RuleFilter.Prefix != "" {
+					return fmt.Errorf("lifecycle rule %s has filter %+v, expected only tag %+v", id, r.RuleFilter, tag)
+				}
+				return nil
+			}
+		}
+		return fmt.Errorf("lifecycle rule %s not found", id)
+	}
+}
+
+func TestParseILMRuleFilter(t *testing.T) {
+	legacy := map[string]interface{}{
+		"filter":      "temp/",
+		"tags":        map[string]interface{}{},
+		"rule_filter": []interface{}{},
+	}
+	filter, err := parseILMRuleFilter(legacy)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if filter.Prefix != "temp/" || !filter.And.IsEmpty() {
+		t.Fatalf("legacy prefix should map to a plain prefix filter, got %+v", filter)
+	}
+
+	block := map[string]interface{}{
+		"filter": "",
+		"tags":   map[string]interface{}{},
+		"rule_filter": []interface{}{
+			map[string]interface{}{
+				"prefix":                   "temp/",
+				"tags":                     map[string]interface{}{"app": "test"},
+				"object_size_greater_than": 1024,
+				"object_size_less_than":    0,
+			},
+		},
+	}
+	filter, err = parseILMRuleFilter(block)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if filter.And.Prefix != "temp/" || filter.And.ObjectSizeGreaterThan != 1024 || len(filter.And.Tags) != 1 {
+		t.Fatalf("multiple conditions should map to an And filter, got %+v", filter)
+	}
+
+	flattened := flattenILMRuleFilter(filter)
+	if flattened["prefix"] != "temp/" || flattened["object_size_greater_than"] != 1024 || flattened["tags"].(map[string]string)["app"] != "test" {
+		t.Fatalf("unexpected flattened filter: %v", flattened)
+	}
+
+	block["filter"] = "other/"
+	if _, err := parseILMRuleFilter(block); err == nil {
+		t.Fatalf("rule_filter together with the deprecated filter should be rejected")
+	}
+
+	block["filter"] = ""
+	block["rule_filter"].([]interface{})[0].(map[string]interface{})["object_size_less_than"] = 512
+	if _, err := parseILMRuleFilter(block); err == nil {
+		t.Fatalf("object_size_greater_than above object_size_less_than should be rejected")
+	}
+}
+
+func TestILMRuleFilterRoundTrip(t *testing.T) {
+	cases := []struct {
+		name            string
+		prefix          string
+		tags            map[string]interface{}
+		sizeGreaterThan int
+		sizeLessThan    int
+		and             bool
+	}{
+		{name: "one tag no prefix", tags: map[string]interface{}{"app": "test"}},
+		{name: "multiple tags no prefix", tags: map[string]interface{}{"app": "test", "env": "dev"}, and: true},
+		{name: "one tag with prefix", prefix: "temp/", tags: map[string]interface{}{"app": "test"}, and: true},
+		{name: "size bounds", sizeGreaterThan: 1024, sizeLessThan: 1048576, and: true},
+		{name: "size and one tag", tags: map[string]interface{}{"app": "test"}, sizeGreaterThan: 1024, and: true},
+		{name: "size, tags and prefix", prefix: "temp/", tags: map[string]interface{}{"app": "test", "env": "dev"}, sizeGreaterThan: 1024, sizeLessThan: 1048576, and: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			filter, err := parseILMRuleFilter(map[string]interface{}{
+				"filter": "",
+				"tags":   map[string]interface{}{},
+				"rule_filter": []interface{}{
+					map[string]interface{}{
+						"prefix":                   tc.prefix,
+						"tags":                     tc.tags,
+						"object_size_greater_than": tc.sizeGreaterThan,
+						"object_size_less_than":    tc.sizeLessThan,
+					},
+				},
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			written, err := xml.Marshal(lifecycle.Configuration{Rules: []lifecycle.Rule{{ID: "rule", Status: "Enabled", RuleFilter: filter}}})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if strings.Contains(string(written), "<And>") != tc.and {
+				t.Fatalf("expected And to be used: %t, got %s", tc.and, written)
+			}
+
+			var read lifecycle.Configuration
+			if err := xml.Unmarshal(written, &read); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			flattened := flattenILMRuleFilter(read.Rules[0].RuleFilter)
+			if flattened["prefix"] != tc.prefix {
+				t.Fatalf("expected prefix %q, got %q", tc.prefix, flattened["prefix"])
+			}
+			if flattened["object_size_greater_than"] != tc.sizeGreaterThan || flattened["object_size_less_than"] != tc.sizeLessThan {
+				t.Fatalf("expected sizes (%d, %d), got (%v, %v)", tc.sizeGreaterThan, tc.sizeLessThan, flattened["object_size_greater_than"], flattened["object_size_less_than"])
+			}
+			readTags := flattened["tags"].(map[string]string)
+			if len(readTags) != len(tc.tags) {
+				t.Fatalf("expected tags %v, got %v", tc.tags, readTags)
+			}
+			for k, v := range tc.tags {
+				if readTags[k] != v {
+					t.Fatalf("expected tags %v, got %v", tc.tags, readTags)
+				}
+			}
+		})
+	}
+}
+
 func TestAccILMPolicy_expireNoncurrentVersion(t *testing.T) {
 	var lifecycleConfig lifecycle.Configuration
 	name := fmt.Sprintf("test-ilm-rule4-%d", acctest.RandInt())
@@ -116,6 +445,35 @@ func TestAccILMPolicy_expireNoncurrentVersion(t *testing.T) {
 	})
 }
 
+func TestAccILMPolicy_expireAllVersions(t *testing.T) {
+	var lifecycleConfig lifecycle.Configuration
+	name := fmt.Sprintf("test-ilm-rule-all-versions-%d", acctest.RandInt())
+	resourceName := "minio_ilm_policy.rule"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckMinioS3BucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMinioILMPolicyExpireAllVersions(name),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMinioILMPolicyExists(resourceName, &lifecycleConfig),
+					testAccCheckMinioLifecycleConfigurationValid(&lifecycleConfig),
+					resource.TestCheckResourceAttr(resourceName, "rule.0.expiration", "7d"),
+					resource.TestCheckResourceAttr(resourceName, "rule.0.expire_all_versions", "true"),
+					resource.TestCheckResourceAttr(resourceName, "rule.0.noncurrent_version_expiration_days", "0"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
 func TestAccILMPolicy_transition(t *testing.T) {
 	var lifecycleConfig lifecycle.Configuration
 	resourceName := "minio_ilm_policy.rule_transition"
@@ -140,36 +498,1706 @@ func TestAccILMPolicy_transition(t *testing.T) {
 					testAccMinioBucketTransitionConfigBucket("my_bucket_in_b", "secondminio", secondBucketName) +
 					testAccMinioILMPolicyTransitionServiceAccount(username) +
 					testAccMinioRemoteTierConfig(remoteTierName, secondaryMinioEndpoint) +
-					testAccMinioILMPolicyTransitionConfig(),
+					testAccMinioILMPolicyTransitionConfig("1d"),
+
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMinioS3BucketExists("minio_s3_bucket.my_bucket_in_a"),
+					testAccCheckMinioILMPolicyExists(resourceName, &lifecycleConfig),
+					resource.TestCheckResourceAttr(resourceName, "bucket", bucketName),
+					testAccCheckMinioLifecycleConfigurationValid(&lifecycleConfig),
+					resource.TestCheckResourceAttr(
+						resourceName, "rule.0.transition.0.days", "1d"),
+				),
+			},
+			{
+				// a plain number of days is read back as 2d without a perpetual diff
+				Config: testAccBucketReplicationConfigLocals(primaryMinioEndpoint, secondaryMinioEndpoint) +
+					testAccMinioBucketTransitionConfigBucket("my_bucket_in_a", "minio", bucketName) +
+					testAccMinioBucketTransitionConfigBucket("my_bucket_in_b", "secondminio", secondBucketName) +
+					testAccMinioILMPolicyTransitionServiceAccount(username) +
+					testAccMinioRemoteTierConfig(remoteTierName, secondaryMinioEndpoint) +
+					testAccMinioILMPolicyTransitionConfig("2"),
+
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMinioILMPolicyExists(resourceName, &lifecycleConfig),
+					testAccCheckMinioLifecycleConfigurationValid(&lifecycleConfig),
+					resource.TestCheckResourceAttr(
+						resourceName, "rule.0.transition.0.days", "2d"),
+				),
+			},
+			{
+				Config: testAccBucketReplicationConfigLocals(primaryMinioEndpoint, secondaryMinioEndpoint) +
+					testAccMinioBucketTransitionConfigBucket("my_bucket_in_a", "minio", bucketName) +
+					testAccMinioBucketTransitionConfigBucket("my_bucket_in_b", "secondminio", secondBucketName) +
+					testAccMinioILMPolicyTransitionServiceAccount(username) +
+					testAccMinioRemoteTierConfig(remoteTierName, secondaryMinioEndpoint) +
+					testAccMinioILMPolicyTransitionDateConfig("2024-06-06"),
+
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMinioS3BucketExists("minio_s3_bucket.my_bucket_in_a"),
+					testAccCheckMinioILMPolicyExists(resourceName, &lifecycleConfig),
+					resource.TestCheckResourceAttr(resourceName, "bucket", bucketName),
+					testAccCheckMinioLifecycleConfigurationValid(&lifecycleConfig),
+					resource.TestCheckResourceAttr(
+						resourceName, "rule.0.transition.0.date", "2024-06-06"),
+				),
+			},
+			{
+				Config: testAccBucketReplicationConfigLocals(primaryMinioEndpoint, secondaryMinioEndpoint) +
+					testAccMinioBucketTransitionConfigBucket("my_bucket_in_a", "minio", bucketName) +
+					testAccMinioBucketTransitionConfigBucket("my_bucket_in_b", "secondminio", secondBucketName) +
+					testAccMinioILMPolicyTransitionServiceAccount(username) +
+					testAccMinioRemoteTierConfig(remoteTierName, secondaryMinioEndpoint) +
+					testAccMinioILMPolicyTransitionDateConfig("2020-01-01"),
+
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMinioILMPolicyExists(resourceName, &lifecycleConfig),
+					testAccCheckMinioLifecycleConfigurationValid(&lifecycleConfig),
+					resource.TestCheckResourceAttr(
+						resourceName, "rule.0.transition.0.date", "2020-01-01"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccILMPolicy_currentAndNoncurrentTransition(t *testing.T) {
+	var lifecycleConfig lifecycle.Configuration
+	resourceName := "minio_ilm_policy.rule_transition"
+
+	bucketName := acctest.RandomWithPrefix("tf-acc-test-a")
+	secondBucketName := acctest.RandomWithPrefix("tf-acc-test-b")
+	username := acctest.RandomWithPrefix("tf-acc-usr")
+
+	primaryMinioEndpoint := os.Getenv("MINIO_ENDPOINT")
+	secondaryMinioEndpoint := os.Getenv("SECOND_MINIO_ENDPOINT")
+
+	remoteTierName := acctest.RandomWithPrefix("COLD")
+
+	config := func(noncurrentStorageClass string) string {
+		return testAccBucketReplicationConfigLocals(primaryMinioEndpoint, secondaryMinioEndpoint) +
+			testAccMinioBucketTransitionConfigBucket("my_bucket_in_a", "minio", bucketName) +
+			testAccMinioBucketTransitionConfigBucket("my_bucket_in_b", "secondminio", secondBucketName) +
+			testAccMinioILMPolicyTransitionServiceAccount(username) +
+			testAccMinioRemoteTierConfig(remoteTierName, secondaryMinioEndpoint) +
+			testAccMinioILMPolicyCurrentAndNoncurrentTransitionConfig(noncurrentStorageClass)
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckMinioS3BucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config(""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMinioILMPolicyExists(resourceName, &lifecycleConfig),
+					testAccCheckMinioLifecycleConfigurationValid(&lifecycleConfig),
+					testAccCheckMinioLifecycleRuleTransitions(&lifecycleConfig, "both", 30, remoteTierName, 7, remoteTierName),
+					resource.TestCheckResourceAttr(resourceName, "rule.0.transition.0.days", "30d"),
+					resource.TestCheckResourceAttr(resourceName, "rule.0.transition.0.storage_class", remoteTierName),
+					resource.TestCheckResourceAttr(resourceName, "rule.0.noncurrent_version_transition_days", "7"),
+					resource.TestCheckResourceAttr(resourceName, "rule.0.noncurrent_version_transition_storage_class", ""),
+					resource.TestCheckResourceAttr("minio_ilm_tier.remote_tier", "arn", "arn:minio:ilm:::tier/"+remoteTierName),
+				),
+			},
+			{
+				Config: config("${minio_ilm_tier.remote_tier.name}"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMinioILMPolicyExists(resourceName, &lifecycleConfig),
+					testAccCheckMinioLifecycleRuleTransitions(&lifecycleConfig, "both", 30, remoteTierName, 7, remoteTierName),
+					resource.TestCheckResourceAttr(resourceName, "rule.0.noncurrent_version_transition_storage_class", remoteTierName),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccCheckMinioLifecycleRuleTransitions(config *lifecycle.Configuration, id string, days int, storageClass string, noncurrentDays int, noncurrentStorageClass string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, r := range config.Rules {
+			if r.ID != id {
+				continue
+			}
+			if int(r.Transition.Days) != days || r.Transition.StorageClass != storageClass {
+				return fmt.Errorf("rule %s: expected transition to %s after %d days, got %+v", id, storageClass, days, r.Transition)
+			}
+			if int(r.NoncurrentVersionTransition.NoncurrentDays) != noncurrentDays || r.NoncurrentVersionTransition.StorageClass != noncurrentStorageClass {
+				return fmt.Errorf("rule %s: expected noncurrent transition to %s after %d days, got %+v", id, noncurrentStorageClass, noncurrentDays, r.NoncurrentVersionTransition)
+			}
+			return nil
+		}
+		return fmt.Errorf("lifecycle rule %s not found", id)
+	}
+}
+
+type fakeTierLister struct {
+	tiers []*madmin.TierConfig
+	calls int
+}
+
+func (f *fakeTierLister) ListTiers(ctx context.Context) ([]*madmin.TierConfig, error) {
+	f.calls++
+	return f.tiers, nil
+}
+
+// fakeILMPolicyChange holds the prior and planned values of the attributes of a policy
+type fakeILMPolicyChange map[string][2][]interface{}
+
+func (f fakeILMPolicyChange) GetChange(key string) (interface{}, interface{}) {
+	return f[key][0], f[key][1]
+}
+
+func TestILMPolicyMissingTiers(t *testing.T) {
+	// the tier defined next to the policy has been created, the other one does not exist on the server
+	client := &fakeTierLister{tiers: []*madmin.TierConfig{{Name: "COLD", Type: madmin.MinIO}}}
+
+	missing, err := ilmPolicyMissingTiers(context.Background(), client, []string{"COLD", "GLACIER"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !reflect.DeepEqual(missing, []string{"GLACIER"}) {
+		t.Fatalf("expected GLACIER to be missing, got %v", missing)
+	}
+
+	missing, err = ilmPolicyMissingTiers(context.Background(), client, []string{"COLD"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(missing) != 0 {
+		t.Fatalf("transition to a configured tier should not be reported, got %v", missing)
+	}
+}
+
+func TestMinioCheckILMPolicyTiers(t *testing.T) {
+	transition := func(storageClass string) []interface{} {
+		return []interface{}{map[string]interface{}{"days": "5d", "storage_class": storageClass}}
+	}
+	rules := func(storageClasses ...string) []interface{} {
+		var rules []interface{}
+		for _, storageClass := range storageClasses {
+			rules = append(rules, map[string]interface{}{
+				"transition": transition(storageClass),
+				"noncurrent_version_transition_storage_class": "",
+			})
+		}
+		return rules
+	}
+
+	client := &fakeTierLister{tiers: []*madmin.TierConfig{{Name: "COLD", Type: madmin.MinIO}}}
+	diags := minioCheckILMPolicyTiers(context.Background(), fakeILMPolicyChange{
+		"rule":               {nil, rules("COLD", "GLACIER", "")},
+		"default_transition": {nil, nil},
+	}, client)
+	if len(diags) != 1 || diags[0].Severity != diag.Warning || !strings.Contains(diags[0].Summary, "GLACIER") {
+		t.Fatalf("expected a warning for GLACIER only, got %v", diags)
+	}
+	if client.calls != 1 {
+		t.Fatalf("expected the tiers to be listed once, got %d", client.calls)
+	}
+
+	// the tiers are not listed again when no storage class is added
+	for _, change := range []fakeILMPolicyChange{
+		{"rule": {rules("GLACIER"), rules("GLACIER")}, "default_transition": {nil, nil}},
+		{"rule": {rules("GLACIER"), nil}, "default_transition": {nil, transition("GLACIER")}},
+		{"rule": {nil, rules("")}, "default_transition": {nil, nil}},
+	} {
+		if diags := minioCheckILMPolicyTiers(context.Background(), change, client); len(diags) != 0 {
+			t.Fatalf("unexpected diagnostics: %v", diags)
+		}
+	}
+	if client.calls != 1 {
+		t.Fatalf("the tiers should only be listed when a storage class is added, got %d calls", client.calls)
+	}
+}
+
+func TestILMPolicyRules_attributePath(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceMinioILMPolicy().Schema, map[string]interface{}{
+		"bucket": "bucket",
+		"rule": []interface{}{
+			map[string]interface{}{"id": "valid", "expiration": "5d"},
+			map[string]interface{}{
+				"id":         "bad-transition",
+				"transition": []interface{}{map[string]interface{}{"days": "1d", "storage_class": ""}},
+			},
+			map[string]interface{}{"id": "no-action"},
+		},
+	})
+
+	_, diags := ilmPolicyRules(d)
+	if len(diags) != 2 {
+		t.Fatalf("expected 2 diagnostics, got %v", diags)
+	}
+
+	expected := []struct {
+		path cty.Path
+		id   string
+	}{
+		{cty.GetAttrPath("rule").IndexInt(1).GetAttr("transition"), "bad-transition"},
+		{cty.GetAttrPath("rule").IndexInt(2), "no-action"},
+	}
+	for i, e := range expected {
+		if !diags[i].AttributePath.Equals(e.path) {
+			t.Fatalf("diagnostic %d should point at %#v, got %#v", i, e.path, diags[i].AttributePath)
+		}
+		if !strings.Contains(diags[i].Summary, e.id) {
+			t.Fatalf("diagnostic %d should name rule %s, got %q", i, e.id, diags[i].Summary)
+		}
+	}
+}
+
+func TestILMPolicyRules_expireAllVersions(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceMinioILMPolicy().Schema, map[string]interface{}{
+		"bucket": "bucket",
+		"rule": []interface{}{
+			map[string]interface{}{"id": "days", "expiration": "7d", "expire_all_versions": true},
+			map[string]interface{}{"id": "date", "expiration": "2030-01-01", "expire_all_versions": true},
+			map[string]interface{}{"id": "both", "expiration": "7d", "expire_all_versions": true, "noncurrent_version_expiration_days": 3},
+		},
+	})
+
+	rules, diags := ilmPolicyRules(d)
+	if rules[0].Expiration.Days != 7 || rules[0].NoncurrentVersionExpiration.NoncurrentDays != 7 {
+		t.Fatalf("expected current and noncurrent versions to expire after 7 days, got %+v", rules[0])
+	}
+
+	if len(diags) != 2 {
+		t.Fatalf("expected 2 diagnostics, got %v", diags)
+	}
+	for i, id := range []string{"date", "both"} {
+		path := cty.GetAttrPath("rule").IndexInt(i + 1).GetAttr("expire_all_versions")
+		if !diags[i].AttributePath.Equals(path) {
+			t.Fatalf("diagnostic %d should point at %#v, got %#v", i, path, diags[i].AttributePath)
+		}
+		if !strings.Contains(diags[i].Summary, id) {
+			t.Fatalf("diagnostic %d should name rule %s, got %q", i, id, diags[i].Summary)
+		}
+	}
+}
+
+func TestILMPolicyRules_prefixes(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceMinioILMPolicy().Schema, map[string]interface{}{
+		"bucket": "bucket",
+		"rule": []interface{}{
+			map[string]interface{}{
+				"id":          "cleanup",
+				"expiration":  "7d",
+				"prefixes":    []interface{}{"logs/", "tmp/"},
+				"rule_filter": []interface{}{map[string]interface{}{"tags": map[string]interface{}{"app": "test"}}},
+			},
+		},
+	})
+
+	rules, diags := ilmPolicyRules(d)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if len(rules) != 2 {
+		t.Fatalf("expected one rule per prefix, got %+v", rules)
+	}
+	for i, prefix := range []string{"logs/", "tmp/"} {
+		if id := fmt.Sprintf("cleanup-%d", i+1); rules[i].ID != id {
+			t.Fatalf("rule %d: expected id %s, got %s", i, id, rules[i].ID)
+		}
+		if rules[i].RuleFilter.And.Prefix != prefix || len(rules[i].RuleFilter.And.Tags) != 1 {
+			t.Fatalf("rule %d: expected prefix %s combined with the tag, got %+v", i, prefix, rules[i].RuleFilter)
+		}
+		if rules[i].Expiration.Days != 7 {
+			t.Fatalf("rule %d: expected the actions of the rule, got %+v", i, rules[i])
+		}
+	}
+
+	invalid := map[string]map[string]interface{}{
+		"legacy filter": {"id": "a", "expiration": "7d", "prefixes": []interface{}{"logs/"}, "filter": "tmp/"},
+		"rule_filter prefix": {"id": "b", "expiration": "7d", "prefixes": []interface{}{"logs/"},
+			"rule_filter": []interface{}{map[string]interface{}{"prefix": "tmp/"}}},
+		"duplicate prefix": {"id": "c", "expiration": "7d", "prefixes": []interface{}{"logs/", "logs/"}},
+	}
+	for name, rule := range invalid {
+		d := schema.TestResourceDataRaw(t, resourceMinioILMPolicy().Schema, map[string]interface{}{
+			"bucket": "bucket",
+			"rule":   []interface{}{rule},
+		})
+		if _, diags := ilmPolicyRules(d); !diags.HasError() {
+			t.Fatalf("%s: expected an error", name)
+		}
+	}
+
+	d = schema.TestResourceDataRaw(t, resourceMinioILMPolicy().Schema, map[string]interface{}{
+		"bucket": "bucket",
+		"rule": []interface{}{
+			map[string]interface{}{"id": "cleanup", "expiration": "7d", "prefixes": []interface{}{"logs/", "tmp/"}},
+			map[string]interface{}{"id": "cleanup-2", "expiration": "7d"},
+		},
+	})
+	if _, diags := ilmPolicyRules(d); !diags.HasError() {
+		t.Fatalf("expected generated rule ids to be checked for duplicates")
+	}
+}
+
+func TestILMPolicyRules_noncurrentVersionTransition(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceMinioILMPolicy().Schema, map[string]interface{}{
+		"bucket": "bucket",
+		"rule": []interface{}{
+			map[string]interface{}{"id": "explicit", "noncurrent_version_transition_days": 5, "noncurrent_version_transition_storage_class": "COLD"},
+			map[string]interface{}{
+				"id":                                 "inherited",
+				"noncurrent_version_transition_days": 5,
+				"transition":                         []interface{}{map[string]interface{}{"days": "10d", "storage_class": "WARM"}},
+			},
+			map[string]interface{}{"id": "missing-storage-class", "noncurrent_version_transition_days": 5},
+		},
+	})
+
+	rules, diags := ilmPolicyRules(d)
+	if rules[0].NoncurrentVersionTransition.StorageClass != "COLD" {
+		t.Fatalf("expected noncurrent versions of rule explicit to move to COLD, got %+v", rules[0].NoncurrentVersionTransition)
+	}
+	if rules[1].NoncurrentVersionTransition.StorageClass != "WARM" {
+		t.Fatalf("expected noncurrent versions of rule inherited to move to WARM, got %+v", rules[1].NoncurrentVersionTransition)
+	}
+
+	if len(diags) != 1 {
+		t.Fatalf("expected 1 diagnostic, got %v", diags)
+	}
+	path := cty.GetAttrPath("rule").IndexInt(2).GetAttr("noncurrent_version_transition_storage_class")
+	if !diags[0].AttributePath.Equals(path) || !strings.Contains(diags[0].Summary, "missing-storage-class") {
+		t.Fatalf("expected an error naming rule missing-storage-class at %#v, got %#v", path, diags[0])
+	}
+}
+
+func TestILMPolicyRules_noncurrentVersionTransitionNewerVersions(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceMinioILMPolicy().Schema, map[string]interface{}{
+		"bucket": "bucket",
+		"rule": []interface{}{
+			map[string]interface{}{
+				"id":                                 "newer",
+				"noncurrent_version_transition_days": 5,
+				"noncurrent_version_transition_storage_class":  "COLD",
+				"noncurrent_version_transition_newer_versions": 3,
+			},
+			map[string]interface{}{"id": "missing-days", "noncurrent_version_expiration_days": 5, "noncurrent_version_transition_newer_versions": 3},
+		},
+	})
+
+	rules, diags := ilmPolicyRules(d)
+	expected := lifecycle.NoncurrentVersionTransition{NoncurrentDays: 5, StorageClass: "COLD", NewerNoncurrentVersions: 3}
+	if !reflect.DeepEqual(rules[0].NoncurrentVersionTransition, expected) {
+		t.Fatalf("expected noncurrent transition %+v, got %+v", expected, rules[0].NoncurrentVersionTransition)
+	}
+
+	if len(diags) != 1 {
+		t.Fatalf("expected 1 diagnostic, got %v", diags)
+	}
+	path := cty.GetAttrPath("rule").IndexInt(1).GetAttr("noncurrent_version_transition_newer_versions")
+	if !diags[0].AttributePath.Equals(path) || !strings.Contains(diags[0].Summary, "missing-days") ||
+		!strings.Contains(diags[0].Summary, "requires noncurrent_version_transition_days") {
+		t.Fatalf("expected an error naming rule missing-days at %#v, got %#v", path, diags[0])
+	}
+
+	summary := ilmRulePrimaryAction(rules[0])
+	if summary != "transition noncurrent versions to COLD after 5d, keeping 3 newer" {
+		t.Fatalf("unexpected summary %q", summary)
+	}
+}
+
+func TestILMPolicyRules_incompleteTransition(t *testing.T) {
+	for name, transition := range map[string]map[string]interface{}{
+		"storage class only": {"storage_class": "COLD"},
+		"days and date":      {"days": "5d", "date": "2030-01-01", "storage_class": "COLD"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resourceMinioILMPolicy().Schema, map[string]interface{}{
+				"bucket": "bucket",
+				"rule": []interface{}{map[string]interface{}{
+					"id":         "archive",
+					"transition": []interface{}{transition},
+				}},
+			})
+
+			_, diags := ilmPolicyRules(d)
+			if len(diags) != 1 || !strings.Contains(diags[0].Summary, "invalid lifecycle rule transition (archive)") {
+				t.Fatalf("expected an error naming rule archive, got %v", diags)
+			}
+			if path := cty.GetAttrPath("rule").IndexInt(0).GetAttr("transition"); !diags[0].AttributePath.Equals(path) {
+				t.Fatalf("expected the error at %#v, got %#v", path, diags[0].AttributePath)
+			}
+		})
+	}
+}
+
+func TestILMPolicyRules_transitionAfterExpiration(t *testing.T) {
+	for name, tc := range map[string]struct {
+		policy map[string]interface{}
+		path   cty.Path
+		err    string
+	}{
+		"transition before expiration": {
+			policy: map[string]interface{}{"rule": []interface{}{map[string]interface{}{
+				"id": "archive", "expiration": "30d", "transition": []interface{}{map[string]interface{}{"days": "10d", "storage_class": "COLD"}},
+			}}},
+		},
+		"transition on expiration": {
+			policy: map[string]interface{}{"rule": []interface{}{map[string]interface{}{
+				"id": "archive", "expiration": "30d", "transition": []interface{}{map[string]interface{}{"days": "30", "storage_class": "COLD"}},
+			}}},
+			path: cty.GetAttrPath("rule").IndexInt(0).GetAttr("transition"),
+			err:  "invalid lifecycle rule transition (archive): transition days (30d) must be lower than expiration days (30d)",
+		},
+		"transition after expiration": {
+			policy: map[string]interface{}{"rule": []interface{}{map[string]interface{}{
+				"id": "archive", "expiration": "30d", "transition": []interface{}{map[string]interface{}{"days": "40d", "storage_class": "COLD"}},
+			}}},
+			path: cty.GetAttrPath("rule").IndexInt(0).GetAttr("transition"),
+			err:  "invalid lifecycle rule transition (archive): transition days (40d) must be lower than expiration days (30d)",
+		},
+		"default transition after expiration": {
+			policy: map[string]interface{}{
+				"default_transition": []interface{}{map[string]interface{}{"days": "60d", "storage_class": "COLD"}},
+				"rule":               []interface{}{map[string]interface{}{"id": "archive", "expiration": "30d"}},
+			},
+			path: cty.GetAttrPath("default_transition"),
+			err:  "invalid lifecycle rule transition (archive): transition days (60d) must be lower than expiration days (30d)",
+		},
+		"date based transition": {
+			policy: map[string]interface{}{"rule": []interface{}{map[string]interface{}{
+				"id": "archive", "expiration": "30d", "transition": []interface{}{map[string]interface{}{"date": "2030-01-01", "storage_class": "COLD"}},
+			}}},
+		},
+		"date based expiration": {
+			policy: map[string]interface{}{"rule": []interface{}{map[string]interface{}{
+				"id": "archive", "expiration": "2030-01-01", "transition": []interface{}{map[string]interface{}{"days": "40d", "storage_class": "COLD"}},
+			}}},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			tc.policy["bucket"] = "bucket"
+			d := schema.TestResourceDataRaw(t, resourceMinioILMPolicy().Schema, tc.policy)
+
+			_, diags := ilmPolicyRules(d)
+			if tc.err == "" {
+				if diags.HasError() {
+					t.Fatalf("unexpected errors: %v", diags)
+				}
+				return
+			}
+			if len(diags) != 1 || !strings.Contains(diags[0].Summary, tc.err) {
+				t.Fatalf("expected an error containing %q, got %v", tc.err, diags)
+			}
+			if !diags[0].AttributePath.Equals(tc.path) {
+				t.Fatalf("expected the error at %#v, got %#v", tc.path, diags[0].AttributePath)
+			}
+		})
+	}
+}
+
+func TestAccILMPolicy_transitionAfterExpiration(t *testing.T) {
+	name := fmt.Sprintf("test-ilm-transition-expiration-%d", acctest.RandInt())
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckMinioS3BucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "minio_s3_bucket" "bucket" {
+  bucket = "%s"
+}
+resource "minio_ilm_policy" "rule" {
+  bucket = minio_s3_bucket.bucket.id
+  rule {
+    id         = "archive"
+    expiration = "30d"
+    transition {
+      days          = "30d"
+      storage_class = "COLD"
+    }
+  }
+}
+`, name),
+				ExpectError: regexp.MustCompile(`archive\): transition days \(30d\) must be lower than expiration days \(30d\)`),
+			},
+		},
+	})
+}
+
+func TestAccILMPolicy_incompleteTransition(t *testing.T) {
+	name := fmt.Sprintf("test-ilm-incomplete-transition-%d", acctest.RandInt())
+	config := func(transition string) string {
+		return fmt.Sprintf(`
+resource "minio_s3_bucket" "bucket" {
+  bucket = "%s"
+}
+resource "minio_ilm_policy" "rule" {
+  bucket = minio_s3_bucket.bucket.id
+  rule {
+    id = "archive"
+    transition {
+      %s
+      storage_class = "COLD"
+    }
+  }
+}
+`, name, transition)
+	}
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckMinioS3BucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      config(""),
+				ExpectError: regexp.MustCompile(`archive\): transition requires either days \(5d\) or date`),
+			},
+			{
+				Config:      config(`days = "5d"` + "\n      " + `date = "2030-01-01"`),
+				ExpectError: regexp.MustCompile(`archive\): transition requires exactly one of days \(5d\) or date`),
+			},
+		},
+	})
+}
+
+func TestAccILMPolicy_noncurrentVersionTransitionWithoutStorageClass(t *testing.T) {
+	name := fmt.Sprintf("test-ilm-noncurrent-transition-%d", acctest.RandInt())
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckMinioS3BucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "minio_s3_bucket" "bucket" {
+  bucket = "%s"
+}
+resource "minio_ilm_policy" "rule" {
+  bucket = minio_s3_bucket.bucket.id
+  rule {
+    id                                 = "noncurrentTransition"
+    noncurrent_version_transition_days = 5
+  }
+}
+`, name),
+				ExpectError: regexp.MustCompile(`noncurrentTransition\): noncurrent_version_transition_days requires`),
+			},
+		},
+	})
+}
+
+func TestValidateILMExpiration(t *testing.T) {
+	for _, expiration := range []string{"1d", "365d", "2030-01-01", "DeleteMarker"} {
+		if diags := validateILMExpiration(expiration, cty.GetAttrPath("expiration")); diags.HasError() {
+			t.Errorf("%q should be a valid expiration: %v", expiration, diags)
+		}
+	}
+
+	for expiration, expected := range map[string]string{
+		"1h":     "MinIO lifecycle supports whole days only, use a number of days such as 1d",
+		"36h":    "MinIO lifecycle supports whole days only, use a number of days such as 1d",
+		"48h":    "MinIO lifecycle supports whole days only, use 2d instead",
+		"90m":    "MinIO lifecycle supports whole days only",
+		"":       "expiration must be a duration (5d)",
+		"5 days": "expiration must be a duration (5d)",
+	} {
+		diags := validateILMExpiration(expiration, cty.GetAttrPath("expiration"))
+		if !diags.HasError() || !strings.Contains(diags[0].Summary, expected) {
+			t.Errorf("%q should be rejected with %q, got %v", expiration, expected, diags)
+		}
+	}
+}
+
+func TestILMPolicyRules_subDayExpiration(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceMinioILMPolicy().Schema, map[string]interface{}{
+		"bucket": "bucket",
+		"rule": []interface{}{
+			map[string]interface{}{"id": "hourly", "expiration": "36h"},
+		},
+	})
+
+	_, diags := ilmPolicyRules(d)
+	path := cty.GetAttrPath("rule").IndexInt(0).GetAttr("expiration")
+	if len(diags) == 0 || !diags[0].AttributePath.Equals(path) || !strings.Contains(diags[0].Summary, "(hourly): expiration \"36h\": MinIO lifecycle supports whole days only") {
+		t.Fatalf("expected an error naming rule hourly at %#v, got %v", path, diags)
+	}
+}
+
+func TestILMPolicyRules_deleteMarkerRequiresNoncurrentExpiration(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceMinioILMPolicy().Schema, map[string]interface{}{
+		"bucket":             "bucket",
+		"default_expiration": "DeleteMarker",
+		"rule": []interface{}{
+			map[string]interface{}{"id": "with-noncurrent", "expiration": "DeleteMarker", "noncurrent_version_expiration_days": 5},
+			map[string]interface{}{"id": "without-noncurrent", "expiration": "DeleteMarker"},
+			map[string]interface{}{"id": "inherited", "noncurrent_version_transition_days": 5, "noncurrent_version_transition_storage_class": "COLD"},
+		},
+	})
+
+	_, diags := ilmPolicyRules(d)
+	if len(diags) != 2 {
+		t.Fatalf("expected 2 diagnostics, got %v", diags)
+	}
+
+	for i, expected := range []struct {
+		path cty.Path
+		id   string
+	}{
+		{cty.GetAttrPath("rule").IndexInt(1).GetAttr("expiration"), "without-noncurrent"},
+		{cty.GetAttrPath("default_expiration"), "inherited"},
+	} {
+		if !diags[i].AttributePath.Equals(expected.path) || !strings.Contains(diags[i].Summary, "("+expected.id+")") ||
+			!strings.Contains(diags[i].Summary, "requires noncurrent_version_expiration_days") {
+			t.Fatalf("expected an error naming rule %s at %#v, got %#v", expected.id, expected.path, diags[i])
+		}
+	}
+}
+
+func TestAccILMPolicy_deleteMarkerWithoutNoncurrentExpiration(t *testing.T) {
+	name := fmt.Sprintf("test-ilm-delete-marker-%d", acctest.RandInt())
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckMinioS3BucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "minio_s3_bucket" "bucket" {
+  bucket = "%s"
+}
+resource "minio_ilm_policy" "rule" {
+  bucket = minio_s3_bucket.bucket.id
+  rule {
+    id         = "markers"
+    expiration = "DeleteMarker"
+  }
+}
+`, name),
+				ExpectError: regexp.MustCompile(`markers\): expiration "DeleteMarker" requires noncurrent_version_expiration_days`),
+			},
+		},
+	})
+}
+
+func TestAccILMPolicy_expireDeleteMarker(t *testing.T) {
+	var lifecycleConfig lifecycle.Configuration
+	name := fmt.Sprintf("test-ilm-expire-delete-marker-%d", acctest.RandInt())
+	resourceName := "minio_ilm_policy.rule"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckMinioS3BucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccMinioILMPolicyConfigExpireDeleteMarker(name, `expiration = "30d"`),
+				ExpectError: regexp.MustCompile(`markers\): expire_delete_marker conflicts with a day or date based expiration`),
+			},
+			{
+				Config: testAccMinioILMPolicyConfigExpireDeleteMarker(name, ""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMinioILMPolicyExists(resourceName, &lifecycleConfig),
+					testAccCheckMinioLifecycleConfigurationValid(&lifecycleConfig),
+					testAccCheckMinioLifecycleRuleExpiration(&lifecycleConfig, "current", lifecycle.Expiration{Days: 30}),
+					testAccCheckMinioLifecycleRuleExpiration(&lifecycleConfig, "markers", lifecycle.Expiration{DeleteMarker: true}),
+					resource.TestCheckResourceAttr(resourceName, "rule.0.expiration", "30d"),
+					resource.TestCheckResourceAttr(resourceName, "rule.0.expire_delete_marker", "false"),
+					resource.TestCheckResourceAttr(resourceName, "rule.1.expiration", ""),
+					resource.TestCheckResourceAttr(resourceName, "rule.1.expire_delete_marker", "true"),
+					resource.TestCheckResourceAttr(resourceName, "rule.1.noncurrent_version_expiration_days", "5"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				// imported delete marker expirations are reported as expiration "DeleteMarker"
+				ImportStateVerifyIgnore: []string{"rule.1.expiration", "rule.1.expire_delete_marker"},
+			},
+		},
+	})
+}
+
+func testAccMinioILMPolicyConfigExpireDeleteMarker(name string, markersExpiration string) string {
+	return fmt.Sprintf(`
+resource "minio_s3_bucket" "bucket" {
+  bucket = "%s"
+}
+resource "minio_s3_bucket_versioning" "bucket" {
+  bucket = minio_s3_bucket.bucket.id
+  versioning_configuration {
+    status = "Enabled"
+  }
+}
+resource "minio_ilm_policy" "rule" {
+  bucket = minio_s3_bucket_versioning.bucket.bucket
+  rule {
+    id         = "current"
+    expiration = "30d"
+  }
+  rule {
+    id                                 = "markers"
+    expire_delete_marker               = true
+    noncurrent_version_expiration_days = 5
+    %s
+  }
+}
+`, name, markersExpiration)
+}
+
+func testAccCheckMinioLifecycleRuleExpiration(config *lifecycle.Configuration, id string, expected lifecycle.Expiration) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, r := range config.Rules {
+			if r.ID != id {
+				continue
+			}
+			if r.Expiration.Days != expected.Days || !r.Expiration.Date.Equal(expected.Date.Time) || r.Expiration.DeleteMarker != expected.DeleteMarker {
+				return fmt.Errorf("rule %s: expected expiration %+v, got %+v", id, expected, r.Expiration)
+			}
+			return nil
+		}
+		return fmt.Errorf("lifecycle rule %s not found", id)
+	}
+}
+
+func TestILMPolicyRules_expireDeleteMarker(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceMinioILMPolicy().Schema, map[string]interface{}{
+		"bucket":             "bucket",
+		"default_expiration": "30d",
+		"rule": []interface{}{
+			map[string]interface{}{"id": "current"},
+			map[string]interface{}{"id": "markers", "expire_delete_marker": true, "noncurrent_version_expiration_days": 5},
+			map[string]interface{}{"id": "conflict", "expiration": "10d", "expire_delete_marker": true, "noncurrent_version_expiration_days": 5},
+			map[string]interface{}{"id": "without-noncurrent", "expire_delete_marker": true},
+		},
+	})
+
+	rules, diags := ilmPolicyRules(d)
+	if rules[0].Expiration.Days != 30 || rules[0].Expiration.DeleteMarker.IsEnabled() {
+		t.Fatalf("expected rule current to inherit the default expiration, got %+v", rules[0].Expiration)
+	}
+	if !rules[1].Expiration.DeleteMarker.IsEnabled() || !rules[1].Expiration.IsDaysNull() || rules[1].NoncurrentVersionExpiration.NoncurrentDays != 5 {
+		t.Fatalf("expected rule markers to only expire delete markers, got %+v", rules[1].Expiration)
+	}
+
+	if len(diags) != 2 {
+		t.Fatalf("expected 2 diagnostics, got %v", diags)
+	}
+	for i, expected := range []struct {
+		index   int
+		id      string
+		message string
+	}{
+		{2, "conflict", "expire_delete_marker conflicts with a day or date based expiration"},
+		{3, "without-noncurrent", "expire_delete_marker requires noncurrent_version_expiration_days"},
+	} {
+		path := cty.GetAttrPath("rule").IndexInt(expected.index).GetAttr("expire_delete_marker")
+		if !diags[i].AttributePath.Equals(path) || !strings.Contains(diags[i].Summary, "("+expected.id+")") || !strings.Contains(diags[i].Summary, expected.message) {
+			t.Fatalf("expected an error naming rule %s at %#v, got %#v", expected.id, path, diags[i])
+		}
+	}
+}
+
+func TestMinioReadILMPolicy_expireDeleteMarker(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/xml")
+		_, _ = w.Write([]byte(`<LifecycleConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/">
+  <Rule><ID>markers</ID><Status>Enabled</Status><Filter></Filter><Expiration><ExpiredObjectDeleteMarker>true</ExpiredObjectDeleteMarker></Expiration><NoncurrentVersionExpiration><NoncurrentDays>5</NoncurrentDays></NoncurrentVersionExpiration></Rule>
+</LifecycleConfiguration>`))
+	}))
+	defer server.Close()
+
+	client, err := minio.New(strings.TrimPrefix(server.URL, "http://"), &minio.Options{
+		Creds:  credentials.NewStaticV4("access", "secret", ""),
+		Region: "us-east-1",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	for name, tc := range map[string]struct {
+		rule               map[string]interface{}
+		expiration         string
+		expireDeleteMarker bool
+	}{
+		"expire_delete_marker": {
+			rule:               map[string]interface{}{"id": "markers", "expire_delete_marker": true, "noncurrent_version_expiration_days": 5},
+			expireDeleteMarker: true,
+		},
+		"expiration": {
+			rule:       map[string]interface{}{"id": "markers", "expiration": "DeleteMarker", "noncurrent_version_expiration_days": 5},
+			expiration: "DeleteMarker",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resourceMinioILMPolicy().Schema, map[string]interface{}{
+				"bucket": "bucket",
+				"rule":   []interface{}{tc.rule},
+			})
+			d.SetId("bucket")
+
+			if diags := minioReadILMPolicy(context.Background(), d, &S3MinioClient{S3Client: client}); diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if expiration := d.Get("rule.0.expiration").(string); expiration != tc.expiration {
+				t.Fatalf("expected expiration %q, got %q", tc.expiration, expiration)
+			}
+			if expireDeleteMarker := d.Get("rule.0.expire_delete_marker").(bool); expireDeleteMarker != tc.expireDeleteMarker {
+				t.Fatalf("expected expire_delete_marker %t, got %t", tc.expireDeleteMarker, expireDeleteMarker)
+			}
+			if days := d.Get("rule.0.noncurrent_version_expiration_days").(int); days != 5 {
+				t.Fatalf("expected noncurrent versions to expire after 5 days, got %d", days)
+			}
+		})
+	}
+}
+
+func TestAccILMPolicy_withoutRules(t *testing.T) {
+	var lifecycleConfig lifecycle.Configuration
+	name := fmt.Sprintf("test-ilm-without-rules-%d", acctest.RandInt())
+	resourceName := "minio_ilm_policy.rule"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckMinioS3BucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMinioILMPolicyConfigConditionalRules(name, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMinioILMPolicyExists(resourceName, &lifecycleConfig),
+					resource.TestCheckResourceAttr(resourceName, "rule.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "rule_count", "2"),
+				),
+			},
+			{
+				Config: testAccMinioILMPolicyConfigConditionalRules(name, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "id", name),
+					resource.TestCheckResourceAttr(resourceName, "rule.#", "0"),
+					resource.TestCheckResourceAttr(resourceName, "rule_count", "0"),
+					testAccCheckMinioILMPolicyCleared(name),
+				),
+			},
+			{
+				Config: testAccMinioILMPolicyConfigConditionalRules(name, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMinioILMPolicyExists(resourceName, &lifecycleConfig),
+					resource.TestCheckResourceAttr(resourceName, "rule_count", "2"),
+				),
+			},
+		},
+	})
+}
+
+func testAccMinioILMPolicyConfigConditionalRules(name string, enabled bool) string {
+	return fmt.Sprintf(`
+locals {
+  rules = %t ? ["expire-logs", "expire-tmp"] : []
+}
+resource "minio_s3_bucket" "bucket" {
+  bucket = "%s"
+}
+resource "minio_ilm_policy" "rule" {
+  bucket = minio_s3_bucket.bucket.id
+
+  dynamic "rule" {
+    for_each = local.rules
+    content {
+      id         = rule.value
+      expiration = "7d"
+    }
+  }
+}
+`, enabled, name)
+}
+
+// testAccCheckMinioILMPolicyCleared checks the bucket is left without lifecycle configuration
+func testAccCheckMinioILMPolicyCleared(bucket string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		minioC := testAccProvider.Meta().(*S3MinioClient).S3Client
+		config, err := minioC.GetBucketLifecycle(context.Background(), bucket)
+		if err != nil {
+			if isNotFoundError(err) {
+				return nil
+			}
+			return err
+		}
+		if len(config.Rules) != 0 {
+			return fmt.Errorf("expected no lifecycle rules on bucket %s, got %+v", bucket, config.Rules)
+		}
+		return nil
+	}
+}
+
+func TestILMPolicyDates_nonUTCTimezone(t *testing.T) {
+	local := time.Local
+	defer func() { time.Local = local }()
+
+	midnight := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, zone := range []*time.Location{time.FixedZone("UTC-12", -12*3600), time.FixedZone("UTC+14", 14*3600)} {
+		time.Local = zone
+
+		d := schema.TestResourceDataRaw(t, resourceMinioILMPolicy().Schema, map[string]interface{}{
+			"bucket": "bucket",
+			"rule": []interface{}{
+				map[string]interface{}{"id": "expire", "expiration": "2030-01-01"},
+				map[string]interface{}{
+					"id":         "transition",
+					"transition": []interface{}{map[string]interface{}{"date": "2030-01-01", "storage_class": "COLD"}},
+				},
+			},
+		})
+
+		rules, diags := ilmPolicyRules(d)
+		if diags.HasError() {
+			t.Fatalf("%s: unexpected errors: %v", zone, diags)
+		}
+		if !rules[0].Expiration.Date.Equal(midnight) || !rules[1].Transition.Date.Equal(midnight) {
+			t.Fatalf("%s: expected dates at midnight UTC, got %s and %s", zone, rules[0].Expiration.Date, rules[1].Transition.Date)
+		}
+
+		rendered, err := renderILMConfiguration(rules)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", zone, err)
+		}
+		if strings.Count(rendered, "<Date>2030-01-01T00:00:00Z</Date>") != 2 {
+			t.Fatalf("%s: expected both dates to be written at midnight UTC, got %s", zone, rendered)
+		}
+
+		// dates read back from the server keep their day whatever the local timezone
+		read := lifecycle.ExpirationDate{Time: midnight.In(time.Local)}
+		if date := flattenILMExpiration(lifecycle.Expiration{Date: read}); date != "2030-01-01" {
+			t.Fatalf("%s: expected expiration 2030-01-01, got %s", zone, date)
+		}
+		if transitions := flattenILMTransition(lifecycle.Transition{Date: read, StorageClass: "COLD"}); transitions[0]["date"] != "2030-01-01" {
+			t.Fatalf("%s: expected transition date 2030-01-01, got %v", zone, transitions)
+		}
+	}
+}
+
+func TestRenderILMConfiguration(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceMinioILMPolicy().Schema, map[string]interface{}{
+		"bucket":  "bucket",
+		"enabled": false,
+		"rule": []interface{}{
+			map[string]interface{}{
+				"id":         "temp",
+				"expiration": "1d",
+				"rule_filter": []interface{}{map[string]interface{}{
+					"prefix": "temp/",
+					"tags":   map[string]interface{}{"app": "web"},
+				}},
+			},
+		},
+	})
+
+	rules, diags := ilmPolicyRules(d)
+	if diags.HasError() {
+		t.Fatalf("unexpected errors: %v", diags)
+	}
+	rendered, err := renderILMConfiguration(rules)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	for _, expected := range []string{
+		"<ID>temp</ID>",
+		"<Status>Disabled</Status>",
+		"<Days>1</Days>",
+		"<And>",
+		"<Prefix>temp/</Prefix>",
+		"<Key>app</Key>",
+	} {
+		if !strings.Contains(rendered, expected) {
+			t.Errorf("rendered configuration should contain %s, got:\n%s", expected, rendered)
+		}
+	}
+}
+
+func TestSummarizeILMRules(t *testing.T) {
+	rules := []lifecycle.Rule{
+		{ID: "tier", Status: "Enabled", Transition: lifecycle.Transition{Days: 30, StorageClass: "WARM"}},
+		{ID: "expire", Status: "Disabled", Expiration: lifecycle.Expiration{Days: 7}},
+		{ID: "markers", Status: "Enabled", Expiration: lifecycle.Expiration{DeleteMarker: true}, NoncurrentVersionExpiration: lifecycle.NoncurrentVersionExpiration{NoncurrentDays: 5}},
+		{ID: "noncurrent", Status: "Enabled", NoncurrentVersionTransition: lifecycle.NoncurrentVersionTransition{NoncurrentDays: 3, StorageClass: "COLD"}},
+	}
+
+	expected := "expire: expire after 7d (disabled); markers: expire delete markers; noncurrent: transition noncurrent versions to COLD after 3d; tier: transition to WARM after 30d"
+	if summary := summarizeILMRules(rules); summary != expected {
+		t.Fatalf("expected %q, got %q", expected, summary)
+	}
+
+	// the order MinIO returns the rules in does not matter
+	reversed := []lifecycle.Rule{rules[3], rules[2], rules[1], rules[0]}
+	if summary := summarizeILMRules(reversed); summary != expected {
+		t.Fatalf("expected %q, got %q", expected, summary)
+	}
+
+	if summary := summarizeILMRules(nil); summary != "" {
+		t.Fatalf("expected an empty summary, got %q", summary)
+	}
+}
+
+func TestParseILMTransition(t *testing.T) {
+	transition, err := parseILMTransition([]interface{}{
+		map[string]interface{}{"days": "", "date": "2020-01-01", "storage_class": "COLD"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !transition.Date.Equal(time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("past transition date should be kept as is, got %s", transition.Date)
+	}
+	out, err := xml.Marshal(transition)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !strings.Contains(string(out), "<Date>2020-01-01T00:00:00Z</Date>") {
+		t.Fatalf("past transition date should be sent unchanged, got %s", out)
+	}
+
+	transition, err = parseILMTransition([]interface{}{
+		map[string]interface{}{"days": "5d", "date": "", "storage_class": "COLD"},
+	})
+	if err != nil || transition.Days != 5 {
+		t.Fatalf("expected a 5 days transition, got %+v (%v)", transition, err)
+	}
+
+	if _, err := parseILMTransition([]interface{}{
+		map[string]interface{}{"days": "", "date": "2020-01-01", "storage_class": ""},
+	}); err == nil {
+		t.Fatalf("transition without storage class should be rejected")
+	}
+
+	if _, err := parseILMTransition([]interface{}{
+		map[string]interface{}{"days": "", "date": "", "storage_class": "COLD"},
+	}); err == nil {
+		t.Fatalf("transition without days or date should be rejected")
+	}
+
+	if _, err := parseILMTransition([]interface{}{
+		map[string]interface{}{"days": "5d", "date": "2020-01-01", "storage_class": "COLD"},
+	}); err == nil || !strings.Contains(err.Error(), "exactly one of days") {
+		t.Fatalf("transition with both days and date should be rejected, got %v", err)
+	}
+}
+
+func TestILMTransitionRoundTrip(t *testing.T) {
+	for _, tc := range []struct {
+		configured map[string]string
+		read       map[string]string
+	}{
+		{
+			configured: map[string]string{"days": "5d", "date": "", "storage_class": "COLD"},
+			read:       map[string]string{"days": "5d", "storage_class": "COLD"},
+		},
+		{
+			configured: map[string]string{"days": "5", "date": "", "storage_class": "COLD"},
+			read:       map[string]string{"days": "5d", "storage_class": "COLD"},
+		},
+		{
+			configured: map[string]string{"days": "365d", "date": "", "storage_class": "WARM"},
+			read:       map[string]string{"days": "365d", "storage_class": "WARM"},
+		},
+		{
+			configured: map[string]string{"days": "", "date": "2024-06-06", "storage_class": "COLD"},
+			read:       map[string]string{"date": "2024-06-06", "storage_class": "COLD"},
+		},
+	} {
+		transition, err := parseILMTransition([]interface{}{map[string]interface{}{
+			"days": tc.configured["days"], "date": tc.configured["date"], "storage_class": tc.configured["storage_class"],
+		}})
+		if err != nil {
+			t.Fatalf("%v: unexpected error: %s", tc.configured, err)
+		}
+
+		read := flattenILMTransition(transition)
+		if len(read) != 1 || !reflect.DeepEqual(read[0], tc.read) {
+			t.Errorf("%v: expected %v to be read back, got %v", tc.configured, tc.read, read)
+		}
+	}
+
+	for _, days := range []string{"5days", "5 d", "0", "0d", "-1d", "1w", "d"} {
+		if diags := validateILMTransitionDays(days, cty.Path{}); !diags.HasError() {
+			t.Errorf("transition days %q should be rejected", days)
+		}
+		if _, err := parseILMTransition([]interface{}{
+			map[string]interface{}{"days": days, "date": "", "storage_class": "COLD"},
+		}); err == nil {
+			t.Errorf("transition days %q should not be written", days)
+		}
+	}
+}
+
+func TestAccILMPolicy_unknownRuleID(t *testing.T) {
+	resourceName := "minio_ilm_policy.rule"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckMinioS3BucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "minio_s3_bucket" "bucket" {
+  bucket_prefix = "tf-acc-ilm-unknown-"
+}
+resource "minio_ilm_policy" "rule" {
+  bucket = minio_s3_bucket.bucket.id
+  rule {
+    id         = "expire-${minio_s3_bucket.bucket.id}"
+    expiration = "5d"
+  }
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "rule_count", "1"),
+					resource.TestCheckResourceAttrPair(resourceName, "managed_rule_ids.0", resourceName, "rule.0.id"),
+					resource.TestMatchResourceAttr(resourceName, "summary", regexp.MustCompile(`^expire-tf-acc-ilm-unknown-\w+: expire after 5d$`)),
+				),
+			},
+		},
+	})
+}
+
+func TestILMPolicyConfigKnown(t *testing.T) {
+	keys := []string{"rule", "enabled"}
+	ruleType := cty.Object(map[string]cty.Type{"id": cty.String, "expiration": cty.String})
+	config := func(id cty.Value) cty.Value {
+		return cty.ObjectVal(map[string]cty.Value{
+			"bucket":  cty.StringVal("bucket"),
+			"enabled": cty.True,
+			"rule":    cty.ListVal([]cty.Value{cty.ObjectVal(map[string]cty.Value{"id": id, "expiration": cty.StringVal("5d")})}),
+		})
+	}
+
+	if !ilmPolicyConfigKnown(config(cty.StringVal("expire")), keys) {
+		t.Fatalf("a configuration without unknown values should be known")
+	}
+	if ilmPolicyConfigKnown(config(cty.UnknownVal(cty.String)), keys) {
+		t.Fatalf("a rule id known only after apply should make the configuration unknown")
+	}
+	if ilmPolicyConfigKnown(cty.ObjectVal(map[string]cty.Value{"rule": cty.UnknownVal(cty.List(ruleType))}), keys) {
+		t.Fatalf("unknown rules should make the configuration unknown")
+	}
+	if !ilmPolicyConfigKnown(cty.NullVal(cty.Object(map[string]cty.Type{"rule": cty.List(ruleType)})), keys) {
+		t.Fatalf("a null configuration should be considered known")
+	}
+}
+
+func TestILMPolicyDiff_transition(t *testing.T) {
+	// the schema alone, CustomizeDiff needs a provider
+	r := &schema.Resource{Schema: resourceMinioILMPolicy().Schema}
+	config := func(days, storageClass string) map[string]interface{} {
+		return map[string]interface{}{
+			"bucket": "bucket",
+			"rule": []interface{}{map[string]interface{}{
+				"id": "archive",
+				"transition": []interface{}{map[string]interface{}{
+					"days":          days,
+					"storage_class": storageClass,
+				}},
+			}},
+		}
+	}
+
+	for _, days := range []string{"5", "5d"} {
+		t.Run(days, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, r.Schema, config(days, "COLD"))
+			d.SetId("bucket")
+			transition, err := parseILMTransition(d.Get("rule.0.transition").([]interface{}))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := d.Set("rule", []interface{}{map[string]interface{}{
+				"id":         "archive",
+				"transition": flattenILMTransition(transition),
+			}}); err != nil {
+				t.Fatal(err)
+			}
+			if err := d.Set("managed_rule_ids", []string{"archive"}); err != nil {
+				t.Fatal(err)
+			}
+			if actual := d.Get("rule.0.transition.0.days"); actual != "5d" {
+				t.Fatalf("expected the days to be read back as 5d, got %q", actual)
+			}
+
+			diff, err := r.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(config(days, "COLD")), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !diff.Empty() {
+				t.Fatalf("expected the transition read back to match the configuration, got %#v", diff.Attributes)
+			}
+
+			diff, err = r.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(config(days, "WARM")), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff == nil || len(diff.Attributes) != 1 || diff.Attributes["rule.0.transition.0.storage_class"] == nil {
+				t.Fatalf("expected only the storage class to change, got %#v", diff)
+			}
+		})
+	}
+}
+
+func TestAccILMPolicy_disabled(t *testing.T) {
+	var lifecycleConfig lifecycle.Configuration
+	name := fmt.Sprintf("test-ilm-disabled-%d", acctest.RandInt())
+	resourceName := "minio_ilm_policy.rule"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckMinioS3BucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMinioILMPolicyEnabled(name, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMinioILMPolicyExists(resourceName, &lifecycleConfig),
+					testAccCheckMinioILMPolicyRulesStatus(&lifecycleConfig, "Disabled"),
+					resource.TestCheckResourceAttr(resourceName, "enabled", "false"),
+					resource.TestCheckResourceAttr(resourceName, "rule.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "rule.0.status", "Disabled"),
+					resource.TestMatchResourceAttr(resourceName, "rendered_configuration", regexp.MustCompile(`<Status>Disabled</Status>`)),
+				),
+			},
+			{
+				Config: testAccMinioILMPolicyEnabled(name, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMinioILMPolicyExists(resourceName, &lifecycleConfig),
+					testAccCheckMinioILMPolicyRulesStatus(&lifecycleConfig, "Enabled"),
+					resource.TestCheckResourceAttr(resourceName, "enabled", "true"),
+					resource.TestCheckResourceAttr(resourceName, "rule.1.status", "Enabled"),
+				),
+			},
+			{
+				Config: testAccMinioILMPolicyEnabled(name, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMinioILMPolicyExists(resourceName, &lifecycleConfig),
+					testAccCheckMinioILMPolicyRulesStatus(&lifecycleConfig, "Disabled"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccILMPolicy_deletedBucket(t *testing.T) {
+	var lifecycleConfig lifecycle.Configuration
+	name := fmt.Sprintf("test-ilm-deleted-bucket-%d", acctest.RandInt())
+	resourceName := "minio_ilm_policy.rule"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckMinioS3BucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMinioILMPolicyConfig(name),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMinioILMPolicyExists(resourceName, &lifecycleConfig),
+					testAccCheckMinioS3DestroyBucket("minio_s3_bucket.bucket"),
+					testAccCheckMinioILMPolicyDeletedBucketDestroy(resourceName, true),
+					testAccCheckMinioILMPolicyDeletedBucketDestroy(resourceName, false),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestAccILMPolicy_externallyClearedLifecycle(t *testing.T) {
+	var lifecycleConfig lifecycle.Configuration
+	name := fmt.Sprintf("test-ilm-cleared-%d", acctest.RandInt())
+	resourceName := "minio_ilm_policy.rule"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckMinioS3BucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMinioILMPolicyConfig(name),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMinioILMPolicyExists(resourceName, &lifecycleConfig),
+				),
+			},
+			{
+				PreConfig: func() {
+					if err := testAccClearExternalLifecycle(name); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:             testAccMinioILMPolicyConfig(name),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccMinioILMPolicyConfig(name),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMinioBucketLifecycleRuleIDs(name, "asdf"),
+					resource.TestCheckResourceAttr(resourceName, "id", name),
+					resource.TestCheckResourceAttr(resourceName, "rule.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "rule.0.id", "asdf"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccILMPolicy_preserveUnmanagedRules(t *testing.T) {
+	var lifecycleConfig lifecycle.Configuration
+	name := fmt.Sprintf("test-ilm-rule6-%d", acctest.RandInt())
+	resourceName := "minio_ilm_policy.rule6b"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckMinioS3BucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMinioILMPolicyPreserveUnmanagedRules(name),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMinioILMPolicyExists(resourceName, &lifecycleConfig),
+					testAccCheckMinioLifecycleConfigurationRuleIDs(&lifecycleConfig, "first", "second"),
+					resource.TestCheckResourceAttr("minio_ilm_policy.rule6a", "rule.#", "1"),
+					resource.TestCheckResourceAttr("minio_ilm_policy.rule6a", "rule.0.id", "first"),
+					resource.TestCheckResourceAttr(resourceName, "rule.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "rule.0.id", "second"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccILMPolicy_preserveUnmanagedRulesOnDestroy(t *testing.T) {
+	var lifecycleConfig lifecycle.Configuration
+	name := fmt.Sprintf("test-ilm-rule11-%d", acctest.RandInt())
+	resourceName := "minio_ilm_policy.rule11"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckMinioS3BucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMinioILMPolicyPreserveUnmanagedRulesOnDestroy(name, true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMinioILMPolicyExists(resourceName, &lifecycleConfig),
+					resource.TestCheckResourceAttr(resourceName, "managed_rule_ids.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "managed_rule_ids.0", "managed"),
+				),
+			},
+			{
+				PreConfig: func() {
+					if err := testAccAddExternalLifecycleRule(name, "external"); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: testAccMinioILMPolicyPreserveUnmanagedRulesOnDestroy(name, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMinioBucketLifecycleRuleIDs(name, "external"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccILMPolicy_importTagFilter(t *testing.T) {
+	name := fmt.Sprintf("test-ilm-import-tags-%d", acctest.RandInt())
+	resourceName := "minio_ilm_policy.rule"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckMinioS3BucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMinioILMPolicyImportTagFilterBucket(name),
+			},
+			{
+				PreConfig: func() {
+					// written as other clients do, with tags out of order and surrounded by whitespace
+					config := lifecycle.NewConfiguration()
+					config.Rules = []lifecycle.Rule{
+						{ID: "tagged", Status: "Enabled", Expiration: lifecycle.Expiration{Days: 5}, RuleFilter: lifecycle.Filter{And: lifecycle.And{
+							Tags: []lifecycle.Tag{{Key: "team", Value: " storage"}, {Key: "app ", Value: "web "}},
+						}}},
+						{ID: "single-tag", Status: "Enabled", Expiration: lifecycle.Expiration{Days: 6}, RuleFilter: lifecycle.Filter{Tag: lifecycle.Tag{Key: "env", Value: "dev"}}},
+						{ID: "empty-prefix", Status: "Enabled", Expiration: lifecycle.Expiration{Days: 7}, RuleFilter: lifecycle.Filter{Prefix: ""}},
+						{ID: "prefixed", Status: "Enabled", Expiration: lifecycle.Expiration{Days: 8}, RuleFilter: lifecycle.Filter{And: lifecycle.And{
+							Prefix: "logs/", Tags: []lifecycle.Tag{{Key: "env", Value: "dev"}},
+						}}},
+					}
+					if err := testAccProvider.Meta().(*S3MinioClient).S3Client.SetBucketLifecycle(context.Background(), name, config); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:             testAccMinioILMPolicyImportTagFilter(name),
+				ResourceName:       resourceName,
+				ImportState:        true,
+				ImportStateId:      name,
+				ImportStatePersist: true,
+			},
+			{
+				Config:   testAccMinioILMPolicyImportTagFilter(name),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func testAccMinioILMPolicyImportTagFilterBucket(name string) string {
+	return fmt.Sprintf(`
+resource "minio_s3_bucket" "bucket" {
+  bucket = %q
+}
+`, name)
+}
+
+func testAccMinioILMPolicyImportTagFilter(name string) string {
+	return testAccMinioILMPolicyImportTagFilterBucket(name) + `
+resource "minio_ilm_policy" "rule" {
+  bucket = minio_s3_bucket.bucket.id
+  rule {
+    id         = "tagged"
+    expiration = "5d"
+    rule_filter {
+      tags = {
+        app  = "web"
+        team = "storage"
+      }
+    }
+  }
+  rule {
+    id         = "single-tag"
+    expiration = "6d"
+    rule_filter {
+      tags = {
+        env = "dev"
+      }
+    }
+  }
+  rule {
+    id         = "empty-prefix"
+    expiration = "7d"
+  }
+  rule {
+    id         = "prefixed"
+    expiration = "8d"
+    rule_filter {
+      prefix = "logs/"
+      tags = {
+        env = "dev"
+      }
+    }
+  }
+}
+`
+}
+
+func testAccAddExternalLifecycleRule(bucket string, id string) error {
+	m := testAccProvider.Meta().(*S3MinioClient)
+
+	config, err := m.S3Client.GetBucketLifecycle(context.Background(), bucket)
+	if err != nil {
+		return fmt.Errorf("error reading lifecycle of %s: %w", bucket, err)
+	}
+	config.Rules = append(config.Rules, lifecycle.Rule{
+		ID:         id,
+		Status:     "Enabled",
+		Expiration: lifecycle.Expiration{Days: 30},
+		RuleFilter: lifecycle.Filter{Prefix: "external/"},
+	})
+	if err := m.S3Client.SetBucketLifecycle(context.Background(), bucket, config); err != nil {
+		return fmt.Errorf("error adding lifecycle rule %s to %s: %w", id, bucket, err)
+	}
+	m.LifecycleCache.Invalidate(bucket)
+
+	return nil
+}
+
+func testAccClearExternalLifecycle(bucket string) error {
+	m := testAccProvider.Meta().(*S3MinioClient)
+
+	if err := m.S3Client.SetBucketLifecycle(context.Background(), bucket, lifecycle.NewConfiguration()); err != nil {
+		return fmt.Errorf("error clearing lifecycle of %s: %w", bucket, err)
+	}
+	m.LifecycleCache.Invalidate(bucket)
+
+	return nil
+}
+
+func testAccCheckMinioBucketLifecycleRuleIDs(bucket string, ids ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		config, err := testAccProvider.Meta().(*S3MinioClient).S3Client.GetBucketLifecycle(context.Background(), bucket)
+		if err != nil {
+			return fmt.Errorf("error reading lifecycle of %s: %w", bucket, err)
+		}
+		return testAccCheckMinioLifecycleConfigurationRuleIDs(config, ids...)(s)
+	}
+}
+
+func TestAccILMPolicy_reorderRules(t *testing.T) {
+	var lifecycleConfig lifecycle.Configuration
+	name := fmt.Sprintf("test-ilm-rule7-%d", acctest.RandInt())
+	resourceName := "minio_ilm_policy.rule7"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckMinioS3BucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMinioILMPolicyRulesOrder(name, "first", "second"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMinioILMPolicyExists(resourceName, &lifecycleConfig),
+					testAccCheckMinioLifecycleConfigurationRuleIDs(&lifecycleConfig, "first", "second"),
+					resource.TestCheckResourceAttr(resourceName, "rule.0.id", "first"),
+					resource.TestCheckResourceAttr(resourceName, "rule.1.id", "second"),
+				),
+			},
+			{
+				Config:   testAccMinioILMPolicyRulesOrder(name, "second", "first"),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccILMPolicy_removeMiddleRule(t *testing.T) {
+	var lifecycleConfig lifecycle.Configuration
+	name := fmt.Sprintf("test-ilm-rule7-%d", acctest.RandInt())
+	resourceName := "minio_ilm_policy.rule7"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckMinioS3BucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMinioILMPolicyRulesOrder(name, "first", "second", "third"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMinioILMPolicyExists(resourceName, &lifecycleConfig),
+					testAccCheckMinioLifecycleConfigurationRuleIDs(&lifecycleConfig, "first", "second", "third"),
+				),
+			},
+			{
+				Config: testAccMinioILMPolicyRulesOrder(name, "first", "third"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMinioILMPolicyExists(resourceName, &lifecycleConfig),
+					testAccCheckMinioLifecycleConfigurationRuleIDs(&lifecycleConfig, "first", "third"),
+					testAccCheckMinioLifecycleRuleExpirationDays(&lifecycleConfig, "first", 5),
+					testAccCheckMinioLifecycleRuleExpirationDays(&lifecycleConfig, "third", 15),
+					resource.TestCheckResourceAttr(resourceName, "rule.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "rule.1.id", "third"),
+				),
+			},
+		},
+	})
+}
+
+func TestILMRuleChanges(t *testing.T) {
+	first := map[string]interface{}{"id": "first", "expiration": "5d"}
+	second := map[string]interface{}{"id": "second", "expiration": "10d"}
+	third := map[string]interface{}{"id": "third", "expiration": "15d"}
+	fourth := map[string]interface{}{"id": "fourth", "expiration": "20d"}
+	thirdModified := map[string]interface{}{"id": "third", "expiration": "30d"}
+
+	added, removed, modified := ilmRuleChanges([]interface{}{first, second, third}, []interface{}{thirdModified, fourth, first})
+	if !reflect.DeepEqual(added, []string{"fourth"}) || !reflect.DeepEqual(removed, []string{"second"}) || !reflect.DeepEqual(modified, []string{"third"}) {
+		t.Fatalf("unexpected changes: added=%v removed=%v modified=%v", added, removed, modified)
+	}
+
+	added, removed, modified = ilmRuleChanges([]interface{}{first, second}, []interface{}{second, first})
+	if len(added)+len(removed)+len(modified) != 0 {
+		t.Fatalf("reordering rules is not a change: added=%v removed=%v modified=%v", added, removed, modified)
+	}
+}
+
+func TestILMRulesEqualIgnoringOrder(t *testing.T) {
+	first := map[string]interface{}{"id": "first", "expiration": "5d"}
+	second := map[string]interface{}{"id": "second", "expiration": "10d"}
+
+	if !ilmRulesEqualIgnoringOrder([]interface{}{first, second}, []interface{}{second, first}) {
+		t.Fatalf("swapped rules should be equal")
+	}
+
+	changed := map[string]interface{}{"id": "second", "expiration": "20d"}
+	if ilmRulesEqualIgnoringOrder([]interface{}{first, second}, []interface{}{changed, first}) {
+		t.Fatalf("changed rule should not be equal")
+	}
+	if ilmRulesEqualIgnoringOrder([]interface{}{first, second}, []interface{}{first}) {
+		t.Fatalf("removed rule should not be equal")
+	}
+}
+
+func TestSortILMRulesByPriorOrder(t *testing.T) {
+	rules := []map[string]interface{}{{"id": "new"}, {"id": "second"}, {"id": "first"}}
+	prior := []interface{}{map[string]interface{}{"id": "first"}, map[string]interface{}{"id": "second"}}
+
+	sortILMRulesByPriorOrder(rules, prior)
+
+	var ids []string
+	for _, rule := range rules {
+		ids = append(ids, rule["id"].(string))
+	}
+	if !reflect.DeepEqual(ids, []string{"first", "second", "new"}) {
+		t.Fatalf("unexpected rule order %v", ids)
+	}
+}
+
+func TestMergeILMRules(t *testing.T) {
+	existing := []lifecycle.Rule{{ID: "other"}, {ID: "managed"}, {ID: "removed"}}
+	managed := []lifecycle.Rule{{ID: "managed", Status: "Enabled"}, {ID: "added"}}
+	managedIDs := map[string]bool{"managed": true, "removed": true, "added": true}
+
+	rules := mergeILMRules(existing, managed, managedIDs)
+
+	var ids []string
+	for _, r := range rules {
+		ids = append(ids, r.ID)
+	}
+	if !reflect.DeepEqual(ids, []string{"other", "managed", "added"}) {
+		t.Fatalf("unexpected merged rules: %v", ids)
+	}
+	if rules[1].Status != "Enabled" {
+		t.Fatalf("managed rule should replace the existing one")
+	}
 
-				Check: resource.ComposeTestCheckFunc(
-					testAccCheckMinioS3BucketExists("minio_s3_bucket.my_bucket_in_a"),
-					testAccCheckMinioILMPolicyExists(resourceName, &lifecycleConfig),
-					resource.TestCheckResourceAttr(resourceName, "bucket", bucketName),
-					testAccCheckMinioLifecycleConfigurationValid(&lifecycleConfig),
-					resource.TestCheckResourceAttr(
-						resourceName, "rule.0.transition.0.days", "1d"),
-				),
-			},
-			{
-				Config: testAccBucketReplicationConfigLocals(primaryMinioEndpoint, secondaryMinioEndpoint) +
-					testAccMinioBucketTransitionConfigBucket("my_bucket_in_a", "minio", bucketName) +
-					testAccMinioBucketTransitionConfigBucket("my_bucket_in_b", "secondminio", secondBucketName) +
-					testAccMinioILMPolicyTransitionServiceAccount(username) +
-					testAccMinioRemoteTierConfig(remoteTierName, secondaryMinioEndpoint) +
-					testAccMinioILMPolicyTransitionDateConfig(),
+	if rules := mergeILMRules(existing, nil, managedIDs); len(rules) != 1 || rules[0].ID != "other" {
+		t.Fatalf("removing managed rules should keep unmanaged ones, got %v", rules)
+	}
+}
 
-				Check: resource.ComposeTestCheckFunc(
-					testAccCheckMinioS3BucketExists("minio_s3_bucket.my_bucket_in_a"),
-					testAccCheckMinioILMPolicyExists(resourceName, &lifecycleConfig),
-					resource.TestCheckResourceAttr(resourceName, "bucket", bucketName),
-					testAccCheckMinioLifecycleConfigurationValid(&lifecycleConfig),
-					resource.TestCheckResourceAttr(
-						resourceName, "rule.0.transition.0.date", "2024-06-06"),
-				),
-			},
-		},
-	})
+func testAccCheckMinioLifecycleConfigurationRuleIDs(config *lifecycle.Configuration, ids ...string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		found := map[string]bool{}
+		for _, r := range config.Rules {
+			found[r.ID] = true
+		}
+		for _, id := range ids {
+			if !found[id] {
+				return fmt.Errorf("lifecycle rule %s not found", id)
+			}
+		}
+		if len(config.Rules) != len(ids) {
+			return fmt.Errorf("expected %d lifecycle rules, got %d", len(ids), len(config.Rules))
+		}
+		return nil
+	}
 }
 
 func testAccCheckMinioLifecycleConfigurationValid(config *lifecycle.Configuration) resource.TestCheckFunc {
@@ -181,6 +2209,41 @@ func testAccCheckMinioLifecycleConfigurationValid(config *lifecycle.Configuratio
 	}
 }
 
+func testAccCheckMinioILMPolicyRulesStatus(config *lifecycle.Configuration, status string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rule := range config.Rules {
+			if rule.Status != status {
+				return fmt.Errorf("lifecycle rule %s should be %s, got %s", rule.ID, status, rule.Status)
+			}
+		}
+		return nil
+	}
+}
+
+// testAccCheckMinioILMPolicyDeletedBucketDestroy runs the delete of the policy, whose bucket no longer exists
+func testAccCheckMinioILMPolicyDeletedBucketDestroy(n string, manageExistingRules bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+
+		d := resourceMinioILMPolicy().TestResourceData()
+		d.SetId(rs.Primary.ID)
+		_ = d.Set("bucket", rs.Primary.ID)
+		_ = d.Set("manage_existing_rules", manageExistingRules)
+
+		if diags := minioDeleteILMPolicy(context.Background(), d, testAccProvider.Meta()); diags.HasError() {
+			return fmt.Errorf("deleting the policy of a deleted bucket should succeed, got %v", diags)
+		}
+		if d.Id() != "" {
+			return fmt.Errorf("policy should be removed from state")
+		}
+
+		return nil
+	}
+}
+
 func testAccCheckMinioILMPolicyExists(n string, config *lifecycle.Configuration) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -248,6 +2311,7 @@ resource "minio_ilm_policy" "rule2" {
   rule {
 	id = "asdf"
 	expiration = "DeleteMarker"
+	noncurrent_version_expiration_days = 5
   }
 }
 `, randInt)
@@ -291,6 +2355,126 @@ resource "minio_ilm_policy" "rule3" {
 `, randInt)
 }
 
+func testAccMinioILMPolicyRuleFilter(randInt string) string {
+	return fmt.Sprintf(`
+resource "minio_s3_bucket" "bucket7" {
+  bucket = "%s"
+  acl    = "public-read"
+}
+resource "minio_ilm_policy" "rule7" {
+  bucket = "${minio_s3_bucket.bucket7.id}"
+  rule {
+	id = "asdf"
+	expiration = "5d"
+	rule_filter {
+	  prefix = "temp/"
+	  tags = {
+		app = "test"
+	  }
+	  object_size_greater_than = 1024
+	  object_size_less_than    = 1048576
+	}
+  }
+}
+`, randInt)
+}
+
+func testAccMinioILMPolicyPrefixes(randInt string, prefixes string) string {
+	return fmt.Sprintf(`
+resource "minio_s3_bucket" "bucket12" {
+  bucket = "%s"
+  acl    = "public-read"
+}
+resource "minio_ilm_policy" "rule12" {
+  bucket = "${minio_s3_bucket.bucket12.id}"
+  rule {
+	id = "cleanup"
+	expiration = "5d"
+	prefixes = [%s]
+	rule_filter {
+	  tags = {
+		app = "test"
+	  }
+	}
+  }
+}
+`, randInt, prefixes)
+}
+
+func testAccMinioILMPolicySingleTagFilter(randInt string) string {
+	return fmt.Sprintf(`
+resource "minio_s3_bucket" "bucket10" {
+  bucket = "%s"
+  acl    = "public-read"
+}
+resource "minio_ilm_policy" "rule10" {
+  bucket = "${minio_s3_bucket.bucket10.id}"
+  rule {
+	id = "singleTag"
+	expiration = "5d"
+	rule_filter {
+	  tags = {
+		app = "test"
+	  }
+	}
+  }
+}
+`, randInt)
+}
+
+func testAccMinioILMPolicyTagOnlyExpiration(randInt string) string {
+	return fmt.Sprintf(`
+resource "minio_s3_bucket" "tag_only" {
+  bucket = "%s"
+}
+resource "minio_ilm_policy" "tag_only" {
+  bucket = minio_s3_bucket.tag_only.id
+  rule {
+	id = "temp"
+	expiration = "7d"
+	rule_filter {
+	  tags = {
+		temp = "true"
+	  }
+	}
+  }
+  rule {
+	id = "scratch"
+	expiration = "7d"
+	tags = {
+	  scratch = "yes"
+	}
+  }
+}
+`, randInt)
+}
+
+func testAccMinioILMPolicyDefaults(randInt string, defaultExpiration string) string {
+	return fmt.Sprintf(`
+resource "minio_s3_bucket" "bucket8" {
+  bucket = "%s"
+  acl    = "public-read"
+}
+resource "minio_ilm_policy" "rule8" {
+  bucket             = "${minio_s3_bucket.bucket8.id}"
+  default_expiration = "%s"
+  rule {
+	id = "inherited"
+	rule_filter {
+	  prefix = "temp/"
+	}
+  }
+  rule {
+	id = "overridden"
+	expiration = "14d"
+	rule_filter {
+	  prefix = "logs/"
+	}
+  }
+}
+`, randInt, defaultExpiration)
+}
+
 func testAccMinioILMPolicyExpireNoncurrentVersion(randInt string) string {
 	return fmt.Sprintf(`
 resource "minio_s3_bucket" "bucket4" {
@@ -307,6 +2491,93 @@ resource "minio_ilm_policy" "rule4" {
 `, randInt)
 }
 
+func testAccMinioILMPolicyEnabled(randInt string, enabled bool) string {
+	return fmt.Sprintf(`
+resource "minio_s3_bucket" "bucket" {
+  bucket = "%s"
+}
+resource "minio_ilm_policy" "rule" {
+  bucket  = minio_s3_bucket.bucket.id
+  enabled = %t
+  rule {
+    id         = "expire"
+    expiration = "7d"
+  }
+  rule {
+    id                                 = "expireNoncurrent"
+    noncurrent_version_expiration_days = 3
+  }
+}
+`, randInt, enabled)
+}
+
+func testAccMinioILMPolicyExpireAllVersions(randInt string) string {
+	return fmt.Sprintf(`
+resource "minio_s3_bucket" "bucket" {
+  bucket = "%s"
+}
+resource "minio_ilm_policy" "rule" {
+  bucket = minio_s3_bucket.bucket.id
+  rule {
+    id                  = "expireAllVersions"
+    expiration          = "7d"
+    expire_all_versions = true
+  }
+}
+`, randInt)
+}
+
+func testAccMinioILMPolicyPreserveUnmanagedRules(randInt string) string {
+	return fmt.Sprintf(`
+resource "minio_s3_bucket" "bucket6" {
+  bucket = "%s"
+  acl    = "public-read"
+}
+resource "minio_ilm_policy" "rule6a" {
+  bucket                = minio_s3_bucket.bucket6.id
+  manage_existing_rules = false
+  rule {
+	id = "first"
+	expiration = "5d"
+	filter = "temp/"
+  }
+}
+resource "minio_ilm_policy" "rule6b" {
+  bucket                = minio_s3_bucket.bucket6.id
+  manage_existing_rules = false
+  rule {
+	id = "second"
+	expiration = "10d"
+	filter = "logs/"
+  }
+}
+`, randInt)
+}
+
+func testAccMinioILMPolicyPreserveUnmanagedRulesOnDestroy(randInt string, withPolicy bool) string {
+	config := fmt.Sprintf(`
+resource "minio_s3_bucket" "bucket11" {
+  bucket        = "%s"
+  acl           = "public-read"
+  force_destroy = true
+}
+`, randInt)
+	if withPolicy {
+		config += `
+resource "minio_ilm_policy" "rule11" {
+  bucket                   = minio_s3_bucket.bucket11.id
+  preserve_unmanaged_rules = true
+  rule {
+	id = "managed"
+	expiration = "5d"
+	filter = "temp/"
+  }
+}
+`
+	}
+	return config
+}
+
 func testAccMinioRemoteTierConfig(remoteTier, endpoint string) string {
 	return fmt.Sprintf(`
 resource "minio_ilm_tier" "remote_tier"{
@@ -322,34 +2593,55 @@ resource "minio_ilm_tier" "remote_tier"{
 `, remoteTier, endpoint)
 }
 
-func testAccMinioILMPolicyTransitionConfig() string {
-	return `
+func testAccMinioILMPolicyTransitionConfig(days string) string {
+	return fmt.Sprintf(`
 resource "minio_ilm_policy" "rule_transition" {
   bucket = "${minio_s3_bucket.my_bucket_in_a.bucket}"
   rule {
 	id = "asdf"
 	transition {
-		days = "1d"
+		days = %q
 		storage_class = "${minio_ilm_tier.remote_tier.name}"
 	}
   }
 }
-`
+`, days)
+}
+
+func testAccMinioILMPolicyCurrentAndNoncurrentTransitionConfig(noncurrentStorageClass string) string {
+	storageClass := ""
+	if noncurrentStorageClass != "" {
+		storageClass = fmt.Sprintf("noncurrent_version_transition_storage_class = %q", noncurrentStorageClass)
+	}
+	return fmt.Sprintf(`
+resource "minio_ilm_policy" "rule_transition" {
+  bucket = "${minio_s3_bucket.my_bucket_in_a.bucket}"
+  rule {
+	id = "both"
+	transition {
+		days = "30d"
+		storage_class = "${minio_ilm_tier.remote_tier.name}"
+	}
+	noncurrent_version_transition_days = 7
+	%s
+  }
+}
+`, storageClass)
 }
 
-func testAccMinioILMPolicyTransitionDateConfig() string {
-	return `
+func testAccMinioILMPolicyTransitionDateConfig(date string) string {
+	return fmt.Sprintf(`
 resource "minio_ilm_policy" "rule_transition" {
   bucket = "${minio_s3_bucket.my_bucket_in_a.bucket}"
   rule {
 	id = "asdf"
 	transition {
-		date = "2024-06-06"
+		date = "%s"
 		storage_class = "${minio_ilm_tier.remote_tier.name}"
 	}
   }
 }
-`
+`, date)
 }
 
 func testAccMinioILMPolicyTransitionServiceAccount(username string) (varBlock string) {
@@ -386,3 +2678,325 @@ resource "minio_s3_bucket" %q {
   bucket = %q
 }`, resourceName, provider, bucketName)
 }
+
+func testAccMinioILMPolicyRulesOrder(randInt string, ids ...string) string {
+	rules := map[string]string{
+		"first": `
+  rule {
+	id = "first"
+	expiration = "5d"
+	filter = "temp/"
+  }`,
+		"second": `
+  rule {
+	id = "second"
+	expiration = "10d"
+	filter = "logs/"
+  }`,
+		"third": `
+  rule {
+	id = "third"
+	expiration = "15d"
+	filter = "cache/"
+  }`,
+	}
+
+	config := fmt.Sprintf(`
+resource "minio_s3_bucket" "bucket7" {
+  bucket = "%s"
+  acl    = "public-read"
+}
+resource "minio_ilm_policy" "rule7" {
+  bucket = minio_s3_bucket.bucket7.id`, randInt)
+	for _, id := range ids {
+		config += rules[id]
+	}
+
+	return config + "\n}\n"
+}
+
+func TestMinioReadILMPolicy_emptyAndMissingConfiguration(t *testing.T) {
+	cases := []struct {
+		name      string
+		status    int
+		body      string
+		keepState bool
+		wantErr   bool
+	}{
+		{
+			name:      "empty configuration",
+			status:    http.StatusOK,
+			body:      `<LifecycleConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"></LifecycleConfiguration>`,
+			keepState: true,
+		},
+		{
+			name:   "missing configuration",
+			status: http.StatusNotFound,
+			body:   `<Error><Code>NoSuchLifecycleConfiguration</Code><Message>The lifecycle configuration does not exist</Message></Error>`,
+		},
+		{
+			name:   "missing bucket",
+			status: http.StatusNotFound,
+			body:   `<Error><Code>NoSuchBucket</Code><Message>The specified bucket does not exist</Message></Error>`,
+		},
+		{
+			name:      "server error",
+			status:    http.StatusForbidden,
+			body:      `<Error><Code>AccessDenied</Code><Message>Access Denied.</Message></Error>`,
+			keepState: true,
+			wantErr:   true,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/xml")
+				w.WriteHeader(c.status)
+				_, _ = w.Write([]byte(c.body))
+			}))
+			defer server.Close()
+
+			client, err := minio.New(strings.TrimPrefix(server.URL, "http://"), &minio.Options{
+				Creds:  credentials.NewStaticV4("access", "secret", ""),
+				Region: "us-east-1",
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			d := schema.TestResourceDataRaw(t, resourceMinioILMPolicy().Schema, map[string]interface{}{
+				"bucket": "bucket",
+				"rule": []interface{}{
+					map[string]interface{}{"id": "expire", "expiration": "5d"},
+				},
+			})
+			d.SetId("bucket")
+
+			diags := minioReadILMPolicy(context.Background(), d, &S3MinioClient{S3Client: client})
+			if diags.HasError() != c.wantErr {
+				t.Fatalf("expected error %t, got %v", c.wantErr, diags)
+			}
+			if (d.Id() != "") != c.keepState {
+				t.Fatalf("expected state to be kept %t, got ID %q", c.keepState, d.Id())
+			}
+			if c.keepState && !c.wantErr {
+				if rules := d.Get("rule").([]interface{}); len(rules) != 0 {
+					t.Fatalf("expected no rules to be read, got %v", rules)
+				}
+				if count := d.Get("rule_count").(int); count != 0 {
+					t.Fatalf("expected a rule_count of 0, got %d", count)
+				}
+			}
+		})
+	}
+}
+
+func TestMinioReadILMPolicy_managedRuleIDs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/xml")
+		_, _ = w.Write([]byte(`<LifecycleConfiguration>` +
+			`<Rule><ID>mine</ID><Status>Enabled</Status><Filter></Filter><Expiration><Days>5</Days></Expiration></Rule>` +
+			`<Rule><ID>other</ID><Status>Enabled</Status><Filter></Filter><Expiration><Days>7</Days></Expiration></Rule>` +
+			`</LifecycleConfiguration>`))
+	}))
+	defer server.Close()
+
+	client, err := minio.New(strings.TrimPrefix(server.URL, "http://"), &minio.Options{
+		Creds:  credentials.NewStaticV4("access", "secret", ""),
+		Region: "us-east-1",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	meta := &S3MinioClient{S3Client: client}
+
+	// without managed rules in state, only the rules of the resource are taken over
+	d := schema.TestResourceDataRaw(t, resourceMinioILMPolicy().Schema, map[string]interface{}{
+		"bucket": "bucket",
+		"rule": []interface{}{
+			map[string]interface{}{"id": "mine", "expiration": "5d"},
+		},
+	})
+	d.SetId("bucket")
+	if diags := minioReadILMPolicy(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if ids := d.Get("managed_rule_ids").([]interface{}); !reflect.DeepEqual(ids, []interface{}{"mine"}) {
+		t.Fatalf("expected only the rule of the resource to be managed, got %v", ids)
+	}
+
+	// imported policies take over all the rules of the bucket
+	d = schema.TestResourceDataRaw(t, resourceMinioILMPolicy().Schema, map[string]interface{}{})
+	d.SetId("bucket")
+	if _, err := minioImportILMPolicy(context.Background(), d, meta); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if diags := minioReadILMPolicy(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+	if ids := d.Get("managed_rule_ids").([]interface{}); !reflect.DeepEqual(ids, []interface{}{"mine", "other"}) {
+		t.Fatalf("expected all the rules to be managed after import, got %v", ids)
+	}
+}
+
+func TestMinioReadILMPolicy_withoutRules(t *testing.T) {
+	for name, tc := range map[string]struct {
+		bucketStatus int
+		keepState    bool
+	}{
+		"bucket without lifecycle": {bucketStatus: http.StatusOK, keepState: true},
+		"missing bucket":           {bucketStatus: http.StatusNotFound},
+	} {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/xml")
+				if r.Method == http.MethodHead {
+					w.WriteHeader(tc.bucketStatus)
+					return
+				}
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(`<Error><Code>NoSuchLifecycleConfiguration</Code><Message>The lifecycle configuration does not exist</Message></Error>`))
+			}))
+			defer server.Close()
+
+			client, err := minio.New(strings.TrimPrefix(server.URL, "http://"), &minio.Options{
+				Creds:  credentials.NewStaticV4("access", "secret", ""),
+				Region: "us-east-1",
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			d := schema.TestResourceDataRaw(t, resourceMinioILMPolicy().Schema, map[string]interface{}{"bucket": "bucket"})
+			d.SetId("bucket")
+
+			if diags := minioReadILMPolicy(context.Background(), d, &S3MinioClient{S3Client: client}); diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if (d.Id() != "") != tc.keepState {
+				t.Fatalf("expected state to be kept %t, got ID %q", tc.keepState, d.Id())
+			}
+			if tc.keepState && d.Get("rule_count").(int) != 0 {
+				t.Fatalf("expected a rule_count of 0, got %d", d.Get("rule_count").(int))
+			}
+		})
+	}
+}
+
+func TestMinioReadILMPolicy_noncurrentTransitionNewerVersions(t *testing.T) {
+	for name, tc := range map[string]struct {
+		newerVersions string
+		expected      int
+		warning       bool
+	}{
+		"kept by the server":    {newerVersions: "<NewerNoncurrentVersions>3</NewerNoncurrentVersions>", expected: 3},
+		"dropped by the server": {expected: 0, warning: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/xml")
+				_, _ = w.Write([]byte(`<LifecycleConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/">
+  <Rule><ID>newer</ID><Status>Enabled</Status><Filter></Filter><NoncurrentVersionTransition><NoncurrentDays>7</NoncurrentDays><StorageClass>COLD</StorageClass>` + tc.newerVersions + `</NoncurrentVersionTransition></Rule>
+</LifecycleConfiguration>`))
+			}))
+			defer server.Close()
+
+			client, err := minio.New(strings.TrimPrefix(server.URL, "http://"), &minio.Options{
+				Creds:  credentials.NewStaticV4("access", "secret", ""),
+				Region: "us-east-1",
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			d := schema.TestResourceDataRaw(t, resourceMinioILMPolicy().Schema, map[string]interface{}{
+				"bucket": "bucket",
+				"rule": []interface{}{
+					map[string]interface{}{
+						"id":                                 "newer",
+						"noncurrent_version_transition_days": 7,
+						"noncurrent_version_transition_storage_class":  "COLD",
+						"noncurrent_version_transition_newer_versions": 3,
+					},
+				},
+			})
+			d.SetId("bucket")
+
+			diags := minioReadILMPolicy(context.Background(), d, &S3MinioClient{S3Client: client})
+			if diags.HasError() {
+				t.Fatalf("unexpected error: %v", diags)
+			}
+			if warned := len(diags) == 1 && strings.Contains(diags[0].Summary, "ignored noncurrent_version_transition_newer_versions"); warned != tc.warning {
+				t.Fatalf("expected a warning %t, got %v", tc.warning, diags)
+			}
+
+			if days := d.Get("rule.0.noncurrent_version_transition_days").(int); days != 7 {
+				t.Fatalf("expected noncurrent versions to be transitioned after 7 days, got %d", days)
+			}
+			if newerVersions := d.Get("rule.0.noncurrent_version_transition_newer_versions").(int); newerVersions != tc.expected {
+				t.Fatalf("expected %d newer noncurrent versions to be read, got %d", tc.expected, newerVersions)
+			}
+		})
+	}
+}
+
+func TestMinioReadILMPolicy_singleTagFilter(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/xml")
+		_, _ = w.Write([]byte(`<LifecycleConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/">
+  <Rule><ID>legacy</ID><Status>Enabled</Status><Filter><Tag><Key>app</Key><Value>legacy</Value></Tag></Filter><Expiration><Days>5</Days></Expiration></Rule>
+  <Rule><ID>filter</ID><Status>Enabled</Status><Filter><Tag><Key>app</Key><Value>test</Value></Tag></Filter><Expiration><Days>7</Days></Expiration></Rule>
+</LifecycleConfiguration>`))
+	}))
+	defer server.Close()
+
+	client, err := minio.New(strings.TrimPrefix(server.URL, "http://"), &minio.Options{
+		Creds:  credentials.NewStaticV4("access", "secret", ""),
+		Region: "us-east-1",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	meta := &S3MinioClient{S3Client: client}
+
+	raw := map[string]interface{}{
+		"bucket": "bucket",
+		"rule": []interface{}{
+			map[string]interface{}{
+				"id":         "legacy",
+				"expiration": "5d",
+				"tags":       map[string]interface{}{"app": "legacy"},
+			},
+			map[string]interface{}{
+				"id":         "filter",
+				"expiration": "7d",
+				"rule_filter": []interface{}{
+					map[string]interface{}{"tags": map[string]interface{}{"app": "test"}},
+				},
+			},
+		},
+	}
+	r := resourceMinioILMPolicy()
+	d := schema.TestResourceDataRaw(t, r.Schema, raw)
+	d.SetId("bucket")
+
+	if diags := minioReadILMPolicy(context.Background(), d, meta); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if tags := d.Get("rule.0.tags").(map[string]interface{}); tags["app"] != "legacy" {
+		t.Fatalf("expected the tag of the legacy rule to be read, got %v", tags)
+	}
+	if tags := d.Get("rule.1.rule_filter.0.tags").(map[string]interface{}); tags["app"] != "test" {
+		t.Fatalf("expected the tag of the rule_filter to be read, got %v", tags)
+	}
+
+	diff, err := r.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(raw), meta)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if diff != nil && !diff.Empty() {
+		t.Fatalf("expected no diff after reading single tag filters, got %v", diff.Attributes)
+	}
+}
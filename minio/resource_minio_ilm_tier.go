@@ -2,13 +2,29 @@ package minio
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/minio/madmin-go/v3"
-	"log"
 )
 
+// ilmTierStorageClasses lists the storage classes objects can be transitioned to, per tier type.
+// Archive classes are left out as objects must stay readable without a restore.
+var ilmTierStorageClasses = map[string][]string{
+	madmin.S3.String():    {"STANDARD", "STANDARD_IA", "ONEZONE_IA", "INTELLIGENT_TIERING", "GLACIER_IR", "REDUCED_REDUNDANCY"},
+	madmin.GCS.String():   {"STANDARD", "NEARLINE", "COLDLINE"},
+	madmin.Azure.String(): {"Hot", "Cool", "Cold"},
+}
+
 func resourceMinioILMTier() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: minioCreateILMTier,
@@ -18,7 +34,8 @@ func resourceMinioILMTier() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
-		Description: "`minio_ilm_tier` handles remote tiers",
+		CustomizeDiff: minioValidateILMTierDiff,
+		Description:   "`minio_ilm_tier` handles remote tiers. The server checks that the remote storage is reachable before adding a tier, which cannot be skipped and may take a while. Tiers are not serialized by the provider, so several tiers are added in parallel up to Terraform's `-parallelism`. Only the credentials of a tier can be updated in place: a tier whose type, endpoint, bucket, region or prefix changed outside of Terraform is planned for replacement",
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -42,21 +59,42 @@ func resourceMinioILMTier() *schema.Resource {
 				ValidateFunc: validation.StringInSlice([]string{"s3", "minio", "gcs", "azure"}, false),
 			},
 			"endpoint": {
-				Type:     schema.TypeString,
-				Optional: true,
-				ForceNew: true,
-				Default:  "",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "Endpoint of the remote storage. Defaults to `https://<account>.blob.core.windows.net` for `azure` tiers and to the server default for the other types",
 			},
 			"region": {
-				Type:     schema.TypeString,
-				Optional: true,
-				ForceNew: true,
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Region of the remote storage, required for `s3` and `gcs` tiers",
 			},
 			"force_new_credentials": {
 				Type:     schema.TypeBool,
 				Optional: true,
 				Default:  false,
 			},
+			"credentials_file": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"credentials_env"},
+				Description: "Path of a file holding the secret of the tier, read at apply time instead of setting it in the config block: " +
+					"the secret key for `s3` and `minio` tiers, the account key for `azure` tiers or the credentials JSON for `gcs` tiers. " +
+					"Only the path is stored in state. Changes to the file content are not detected, set `force_new_credentials` to push them",
+			},
+			"credentials_env": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"credentials_file"},
+				Description:   "Name of an environment variable holding the secret of the tier, read at apply time like `credentials_file`. Only the variable name is stored in state",
+			},
+			"arn": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Identifier of the tier in ARN form, `arn:minio:ilm:::tier/<name>`. Tiers have no AWS ARN, the format is specific to this provider",
+			},
 
 			"minio_config": {
 				Type:     schema.TypeList,
@@ -104,9 +142,10 @@ func resourceMinioILMTier() *schema.Resource {
 							},
 						},
 						"storage_class": {
-							Type:     schema.TypeString,
-							Optional: true,
-							ForceNew: true,
+							Type:        schema.TypeString,
+							Optional:    true,
+							ForceNew:    true,
+							Description: "One of `STANDARD`, `STANDARD_IA`, `ONEZONE_IA`, `INTELLIGENT_TIERING`, `GLACIER_IR` or `REDUCED_REDUNDANCY`",
 						},
 					},
 				},
@@ -118,9 +157,16 @@ func resourceMinioILMTier() *schema.Resource {
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"container": {
-							Type:     schema.TypeString,
-							Optional: true,
-							ForceNew: true,
+							Type:        schema.TypeString,
+							Optional:    true,
+							ForceNew:    true,
+							Description: "Name of the Azure storage account, used with `account_key` to authenticate and to derive the default `endpoint`",
+						},
+						"storage_class": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							ForceNew:    true,
+							Description: "Access tier of the transitioned blobs: `Hot`, `Cool` or `Cold`",
 						},
 						"account_key": {
 							Type:      schema.TypeString,
@@ -143,9 +189,11 @@ func resourceMinioILMTier() *schema.Resource {
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"credentials": {
-							Type:      schema.TypeString,
-							Optional:  true,
-							Sensitive: true,
+							Type:         schema.TypeString,
+							Optional:     true,
+							Sensitive:    true,
+							ValidateFunc: validateGCSTierCredentials,
+							Description:  "Service account key JSON of the Google Cloud project, as downloaded from the console. Use `credentials_file` to read it from a file instead",
 							DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
 								if d.Get("force_new_credentials").(bool) {
 									return false
@@ -153,6 +201,12 @@ func resourceMinioILMTier() *schema.Resource {
 								return old == "REDACTED"
 							},
 						},
+						"storage_class": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							ForceNew:    true,
+							Description: "One of `STANDARD`, `NEARLINE` or `COLDLINE`",
+						},
 					},
 				},
 			},
@@ -168,32 +222,90 @@ func minioCreateILMTier(ctx context.Context, d *schema.ResourceData, meta interf
 	d.SetId(name)
 	switch d.Get("type").(string) {
 	case madmin.S3.String():
+		s3Config := ilmTierConfigBlock(d, "s3_config")
+		options := []madmin.S3Options{
+			madmin.S3Region(d.Get("region").(string)),
+			madmin.S3Prefix(d.Get("prefix").(string)),
+		}
+		if endpoint := d.Get("endpoint").(string); endpoint != "" {
+			options = append(options, madmin.S3Endpoint(endpoint))
+		}
+		if storageClass := s3Config["storage_class"].(string); storageClass != "" {
+			options = append(options, madmin.S3StorageClass(storageClass))
+		}
+		secretKey, secretErr := ilmTierSecret(d, s3Config["secret_key"])
+		if secretErr != nil {
+			return NewResourceError("reading remote tier credentials failed", name, secretErr)
+		}
 		tierConf, err = madmin.NewTierS3(
 			name,
-			d.Get("access_key").(string),
-			d.Get("secret_key").(string),
+			s3Config["access_key"].(string),
+			secretKey,
 			d.Get("bucket").(string),
+			options...,
 		)
 	case madmin.MinIO.String():
 		minioConfig := d.Get("minio_config").([]interface{})[0].(map[string]interface{})
+		secretKey, secretErr := ilmTierSecret(d, minioConfig["secret_key"])
+		if secretErr != nil {
+			return NewResourceError("reading remote tier credentials failed", name, secretErr)
+		}
 		tierConf, err = madmin.NewTierMinIO(
 			name,
 			d.Get("endpoint").(string),
 			minioConfig["access_key"].(string),
-			minioConfig["secret_key"].(string),
+			secretKey,
 			d.Get("bucket").(string),
 		)
 	case madmin.GCS.String():
+		gcsConfig := ilmTierConfigBlock(d, "gcs_config")
+		options := []madmin.GCSOptions{
+			madmin.GCSRegion(d.Get("region").(string)),
+			madmin.GCSPrefix(d.Get("prefix").(string)),
+		}
+		if storageClass := gcsConfig["storage_class"].(string); storageClass != "" {
+			options = append(options, madmin.GCSStorageClass(storageClass))
+		}
+		credentials, secretErr := ilmTierSecret(d, gcsConfig["credentials"])
+		if secretErr == nil {
+			secretErr = parseGCSTierCredentials(credentials)
+		}
+		if secretErr != nil {
+			return NewResourceError("reading remote tier credentials failed", name, secretErr)
+		}
 		tierConf, err = madmin.NewTierGCS(
 			name,
-			d.Get("credentials").([]byte),
+			[]byte(credentials),
 			d.Get("bucket").(string),
+			options...,
 		)
 	case madmin.Azure.String():
+		azureConfig := ilmTierConfigBlock(d, "azure_config")
+		options := []madmin.AzureOptions{
+			madmin.AzurePrefix(d.Get("prefix").(string)),
+		}
+		endpoint := d.Get("endpoint").(string)
+		if endpoint == "" {
+			if endpoint, err = azureTierEndpoint(azureConfig["container"].(string)); err != nil {
+				return NewResourceError("creating remote tier failed", name, err)
+			}
+		}
+		options = append(options, madmin.AzureEndpoint(endpoint))
+		if region := d.Get("region").(string); region != "" {
+			options = append(options, madmin.AzureRegion(region))
+		}
+		if storageClass := azureConfig["storage_class"].(string); storageClass != "" {
+			options = append(options, madmin.AzureStorageClass(storageClass))
+		}
+		accountKey, secretErr := ilmTierSecret(d, azureConfig["account_key"])
+		if secretErr != nil {
+			return NewResourceError("reading remote tier credentials failed", name, secretErr)
+		}
 		tierConf, err = madmin.NewTierAzure(name,
-			d.Get("account_name").(string),
-			d.Get("account_key").(string),
+			azureConfig["container"].(string),
+			accountKey,
 			d.Get("bucket").(string),
+			options...,
 		)
 	}
 	if err != nil {
@@ -201,42 +313,118 @@ func minioCreateILMTier(ctx context.Context, d *schema.ResourceData, meta interf
 	}
 	err = c.AddTier(ctx, tierConf)
 	if err != nil {
-		return NewResourceError("adding remote tier failed", name, err)
+		return NewResourceError("adding remote tier failed", name, redactError(minioTierUnsupportedError(ctx, c, err), ilmTierSecrets(tierConf)...))
 	}
-	log.Printf("[DEBUG] Created Tier %s", name)
+	tflog.Debug(ctx, "Created remote tier", map[string]interface{}{"tier_name": name})
 	return minioReadILMTier(ctx, d, meta)
 }
 
+// ilmTierSecrets returns the credentials of a tier, to be masked in the errors of the server
+func ilmTierSecrets(tier *madmin.TierConfig) []string {
+	var secrets []string
+	switch {
+	case tier.S3 != nil:
+		secrets = append(secrets, tier.S3.SecretKey)
+	case tier.MinIO != nil:
+		secrets = append(secrets, tier.MinIO.SecretKey)
+	case tier.Azure != nil:
+		secrets = append(secrets, tier.Azure.AccountKey)
+	case tier.GCS != nil:
+		secrets = append(secrets, tier.GCS.Creds)
+		if creds, err := tier.GCS.GetCredentialJSON(); err == nil {
+			secrets = append(secrets, string(creds))
+		}
+	}
+	return secrets
+}
+
+// minioTieringRelease is the first MinIO release supporting remote tiers
+const minioTieringRelease = "RELEASE.2021-04-22T15-44-28Z"
+
+// minioServerInfoGetter is the part of the admin client needed to look up the server versions
+type minioServerInfoGetter interface {
+	ServerInfo(ctx context.Context) (madmin.InfoMessage, error)
+}
+
+// minioTierUnsupportedError explains a failure to add a tier when the server predates remote tiering,
+// and returns the error as is otherwise.
+func minioTierUnsupportedError(ctx context.Context, client minioServerInfoGetter, err error) error {
+	if madmin.ToErrorResponse(err).Code == "NotImplemented" {
+		return fmt.Errorf("remote tiering requires MinIO %s or later, the server does not support it: %w", minioTieringRelease, err)
+	}
+
+	info, infoErr := client.ServerInfo(ctx)
+	if infoErr != nil {
+		tflog.Warn(ctx, "Unable to get the server version to explain the tier error", map[string]interface{}{"error": redactSecrets(infoErr.Error())})
+		return err
+	}
+
+	supportedSince, _ := time.Parse("2006-01-02T15-04-05Z", strings.TrimPrefix(minioTieringRelease, "RELEASE."))
+	for _, server := range info.Servers {
+		// development builds do not report a release date and are assumed recent
+		released, parseErr := time.Parse(time.RFC3339, server.Version)
+		if parseErr == nil && released.Before(supportedSince) {
+			return fmt.Errorf("remote tiering requires MinIO %s or later, server %s runs %s: %w", minioTieringRelease, server.Endpoint, server.Version, err)
+		}
+	}
+
+	return err
+}
+
 func minioReadILMTier(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	c := meta.(*S3MinioClient).S3Admin
-	name := d.Get("name").(string)
+	// the ID is the tier name, the only attribute set on import
+	name := d.Id()
 	tier, err := getTier(c, ctx, name)
 	if err != nil {
 		return NewResourceError("reading remote tier failed", name, err)
 	}
 	if tier == nil {
-		log.Printf("%s", NewResourceErrorStr("unable to find tier", name, err))
+		tflog.Warn(ctx, "Remote tier not found, removing from state", map[string]interface{}{"tier_name": name})
 		d.SetId("")
 		return nil
 	}
-	log.Printf("[DEBUG] Tier [%s] exists!", name)
-	if err := d.Set("type", tier.Type.String()); err != nil {
-		return diag.FromErr(err)
-	}
-	if err := d.Set("prefix", tier.Prefix()); err != nil {
-		return diag.FromErr(err)
+	tflog.Debug(ctx, "Remote tier exists", map[string]interface{}{"tier_name": name})
+	if err := flattenILMTier(ctx, d, tier); err != nil {
+		return NewResourceError("reading remote tier failed", name, err)
 	}
-	if err := d.Set("name", tier.Name); err != nil {
-		return diag.FromErr(err)
+
+	return nil
+}
+
+// ilmTierImmutableFields are the settings of a tier which cannot be edited on the server, their values read back are
+// compared with state so a tier changed outside of Terraform is planned for replacement
+var ilmTierImmutableFields = []string{"type", "endpoint", "bucket", "region", "prefix"}
+
+// flattenILMTier sets the state from the tier read back from the server. Only the credentials of a tier can be
+// updated in place, changes to the other settings are planned as a replacement by their ForceNew schema.
+func flattenILMTier(ctx context.Context, d *schema.ResourceData, tier *madmin.TierConfig) error {
+	live := map[string]string{
+		"type":     tier.Type.String(),
+		"endpoint": tier.Endpoint(),
+		"bucket":   tier.Bucket(),
+		"region":   tier.Region(),
+		"prefix":   tier.Prefix(),
 	}
-	if err := d.Set("bucket", tier.Bucket()); err != nil {
-		return diag.FromErr(err)
+	for _, field := range ilmTierImmutableFields {
+		// empty values are either unset or not known yet, as the endpoint on create
+		if current := d.Get(field).(string); current != "" && current != live[field] {
+			tflog.Warn(ctx, "Remote tier changed outside of Terraform, it must be replaced", map[string]interface{}{
+				"tier_name": tier.Name,
+				"field":     field,
+				"state":     current,
+				"server":    live[field],
+			})
+		}
+		if err := d.Set(field, live[field]); err != nil {
+			return err
+		}
 	}
-	if err := d.Set("endpoint", tier.Endpoint()); err != nil {
-		return diag.FromErr(err)
+	if err := d.Set("name", tier.Name); err != nil {
+		return err
 	}
-	if err := d.Set("region", tier.Region()); err != nil {
-		return diag.FromErr(err)
+	if err := d.Set("arn", ilmTierArn(tier.Name)); err != nil {
+		return err
 	}
 	switch tier.Type {
 	case madmin.MinIO:
@@ -245,22 +433,24 @@ func minioReadILMTier(ctx context.Context, d *schema.ResourceData, meta interfac
 			"secret_key": tier.MinIO.SecretKey,
 		}}
 		if err := d.Set("minio_config", minioConfig); err != nil {
-			return diag.FromErr(err)
+			return err
 		}
 	case madmin.GCS:
 		gcsConfig := []map[string]string{{
-			"credentials": tier.GCS.Creds,
+			"credentials":   tier.GCS.Creds,
+			"storage_class": tier.GCS.StorageClass,
 		}}
 		if err := d.Set("gcs_config", gcsConfig); err != nil {
-			return diag.FromErr(err)
+			return err
 		}
 	case madmin.Azure:
 		azureConfig := []map[string]string{{
-			"container":   tier.Azure.AccountName,
-			"account_key": tier.Azure.AccountKey,
+			"container":     tier.Azure.AccountName,
+			"account_key":   tier.Azure.AccountKey,
+			"storage_class": tier.Azure.StorageClass,
 		}}
 		if err := d.Set("azure_config", azureConfig); err != nil {
-			return diag.FromErr(err)
+			return err
 		}
 	case madmin.S3:
 		s3Config := []map[string]string{{
@@ -269,9 +459,8 @@ func minioReadILMTier(ctx context.Context, d *schema.ResourceData, meta interfac
 			"storage_class": tier.S3.StorageClass,
 		}}
 		if err := d.Set("s3_config", s3Config); err != nil {
-			return diag.FromErr(err)
+			return err
 		}
-
 	}
 
 	return nil
@@ -290,31 +479,194 @@ func minioUpdateILMTier(ctx context.Context, d *schema.ResourceData, meta interf
 	c := meta.(*S3MinioClient).S3Admin
 	name := d.Get("name").(string)
 	credentials := madmin.TierCreds{}
+	var err error
 	switch d.Get("type").(string) {
 	case madmin.MinIO.String():
 		minioConfig := d.Get("minio_config").([]interface{})[0].(map[string]interface{})
 		credentials.AccessKey = minioConfig["access_key"].(string)
-		credentials.SecretKey = minioConfig["secret_key"].(string)
+		credentials.SecretKey, err = ilmTierSecret(d, minioConfig["secret_key"])
 	case madmin.GCS.String():
 		gcsConfig := d.Get("gcs_config").([]interface{})[0].(map[string]interface{})
-		credentials.CredsJSON = gcsConfig["credentials"].([]byte)
+		var creds string
+		creds, err = ilmTierSecret(d, gcsConfig["credentials"])
+		if err == nil {
+			err = parseGCSTierCredentials(creds)
+		}
+		credentials.CredsJSON = []byte(creds)
 	case madmin.Azure.String():
 		azureConfig := d.Get("azure_config").([]interface{})[0].(map[string]interface{})
-		credentials.SecretKey = azureConfig["account_key"].(string)
+		credentials.SecretKey, err = ilmTierSecret(d, azureConfig["account_key"])
 	case madmin.S3.String():
 		minioConfig := d.Get("s3_config").([]interface{})[0].(map[string]interface{})
 		credentials.AccessKey = minioConfig["access_key"].(string)
-		credentials.SecretKey = minioConfig["secret_key"].(string)
+		credentials.SecretKey, err = ilmTierSecret(d, minioConfig["secret_key"])
+	}
+	if err != nil {
+		return NewResourceError("reading remote tier credentials failed", name, err)
 	}
-	if d.HasChanges("minio_config", "gcs_config", "azure_config", "s3_config") {
+	if d.HasChanges("minio_config", "gcs_config", "azure_config", "s3_config", "credentials_file", "credentials_env") {
 		err := c.EditTier(ctx, name, credentials)
 		if err != nil {
-			return NewResourceError("error updating ILM tier %s: %s", d.Id(), err)
+			return NewResourceError("updating remote tier credentials failed", d.Id(), redactError(err, credentials.SecretKey, string(credentials.CredsJSON)))
 		}
 	}
 	return minioReadILMTier(ctx, d, meta)
 }
 
+func minioValidateILMTierDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	tierType := d.Get("type").(string)
+	configKey := tierType + "_config"
+
+	var storageClass string
+	blocks, ok := d.Get(configKey).([]interface{})
+	if !ok || len(blocks) == 0 || blocks[0] == nil {
+		return fmt.Errorf("%s block is required when type is %q", configKey, tierType)
+	}
+	if sc, ok := blocks[0].(map[string]interface{})["storage_class"]; ok {
+		storageClass = sc.(string)
+	}
+
+	if tierType == madmin.Azure.String() && d.NewValueKnown(configKey) {
+		if err := minioValidateAzureTierDiff(d, blocks[0].(map[string]interface{})); err != nil {
+			return err
+		}
+	}
+
+	if !d.NewValueKnown("region") {
+		return nil
+	}
+
+	return validateILMTierSettings(tierType, d.Get("region").(string), storageClass)
+}
+
+// minioValidateAzureTierDiff checks the storage account credentials are set, and derives the endpoint
+// from the account name when it is omitted on create
+func minioValidateAzureTierDiff(d *schema.ResourceDiff, azureConfig map[string]interface{}) error {
+	accountName := azureConfig["container"].(string)
+	if accountName == "" {
+		return fmt.Errorf("azure_config.container must be set to the storage account name when type is \"azure\"")
+	}
+	credentialsKnown := d.NewValueKnown("credentials_file") && d.NewValueKnown("credentials_env")
+	if credentialsKnown && azureConfig["account_key"].(string) == "" && d.Get("credentials_file").(string) == "" && d.Get("credentials_env").(string) == "" {
+		return fmt.Errorf("azure_config.account_key, credentials_file or credentials_env must be set when type is \"azure\"")
+	}
+
+	// existing tiers keep the endpoint they were created with
+	if rawConfig := d.GetRawConfig(); d.Id() != "" || rawConfig.IsNull() || !rawConfig.GetAttr("endpoint").IsNull() {
+		return nil
+	}
+	endpoint, err := azureTierEndpoint(accountName)
+	if err != nil {
+		return err
+	}
+	return d.SetNew("endpoint", endpoint)
+}
+
+// parseGCSTierCredentials checks the credentials of a gcs tier are a service account key JSON, so a malformed key
+// is reported before the server tries to reach the bucket with it
+func parseGCSTierCredentials(credentials string) error {
+	var key struct {
+		Type        string `json:"type"`
+		ClientEmail string `json:"client_email"`
+		PrivateKey  string `json:"private_key"`
+	}
+	// the decoding error is not wrapped since it may quote part of the key
+	if err := json.Unmarshal([]byte(credentials), &key); err != nil {
+		var syntaxErr *json.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			return fmt.Errorf("gcs credentials are not valid JSON (syntax error at offset %d)", syntaxErr.Offset)
+		}
+		return fmt.Errorf("gcs credentials must be a service account key JSON object")
+	}
+	if key.Type != "service_account" {
+		return fmt.Errorf("gcs credentials must be a service account key JSON, got type %q", key.Type)
+	}
+	if key.ClientEmail == "" || key.PrivateKey == "" {
+		return fmt.Errorf("gcs credentials must be a service account key JSON with client_email and private_key")
+	}
+	return nil
+}
+
+func validateGCSTierCredentials(v interface{}, k string) (ws []string, errors []error) {
+	// empty credentials are read from credentials_file or credentials_env
+	if value := v.(string); value != "" {
+		if err := parseGCSTierCredentials(value); err != nil {
+			errors = append(errors, err)
+		}
+	}
+	return
+}
+
+// azureStorageAccountNamePattern matches the names Azure accepts for storage accounts
+var azureStorageAccountNamePattern = regexp.MustCompile(`^[a-z0-9]{3,24}$`)
+
+// azureTierEndpoint returns the blob endpoint of an Azure storage account
+func azureTierEndpoint(accountName string) (string, error) {
+	if !azureStorageAccountNamePattern.MatchString(accountName) {
+		return "", fmt.Errorf("unable to derive the endpoint from storage account name %q, which must be 3 to 24 lowercase letters and digits; set endpoint explicitly", accountName)
+	}
+	return fmt.Sprintf("https://%s.blob.core.windows.net", accountName), nil
+}
+
+// validateILMTierSettings checks the region and storage class are valid for the tier backend
+func validateILMTierSettings(tierType, region, storageClass string) error {
+	switch tierType {
+	case madmin.S3.String(), madmin.GCS.String():
+		if region == "" {
+			return fmt.Errorf("region is required when type is %q", tierType)
+		}
+	}
+
+	if storageClass == "" {
+		return nil
+	}
+
+	validClasses, ok := ilmTierStorageClasses[tierType]
+	if !ok {
+		return fmt.Errorf("storage_class is not supported when type is %q", tierType)
+	}
+	if !Contains(validClasses, storageClass) {
+		return fmt.Errorf("invalid storage_class %q for type %q, expected one of: %s", storageClass, tierType, strings.Join(validClasses, ", "))
+	}
+
+	return nil
+}
+
+func ilmTierConfigBlock(d *schema.ResourceData, key string) map[string]interface{} {
+	blocks := d.Get(key).([]interface{})
+	if len(blocks) == 0 || blocks[0] == nil {
+		return map[string]interface{}{}
+	}
+	return blocks[0].(map[string]interface{})
+}
+
+// ilmTierSecret returns the secret of the tier from credentials_file or credentials_env when set,
+// or the secret of the config block otherwise
+func ilmTierSecret(d *schema.ResourceData, configured interface{}) (string, error) {
+	if path := d.Get("credentials_file").(string); path != "" {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("unable to read credentials_file: %w", err)
+		}
+		return strings.TrimRight(string(content), "\r\n"), nil
+	}
+
+	if name := d.Get("credentials_env").(string); name != "" {
+		secret, ok := os.LookupEnv(name)
+		if !ok || secret == "" {
+			return "", fmt.Errorf("environment variable %s from credentials_env is not set", name)
+		}
+		return secret, nil
+	}
+
+	secret, _ := configured.(string)
+	return secret, nil
+}
+
+func ilmTierArn(name string) string {
+	return fmt.Sprintf("%s%s", ilmTierResourcePrefix, name)
+}
+
 func getTier(client *madmin.AdminClient, ctx context.Context, name string) (*madmin.TierConfig, error) {
 	tiers, err := client.ListTiers(ctx)
 	if err != nil {
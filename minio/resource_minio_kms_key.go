@@ -2,22 +2,40 @@ package minio
 
 import (
 	"context"
-	"log"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/minio/madmin-go/v3"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/sse"
 )
 
+const kmsKeyStatusPollInterval = 2 * time.Second
+
+// minioKMSKeyStatusGetter is the part of the admin client needed to check that a key is usable
+type minioKMSKeyStatusGetter interface {
+	GetKeyStatus(ctx context.Context, keyID string) (*madmin.KMSKeyStatus, error)
+}
+
 func resourceMinioKMSKey() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: minioCreateKMSKey,
 		ReadContext:   minioReadKMSKey,
+		UpdateContext: minioUpdateKMSKey,
 		DeleteContext: minioDeleteKMSKey,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(time.Minute),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"key_id": {
@@ -25,6 +43,25 @@ func resourceMinioKMSKey() *schema.Resource {
 				Required: true,
 				ForceNew: true,
 			},
+			"key_material": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Sensitive:    true,
+				ValidateFunc: validateKMSKeyMaterial,
+				Description:  "Base64 encoded 256 bit AES key to import instead of letting the KMS generate the key, e.g. to restore a key from a backup. Requires a KMS that supports key import",
+			},
+			"default_for_buckets": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Buckets whose default server-side encryption is set to SSE-KMS with this key. The encryption is removed again when a bucket is taken out of the list or the key is destroyed",
+			},
+			"arn": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ARN of the key, in the form `arn:aws:kms:<key_id>` used by SSE-KMS bucket encryption",
+			},
 		},
 	}
 }
@@ -34,30 +71,61 @@ func minioCreateKMSKey(ctx context.Context, d *schema.ResourceData, meta interfa
 
 	keyID := keyConfig.MinioKMSKeyID
 
-	if err := keyConfig.MinioAdmin.CreateKey(ctx, keyID); err != nil {
-		return NewResourceError("error creating service account", keyID, err)
+	if diags := minioCheckKMSReachable(ctx, meta, keyID); diags.HasError() {
+		return diags
+	}
+
+	if err := minioCheckBucketsExist(ctx, keyConfig.MinioClient, keyConfig.MinioDefaultForBuckets); err != nil {
+		return NewResourceError("error validating default_for_buckets", keyID, err)
+	}
+
+	if keyConfig.MinioKMSKeyMaterial != "" {
+		if err := minioImportKMSKey(ctx, keyConfig.MinioAdmin, keyID, keyConfig.MinioKMSKeyMaterial); err != nil {
+			return NewResourceError("error importing KMS key", keyID, err)
+		}
+	} else if err := keyConfig.MinioAdmin.CreateKey(ctx, keyID); err != nil {
+		return NewResourceError("error creating KMS key", keyID, err)
 	}
 
 	d.SetId(aws.StringValue(&keyID))
 	_ = d.Set("key_id", d.Id())
 
+	// a new key may report encryption or decryption errors for a moment before it can be used
+	waitCtx, cancel := context.WithTimeout(ctx, d.Timeout(schema.TimeoutCreate))
+	defer cancel()
+
+	if err := waitForKMSKeyStatus(waitCtx, keyConfig.MinioAdmin, keyID, kmsKeyStatusPollInterval); err != nil {
+		return NewResourceError("KMS key did not become usable", keyID, err)
+	}
+
+	for _, bucket := range keyConfig.MinioDefaultForBuckets {
+		if err := minioSetBucketDefaultKMSKey(ctx, keyConfig.MinioClient, *bucket, keyID); err != nil {
+			return NewResourceError("error setting bucket default encryption", *bucket, err)
+		}
+	}
+
 	return minioReadKMSKey(ctx, d, meta)
 }
 
 func minioReadKMSKey(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	keyConfig := KMSKeyConfig(d, meta)
 
-	log.Printf("[DEBUG] Reading KMS key [%s]", keyConfig.MinioKMSKeyID)
+	tflog.Debug(ctx, "Reading KMS key", map[string]interface{}{"kms_key_id": keyConfig.MinioKMSKeyID})
 
 	status, err := keyConfig.MinioAdmin.GetKeyStatus(ctx, keyConfig.MinioKMSKeyID)
 	if err != nil {
-		log.Printf("%s", NewResourceErrorStr("error reading KMS key", keyConfig.MinioKMSKeyID, err))
-		d.SetId("")
+		if isNotFoundError(err) {
+			tflog.Warn(ctx, "KMS key not found, removing from state", map[string]interface{}{
+				"kms_key_id": keyConfig.MinioKMSKeyID,
+			})
+			d.SetId("")
+			return nil
+		}
 
-		return nil
+		return NewResourceError("error reading KMS key", keyConfig.MinioKMSKeyID, err)
 	}
 
-	log.Printf("[DEBUG] KMS key [%s] exists!", keyConfig.MinioKMSKeyID)
+	tflog.Debug(ctx, "KMS key exists", map[string]interface{}{"kms_key_id": keyConfig.MinioKMSKeyID})
 
 	if status.EncryptionErr != "" {
 		return NewResourceError("KMS key has encryption error", keyConfig.MinioKMSKeyID, status.EncryptionErr)
@@ -68,27 +136,195 @@ func minioReadKMSKey(ctx context.Context, d *schema.ResourceData, meta interface
 	}
 
 	_ = d.Set("key_id", d.Id())
+	_ = d.Set("arn", kmsKeyArn(d.Id()))
+
+	var buckets []string
+	for _, bucket := range keyConfig.MinioDefaultForBuckets {
+		if minioBucketUsesKMSKey(ctx, keyConfig.MinioClient, *bucket, d.Id()) {
+			buckets = append(buckets, *bucket)
+		}
+	}
+	if err := d.Set("default_for_buckets", buckets); err != nil {
+		return NewResourceError("error setting default_for_buckets", d.Id(), err)
+	}
 
 	return nil
 }
 
+func minioUpdateKMSKey(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	keyConfig := KMSKeyConfig(d, meta)
+
+	if d.HasChange("default_for_buckets") {
+		if diags := minioCheckKMSReachable(ctx, meta, d.Id()); diags.HasError() {
+			return diags
+		}
+
+		o, n := d.GetChange("default_for_buckets")
+		os := o.(*schema.Set)
+		ns := n.(*schema.Set)
+
+		bucketsToAdd := getStringList(ns.Difference(os).List())
+		if err := minioCheckBucketsExist(ctx, keyConfig.MinioClient, bucketsToAdd); err != nil {
+			return NewResourceError("error validating default_for_buckets", d.Id(), err)
+		}
+
+		for _, bucket := range getStringList(os.Difference(ns).List()) {
+			if err := minioUnsetBucketDefaultKMSKey(ctx, keyConfig.MinioClient, *bucket, d.Id()); err != nil {
+				return NewResourceError("error removing bucket default encryption", *bucket, err)
+			}
+		}
+
+		for _, bucket := range bucketsToAdd {
+			if err := minioSetBucketDefaultKMSKey(ctx, keyConfig.MinioClient, *bucket, d.Id()); err != nil {
+				return NewResourceError("error setting bucket default encryption", *bucket, err)
+			}
+		}
+	}
+
+	return minioReadKMSKey(ctx, d, meta)
+}
+
 func minioDeleteKMSKey(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var err error
 
 	keyConfig := KMSKeyConfig(d, meta)
 
-	log.Printf("[DEBUG] Deleting KMS key [%s]", d.Id())
+	for _, bucket := range keyConfig.MinioDefaultForBuckets {
+		if err = minioUnsetBucketDefaultKMSKey(ctx, keyConfig.MinioClient, *bucket, d.Id()); err != nil {
+			return NewResourceError("error removing bucket default encryption", *bucket, err)
+		}
+	}
+
+	tflog.Debug(ctx, "Deleting KMS key", map[string]interface{}{"kms_key_id": d.Id()})
 
 	if err = keyConfig.MinioAdmin.DeleteKey(ctx, d.Id()); err != nil {
-		log.Printf("%s", NewResourceErrorStr("unable to remove KMS key", d.Id(), err))
+		tflog.Error(ctx, "Unable to remove KMS key", map[string]interface{}{
+			"kms_key_id": d.Id(),
+			"error":      redactSecrets(err.Error()),
+		})
 
 		return NewResourceError("unable to remove KMS key", d.Id(), err)
 	}
 
-	log.Printf("[DEBUG] Deleted KMS key: [%s]", d.Id())
+	tflog.Debug(ctx, "Deleted KMS key", map[string]interface{}{"kms_key_id": d.Id()})
 
 	_ = d.Set("key_id", "")
 
 	return nil
 
 }
+
+// waitForKMSKeyStatus polls the status of a key until it reports no errors or the context expires,
+// returning the last error on timeout
+func waitForKMSKeyStatus(ctx context.Context, client minioKMSKeyStatusGetter, keyID string, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		status, err := client.GetKeyStatus(ctx, keyID)
+		switch {
+		case err != nil:
+		case status.EncryptionErr != "":
+			err = fmt.Errorf("encryption error: %s", status.EncryptionErr)
+		case status.DecryptionErr != "":
+			err = fmt.Errorf("decryption error: %s", status.DecryptionErr)
+		default:
+			return nil
+		}
+
+		tflog.Debug(ctx, "KMS key not usable yet", map[string]interface{}{
+			"kms_key_id": keyID,
+			"error":      redactSecrets(err.Error()),
+		})
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timeout while waiting for KMS key %s to become usable: %w", keyID, err)
+		case <-ticker.C:
+		}
+	}
+}
+
+func minioCheckBucketsExist(ctx context.Context, client *minio.Client, buckets []*string) error {
+	for _, bucket := range buckets {
+		exists, err := client.BucketExists(ctx, *bucket)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return fmt.Errorf("bucket %s does not exist", *bucket)
+		}
+	}
+
+	return nil
+}
+
+func minioSetBucketDefaultKMSKey(ctx context.Context, client *minio.Client, bucket, keyID string) error {
+	tflog.Debug(ctx, "Setting bucket default encryption with KMS key", map[string]interface{}{
+		"bucket":     bucket,
+		"kms_key_id": keyID,
+	})
+
+	return client.SetBucketEncryption(ctx, bucket, sse.NewConfigurationSSEKMS(keyID))
+}
+
+// minioUnsetBucketDefaultKMSKey removes the bucket default encryption, unless it has been changed to another key since
+func minioUnsetBucketDefaultKMSKey(ctx context.Context, client *minio.Client, bucket, keyID string) error {
+	if !minioBucketUsesKMSKey(ctx, client, bucket, keyID) {
+		return nil
+	}
+
+	tflog.Debug(ctx, "Removing bucket default encryption with KMS key", map[string]interface{}{
+		"bucket":     bucket,
+		"kms_key_id": keyID,
+	})
+
+	return client.RemoveBucketEncryption(ctx, bucket)
+}
+
+func minioBucketUsesKMSKey(ctx context.Context, client *minio.Client, bucket, keyID string) bool {
+	config, err := client.GetBucketEncryption(ctx, bucket)
+	if err != nil || len(config.Rules) == 0 {
+		return false
+	}
+
+	apply := config.Rules[0].Apply
+	return apply.SSEAlgorithm == "aws:kms" && strings.TrimPrefix(apply.KmsMasterKeyID, kmsKeyResourcePrefix) == keyID
+}
+
+// minioKMSKeyImporter is the part of the admin client needed to import key material
+type minioKMSKeyImporter interface {
+	ImportKey(ctx context.Context, keyID string, content []byte) error
+}
+
+func minioImportKMSKey(ctx context.Context, client minioKMSKeyImporter, keyID, keyMaterial string) error {
+	content, err := json.Marshal(map[string]string{
+		"bytes":     keyMaterial,
+		"algorithm": "AES256",
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := client.ImportKey(ctx, keyID, content); err != nil {
+		switch madmin.ToErrorResponse(err).Code {
+		case "NotImplemented", "XMinioKMSNotConfigured":
+			return fmt.Errorf("the KMS of the server does not support importing key material: %w", redactError(err, keyMaterial))
+		}
+		return redactError(err, keyMaterial)
+	}
+
+	return nil
+}
+
+func validateKMSKeyMaterial(v interface{}, k string) (ws []string, errors []error) {
+	key, err := base64.StdEncoding.DecodeString(v.(string))
+	if err != nil || len(key) != 32 {
+		errors = append(errors, fmt.Errorf("%q must be a base64 encoded 256 bit key", k))
+	}
+	return
+}
+
+func kmsKeyArn(keyID string) string {
+	return fmt.Sprintf("%s%s", kmsKeyResourcePrefix, keyID)
+}
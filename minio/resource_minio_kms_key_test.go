@@ -0,0 +1,196 @@
+package minio
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/minio/madmin-go/v3"
+)
+
+func TestAccMinioKMSKey_defaultForBuckets(t *testing.T) {
+	keyID := acctest.RandomWithPrefix("tf-acc-key")
+	bucketName := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "minio_kms_key.key"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckMinioS3BucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMinioKMSKeyDefaultForBucketsConfig(keyID, bucketName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "key_id", keyID),
+					resource.TestCheckResourceAttr(resourceName, "arn", "arn:aws:kms:"+keyID),
+					resource.TestCheckResourceAttr(resourceName, "default_for_buckets.#", "1"),
+					testAccCheckMinioBucketDefaultKMSKey(bucketName, keyID),
+				),
+			},
+		},
+	})
+}
+
+type fakeKMSKeyImporter struct {
+	content []byte
+	err     error
+}
+
+func (f *fakeKMSKeyImporter) ImportKey(ctx context.Context, keyID string, content []byte) error {
+	f.content = content
+	return f.err
+}
+
+func TestMinioImportKMSKey(t *testing.T) {
+	keyMaterial := "WS2Xg2Bpn+B4a6ANLNw0n0vDJo5w9Y5AvJ3sQ/CPGCY="
+	client := &fakeKMSKeyImporter{}
+
+	if err := minioImportKMSKey(context.Background(), client, "my-key", keyMaterial); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var request map[string]string
+	if err := json.Unmarshal(client.content, &request); err != nil {
+		t.Fatalf("import request should be JSON: %s", err)
+	}
+	if request["bytes"] != keyMaterial || request["algorithm"] != "AES256" {
+		t.Fatalf("unexpected import request %v", request)
+	}
+
+	client.err = madmin.ErrorResponse{Code: "NotImplemented", Message: "not implemented"}
+	err := minioImportKMSKey(context.Background(), client, "my-key", keyMaterial)
+	if err == nil || !strings.Contains(err.Error(), "does not support importing key material") {
+		t.Fatalf("expected unsupported import error, got %v", err)
+	}
+}
+
+func TestMinioReadKMSKey_errors(t *testing.T) {
+	cases := []struct {
+		name     string
+		status   int
+		code     string
+		notFound bool
+	}{
+		{name: "not found", status: http.StatusNotFound, code: "kms:KeyNotFound", notFound: true},
+		{name: "other error", status: http.StatusForbidden, code: "AccessDenied"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tc.status)
+				_, _ = w.Write([]byte(fmt.Sprintf(`{"Code":%q,"Message":"failed"}`, tc.code)))
+			}))
+			defer server.Close()
+
+			config := NewConfig(schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+				"minio_server":   strings.TrimPrefix(server.URL, "http://"),
+				"minio_user":     "access",
+				"minio_password": "secret",
+			}))
+			client, err := config.NewClient()
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			d := schema.TestResourceDataRaw(t, resourceMinioKMSKey().Schema, map[string]interface{}{"key_id": "my-key"})
+			d.SetId("my-key")
+
+			diags := minioReadKMSKey(context.Background(), d, client)
+			if tc.notFound {
+				if diags.HasError() || d.Id() != "" {
+					t.Fatalf("a missing key should be removed from state, got %v", diags)
+				}
+				return
+			}
+			// the key may still exist, removing it from state would create it again
+			if !diags.HasError() || d.Id() != "my-key" {
+				t.Fatalf("expected an error and the key kept in state, got %v", diags)
+			}
+		})
+	}
+}
+
+// fakeKMSKeyStatusGetter returns its statuses in turn, repeating the last one
+type fakeKMSKeyStatusGetter struct {
+	statuses []madmin.KMSKeyStatus
+	errs     []error
+	calls    int
+}
+
+func (f *fakeKMSKeyStatusGetter) GetKeyStatus(ctx context.Context, keyID string) (*madmin.KMSKeyStatus, error) {
+	i := f.calls
+	if i >= len(f.statuses) {
+		i = len(f.statuses) - 1
+	}
+	f.calls++
+	return &f.statuses[i], f.errs[i]
+}
+
+func TestWaitForKMSKeyStatus(t *testing.T) {
+	client := &fakeKMSKeyStatusGetter{
+		statuses: []madmin.KMSKeyStatus{{}, {EncryptionErr: "key not found"}, {}},
+		errs:     []error{errors.New("connection reset"), nil, nil},
+	}
+	if err := waitForKMSKeyStatus(context.Background(), client, "my-key", time.Millisecond); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if client.calls != 3 {
+		t.Fatalf("expected 3 status reads, got %d", client.calls)
+	}
+
+	client = &fakeKMSKeyStatusGetter{
+		statuses: []madmin.KMSKeyStatus{{DecryptionErr: "key not found"}},
+		errs:     []error{nil},
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err := waitForKMSKeyStatus(ctx, client, "my-key", time.Millisecond)
+	if err == nil || !strings.Contains(err.Error(), "timeout while waiting for KMS key my-key") || !strings.Contains(err.Error(), "decryption error: key not found") {
+		t.Fatalf("expected timeout with the last error, got %v", err)
+	}
+}
+
+func TestValidateKMSKeyMaterial(t *testing.T) {
+	if _, errs := validateKMSKeyMaterial("WS2Xg2Bpn+B4a6ANLNw0n0vDJo5w9Y5AvJ3sQ/CPGCY=", "key_material"); len(errs) != 0 {
+		t.Fatalf("valid key material rejected: %v", errs)
+	}
+	for _, keyMaterial := range []string{"not base64!", "c2hvcnQ="} {
+		if _, errs := validateKMSKeyMaterial(keyMaterial, "key_material"); len(errs) == 0 {
+			t.Fatalf("invalid key material %q accepted", keyMaterial)
+		}
+	}
+}
+
+func testAccCheckMinioBucketDefaultKMSKey(bucket, keyID string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		minioC := testAccProvider.Meta().(*S3MinioClient).S3Client
+		if !minioBucketUsesKMSKey(context.Background(), minioC, bucket, keyID) {
+			return fmt.Errorf("bucket %s default encryption does not use KMS key %s", bucket, keyID)
+		}
+		return nil
+	}
+}
+
+func testAccMinioKMSKeyDefaultForBucketsConfig(keyID, bucketName string) string {
+	return fmt.Sprintf(`
+resource "minio_s3_bucket" "bucket" {
+  bucket = "%s"
+}
+
+resource "minio_kms_key" "key" {
+  key_id              = "%s"
+  default_for_buckets = [minio_s3_bucket.bucket.bucket]
+}
+`, bucketName, keyID)
+}
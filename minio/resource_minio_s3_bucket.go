@@ -10,6 +10,7 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/id"
 	"github.com/minio/minio-go/v7"
@@ -40,7 +41,13 @@ func resourceMinioBucket() *schema.Resource {
 				Computed:      true,
 				ForceNew:      true,
 				ConflictsWith: []string{"bucket_prefix"},
-				ValidateFunc:  validation.StringLenBetween(0, 63),
+				ValidateDiagFunc: func(v interface{}, p cty.Path) diag.Diagnostics {
+					// an empty bucket is generated like when it is not set
+					if v.(string) == "" {
+						return nil
+					}
+					return validateBucketName(v, p)
+				},
 			},
 			"bucket_prefix": {
 				Type:          schema.TypeString,
@@ -73,10 +80,18 @@ func resourceMinioBucket() *schema.Resource {
 				Optional: true,
 			},
 			"object_locking": {
-				Type:     schema.TypeBool,
-				Optional: true,
-				Default:  false,
-				ForceNew: false,
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				ForceNew:    true,
+				Description: "Enable object locking on the bucket. It can only be set when the bucket is created, changing it replaces the bucket",
+			},
+			"region": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "Region the bucket is created in, for servers and gateways requiring a matching region. Defaults to the provider region, or us-east-1. Changing it replaces the bucket",
 			},
 		},
 	}
@@ -125,18 +140,22 @@ func minioCreateBucket(ctx context.Context, d *schema.ResourceData, meta interfa
 	}
 
 	_ = d.Set("bucket", bucket)
+	_ = d.Set("region", region)
 	d.SetId(bucket)
 
-	bucketConfig = BucketConfig(d, meta)
-
-	if errACL := minioSetBucketACL(ctx, bucketConfig); errACL != nil {
-		log.Printf("%s", NewResourceErrorStr("unable to create bucket", bucket, errACL))
-		return NewResourceError("[ACL] Unable to create bucket", bucket, errACL)
+	if diags := minioApplyBucketFeatures(ctx, d, BucketConfig(d, meta)); diags.HasError() {
+		// roll back the bucket so the next apply starts over instead of replacing a tainted bucket
+		if err := bucketConfig.MinioClient.RemoveBucket(ctx, bucket); err != nil {
+			log.Printf("%s", NewResourceErrorStr("unable to roll back bucket", bucket, err))
+			return append(diags, NewResourceError("unable to roll back bucket", bucket, err)...)
+		}
+		d.SetId("")
+		return diags
 	}
 
 	log.Printf("[DEBUG] Created bucket: [%s] in region: [%s]", bucket, region)
 
-	return minioUpdateBucket(ctx, d, meta)
+	return minioReadBucket(ctx, d, meta)
 }
 
 func minioReadBucket(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -144,7 +163,14 @@ func minioReadBucket(ctx context.Context, d *schema.ResourceData, meta interface
 
 	log.Printf("[DEBUG] Reading bucket [%s] in region [%s]", d.Id(), bucketConfig.MinioRegion)
 
-	found, err := bucketConfig.MinioClient.BucketExists(ctx, d.Id())
+	var found bool
+	err := retryOnError(ctx, retryTimeout, func() (err error) {
+		found, err = bucketConfig.MinioClient.BucketExists(ctx, d.Id())
+		return err
+	})
+	if err != nil && !isNotFoundError(err) {
+		return NewResourceError("error reading bucket", d.Id(), err)
+	}
 	if !found {
 		log.Printf("%s", NewResourceErrorStr("unable to find bucket", d.Id(), err))
 		d.SetId("")
@@ -162,41 +188,86 @@ func minioReadBucket(ctx context.Context, d *schema.ResourceData, meta interface
 	_ = d.Set("arn", bucketArn(d.Id()))
 	_ = d.Set("bucket_domain_name", bucketDomainName(d.Id(), bucketURL))
 
+	objectLocking, err := minioBucketObjectLockingEnabled(ctx, bucketConfig.MinioClient, d.Id())
+	if err != nil {
+		return NewResourceError("unable to read bucket object lock configuration", d.Id(), err)
+	}
+	_ = d.Set("object_locking", objectLocking)
+
+	// MinIO reports its own region for every bucket rather than the one it was created in, so the region
+	// is only read for imported buckets
+	if _, ok := d.GetOk("region"); !ok {
+		region, err := bucketConfig.MinioClient.GetBucketLocation(ctx, d.Id())
+		if err != nil {
+			return NewResourceError("unable to read bucket location", d.Id(), err)
+		}
+		_ = d.Set("region", region)
+	}
+
 	return nil
 }
 
 func minioUpdateBucket(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	bucketConfig := BucketConfig(d, meta)
+	if diags := minioApplyBucketFeatures(ctx, d, BucketConfig(d, meta)); diags.HasError() {
+		return diags
+	}
 
-	if d.HasChange("acl") {
-		log.Printf("[DEBUG] Updating bucket. Bucket: [%s], Region: [%s]",
-			bucketConfig.MinioBucket, bucketConfig.MinioRegion)
+	return minioReadBucket(ctx, d, meta)
+}
 
-		if err := minioSetBucketACL(ctx, bucketConfig); err != nil {
-			log.Printf("%s", NewResourceErrorStr("unable to update bucket", bucketConfig.MinioBucket, err))
-			return NewResourceError("[ACL] Unable to update bucket", bucketConfig.MinioBucket, err)
-		}
+// minioBucketFeature is a setting of minio_s3_bucket applied on top of the bucket itself
+type minioBucketFeature struct {
+	name  string
+	key   string
+	apply func(ctx context.Context, d *schema.ResourceData, bucketConfig *S3MinioBucket) diag.Diagnostics
+}
 
-		log.Printf("[DEBUG] Bucket [%s] updated!", bucketConfig.MinioBucket)
-		_ = d.Set("acl", bucketConfig.MinioACL)
-	}
+// minioBucketFeatures lists the bucket features in the order they are applied
+var minioBucketFeatures = []minioBucketFeature{
+	{
+		name: "ACL",
+		key:  "acl",
+		apply: func(ctx context.Context, d *schema.ResourceData, bucketConfig *S3MinioBucket) diag.Diagnostics {
+			return minioSetBucketACL(ctx, bucketConfig)
+		},
+	},
+	{
+		name: "Quota",
+		key:  "quota",
+		apply: func(ctx context.Context, d *schema.ResourceData, bucketConfig *S3MinioBucket) diag.Diagnostics {
+			return minioSetBucketQuota(ctx, bucketConfig, &madmin.BucketQuota{Quota: uint64(d.Get("quota").(int)), Type: madmin.HardQuota})
+		},
+	},
+}
 
-	if d.HasChange("quota") {
-		log.Printf("[DEBUG] Updating bucket, quota changed. Bucket: [%s], Region: [%s]",
-			bucketConfig.MinioBucket, bucketConfig.MinioRegion)
+// minioApplyBucketFeatures applies the changed bucket features in order and stops at the first failure.
+// The failed feature and the ones after it keep their previous value in state so the next apply retries them.
+func minioApplyBucketFeatures(ctx context.Context, d *schema.ResourceData, bucketConfig *S3MinioBucket) diag.Diagnostics {
+	for i, feature := range minioBucketFeatures {
+		if !d.HasChange(feature.key) {
+			continue
+		}
 
-		bucketQuota := madmin.BucketQuota{Quota: uint64(d.Get("quota").(int)), Type: madmin.HardQuota}
+		log.Printf("[DEBUG] Updating bucket, %s changed. Bucket: [%s], Region: [%s]",
+			feature.key, bucketConfig.MinioBucket, bucketConfig.MinioRegion)
 
-		if err := minioSetBucketQuota(ctx, bucketConfig, &bucketQuota); err != nil {
-			log.Printf("%s", NewResourceErrorStr("unable to update bucket", bucketConfig.MinioBucket, err))
-			return NewResourceError("[Quota] Unable to update bucket", bucketConfig.MinioBucket, err)
+		if diags := feature.apply(ctx, d, bucketConfig); diags.HasError() {
+			for _, pending := range minioBucketFeatures[i:] {
+				old, _ := d.GetChange(pending.key)
+				_ = d.Set(pending.key, old)
+			}
+
+			diags = NewResourceError(fmt.Sprintf("[%s] Unable to configure bucket", feature.name), bucketConfig.MinioBucket, diags)
+			for j := range diags {
+				diags[j].AttributePath = cty.GetAttrPath(feature.key)
+			}
+			return diags
 		}
 
-		log.Printf("[DEBUG] Bucket [%s] updated!", bucketConfig.MinioBucket)
-		_ = d.Set("quota", bucketQuota.Quota)
+		log.Printf("[DEBUG] Bucket [%s] %s updated!", bucketConfig.MinioBucket, feature.key)
 	}
 
-	return minioReadBucket(ctx, d, meta)
+	return nil
 }
 
 func minioDeleteBucket(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -208,32 +279,39 @@ func minioDeleteBucket(ctx context.Context, d *schema.ResourceData, meta interfa
 		if strings.Contains(err.Error(), "empty") {
 			if bucketConfig.MinioForceDestroy {
 				objectsCh := make(chan minio.ObjectInfo)
+				listErrCh := make(chan error, 1)
 
 				// Send object names that are needed to be removed to objectsCh
 				go func() {
 					defer close(objectsCh)
 
-					ctx, cancel := context.WithCancel(ctx)
-
-					// Indicate to our routine to exit cleanly upon return.
-					defer cancel()
-
 					// List all objects from a bucket-name with a matching prefix.
 					for object := range bucketConfig.MinioClient.ListObjects(ctx, d.Id(), minio.ListObjectsOptions{
 						Recursive:    true,
 						WithVersions: true,
 					}) {
 						if object.Err != nil {
-							log.Fatalln(object.Err)
+							listErrCh <- object.Err
+							return
 						}
 						objectsCh <- object
 					}
 				}()
 
-				errorCh := bucketConfig.MinioClient.RemoveObjects(ctx, d.Id(), objectsCh, minio.RemoveObjectsOptions{})
-
-				if len(errorCh) > 0 {
-					return NewResourceError("unable to remove bucket", d.Id(), errors.New("could not delete objects"))
+				// wait for every object to be removed before removing the bucket again
+				var removeErr error
+				for removeObjectErr := range bucketConfig.MinioClient.RemoveObjects(ctx, d.Id(), objectsCh, minio.RemoveObjectsOptions{}) {
+					if removeErr == nil {
+						removeErr = fmt.Errorf("could not delete object %s: %w", removeObjectErr.ObjectName, removeObjectErr.Err)
+					}
+				}
+				select {
+				case listErr := <-listErrCh:
+					return NewResourceError("unable to list the objects of the bucket", d.Id(), listErr)
+				default:
+				}
+				if removeErr != nil {
+					return NewResourceError("unable to remove bucket", d.Id(), removeErr)
 				}
 
 				return minioDeleteBucket(ctx, d, meta)
@@ -254,6 +332,18 @@ func minioDeleteBucket(ctx context.Context, d *schema.ResourceData, meta interfa
 
 }
 
+func minioBucketObjectLockingEnabled(ctx context.Context, client *minio.Client, bucket string) (bool, error) {
+	objectLock, _, _, _, err := client.GetObjectLockConfig(ctx, bucket)
+	if err != nil {
+		if minio.ToErrorResponse(err).Code == "ObjectLockConfigurationNotFoundError" {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return objectLock == "Enabled", nil
+}
+
 func minioSetBucketACL(ctx context.Context, bucketConfig *S3MinioBucket) diag.Diagnostics {
 
 	defaultPolicies := map[string]string{
@@ -311,6 +401,20 @@ func bucketDomainName(bucket string, bucketConfig *url.URL) string {
 	return fmt.Sprintf("%s/minio/%s", bucketConfig, bucket)
 }
 
+// validateBucketName checks the bucket of bucket-scoped resources against the S3 naming rules, so that typos are
+// reported at plan time instead of by the server
+func validateBucketName(v interface{}, p cty.Path) diag.Diagnostics {
+	if err := validateS3BucketName(v.(string)); err != nil {
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       "invalid bucket name",
+			Detail:        err.Error(),
+			AttributePath: p,
+		}}
+	}
+	return nil
+}
+
 func validateS3BucketName(value string) error {
 	if (len(value) < 3) || (len(value) > 63) {
 		return fmt.Errorf("%q must contain from 3 to 63 characters", value)
@@ -327,6 +431,9 @@ func validateS3BucketName(value string) error {
 	if strings.HasSuffix(value, `.`) {
 		return fmt.Errorf("%q cannot end with a period", value)
 	}
+	if strings.HasPrefix(value, `-`) || strings.HasSuffix(value, `-`) {
+		return fmt.Errorf("%q must start and end with a lowercase letter or a number", value)
+	}
 	if strings.Contains(value, `..`) {
 		return fmt.Errorf("%q can be only one period between labels", value)
 	}
@@ -23,9 +23,10 @@ func resourceMinioBucketNotification() *schema.Resource {
 		},
 		Schema: map[string]*schema.Schema{
 			"bucket": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateBucketName,
 			},
 			"queue": {
 				Type:     schema.TypeList,
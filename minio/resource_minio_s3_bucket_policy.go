@@ -9,8 +9,13 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
+// bucketPolicyCannedACLs are the canned policies of minio_s3_bucket_policy, in the
+// order they are matched when recognizing a policy read from the server
+var bucketPolicyCannedACLs = []string{"private", "public-read", "public-read-write", "upload", "download"}
+
 func resourceMinioBucketPolicy() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: minioPutBucketPolicy,
@@ -20,18 +25,29 @@ func resourceMinioBucketPolicy() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		CustomizeDiff: minioBucketPolicyACLDiff,
 		Schema: map[string]*schema.Schema{
 			"bucket": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateBucketName,
 			},
 			"policy": {
 				Type:             schema.TypeString,
-				Required:         true,
+				Optional:         true,
+				Computed:         true,
+				ExactlyOneOf:     []string{"policy", "acl"},
 				ValidateFunc:     validateIAMPolicyJSON,
 				DiffSuppressFunc: suppressEquivalentAwsPolicyDiffs,
 			},
+			"acl": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"policy", "acl"},
+				ValidateFunc: validation.StringInSlice(bucketPolicyCannedACLs, false),
+				Description:  "Canned anonymous access policy to apply instead of `policy`, one of `private`, `public-read`, `public-read-write`, `download` or `upload`",
+			},
 		},
 	}
 }
@@ -39,6 +55,10 @@ func resourceMinioBucketPolicy() *schema.Resource {
 func minioPutBucketPolicy(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	bucketPolicyConfig := BucketPolicyConfig(d, meta)
 
+	if bucketPolicyConfig.MinioBucketACL != "" {
+		bucketPolicyConfig.MinioBucketPolicy = bucketPolicyCannedPolicy(bucketPolicyConfig.MinioBucket, bucketPolicyConfig.MinioBucketACL)
+	}
+
 	policy, err := structure.NormalizeJsonString(bucketPolicyConfig.MinioBucketPolicy)
 
 	if err != nil {
@@ -55,6 +75,13 @@ func minioPutBucketPolicy(ctx context.Context, d *schema.ResourceData, meta inte
 
 	d.SetId(bucketPolicyConfig.MinioBucket)
 
+	// a private acl removes the policy, which an empty planned policy does not show
+	if bucketPolicyConfig.MinioBucketACL != "" {
+		if err := d.Set("policy", policy); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
 	return nil
 }
 
@@ -68,6 +95,16 @@ func minioReadBucketPolicy(ctx context.Context, d *schema.ResourceData, meta int
 		return NewResourceError("failed to load bucket policy", d.Id(), err)
 	}
 
+	// The acl is only tracked when it is used or, on import, when the policy matches one
+	acl := bucketPolicyConfig.MinioBucketACL
+	if acl != "" || bucketPolicyConfig.MinioBucketPolicy == "" {
+		if cannedACL := bucketPolicyCannedACL(d.Id(), actualPolicyText, acl); cannedACL != "" || acl != "" {
+			if err := d.Set("acl", cannedACL); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	}
+
 	policy, err := secondJSONUnlessEquivalent(d.Get("policy").(string), actualPolicyText)
 	if err != nil {
 		return NewResourceError("error while setting policy", policy, err)
@@ -103,6 +140,61 @@ func minioDeleteBucketPolicy(ctx context.Context, d *schema.ResourceData, meta i
 	return nil
 }
 
+// minioBucketPolicyACLDiff plans the policy of a canned acl, so that the policy in state follows acl changes
+func minioBucketPolicyACLDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	acl := d.Get("acl").(string)
+	if acl == "" || !d.NewValueKnown("acl") || !d.HasChange("acl") {
+		return nil
+	}
+
+	policy, err := structure.NormalizeJsonString(bucketPolicyCannedPolicy(d.Get("bucket").(string), acl))
+	if err != nil {
+		return err
+	}
+
+	return d.SetNew("policy", policy)
+}
+
+// bucketPolicyCannedPolicy returns the policy JSON of a canned acl, empty for private
+func bucketPolicyCannedPolicy(bucket, acl string) string {
+	bucketConfig := &S3MinioBucket{MinioBucket: bucket}
+
+	switch acl {
+	case "public-read", "download":
+		return exportPolicyString(ReadOnlyPolicy(bucketConfig), bucket)
+	case "public-read-write":
+		return exportPolicyString(ReadWritePolicy(bucketConfig), bucket)
+	case "upload":
+		return exportPolicyString(WriteOnlyPolicy(bucketConfig), bucket)
+	}
+
+	return ""
+}
+
+// bucketPolicyCannedACL returns the canned acl matching policy, preferring current
+// when several acls produce the same policy, or empty when the policy is not canned
+func bucketPolicyCannedACL(bucket, policy, current string) string {
+	acls := bucketPolicyCannedACLs
+	if current != "" {
+		acls = append([]string{current}, acls...)
+	}
+
+	for _, acl := range acls {
+		cannedPolicy := bucketPolicyCannedPolicy(bucket, acl)
+		if cannedPolicy == "" || strings.TrimSpace(policy) == "" {
+			if cannedPolicy == strings.TrimSpace(policy) {
+				return acl
+			}
+			continue
+		}
+		if equivalent, err := awspolicy.PoliciesAreEquivalent(cannedPolicy, policy); err == nil && equivalent {
+			return acl
+		}
+	}
+
+	return ""
+}
+
 // Based on SecondJSONUnlessEquivalent from SecondJSONUnlessEquivalent
 func secondJSONUnlessEquivalent(old, new string) (string, error) {
 	// valid empty JSON is "{}" not "" so handle special case to avoid
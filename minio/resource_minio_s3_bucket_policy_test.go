@@ -3,6 +3,7 @@ package minio
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"testing"
 
 	awspolicy "github.com/hashicorp/awspolicyequivalence"
@@ -148,6 +149,112 @@ func TestAccS3BucketPolicy_order(t *testing.T) {
 	})
 }
 
+func TestAccS3BucketPolicy_acl(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "minio_s3_bucket_policy.bucket"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckMinioS3BucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBucketPolicyConfigACL(name, "download"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "acl", "download"),
+					testAccCheckBucketHasPolicy("minio_s3_bucket.bucket", bucketPolicyCannedPolicy(name, "download")),
+				),
+			},
+			{
+				Config: testAccBucketPolicyConfigACL(name, "public-read-write"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "acl", "public-read-write"),
+					testAccCheckBucketHasPolicy("minio_s3_bucket.bucket", bucketPolicyCannedPolicy(name, "public-read-write")),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccBucketPolicyConfigACL(name, "private"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "acl", "private"),
+					resource.TestCheckResourceAttr(resourceName, "policy", ""),
+				),
+			},
+		},
+	})
+}
+
+func TestAccS3BucketPolicy_aclConflictsWithPolicy(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckMinioS3BucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "minio_s3_bucket" "bucket" {
+  bucket = "%s"
+}
+resource "minio_s3_bucket_policy" "bucket" {
+  bucket = minio_s3_bucket.bucket.bucket
+  acl    = "public-read"
+  policy = jsonencode({
+    Version   = "2012-10-17"
+    Statement = []
+  })
+}
+`, name),
+				ExpectError: regexp.MustCompile("only one of `acl,policy` can be specified"),
+			},
+		},
+	})
+}
+
+func TestBucketPolicyCannedACL(t *testing.T) {
+	bucket := "bucket"
+
+	cases := []struct {
+		name    string
+		policy  string
+		current string
+		want    string
+	}{
+		{"no policy", "", "", "private"},
+		{"read only", bucketPolicyCannedPolicy(bucket, "public-read"), "", "public-read"},
+		{"read only with download alias", bucketPolicyCannedPolicy(bucket, "public-read"), "download", "download"},
+		{"write only", bucketPolicyCannedPolicy(bucket, "upload"), "", "upload"},
+		{"read write", bucketPolicyCannedPolicy(bucket, "public-read-write"), "public-read", "public-read-write"},
+		{"canned policy of another bucket", bucketPolicyCannedPolicy("other", "public-read"), "public-read", ""},
+		{"custom policy", `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"AWS":["*"]},"Action":["s3:ListBucket"],"Resource":["arn:aws:s3:::bucket"]}]}`, "", ""},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := bucketPolicyCannedACL(bucket, tc.policy, tc.current); got != tc.want {
+				t.Errorf("bucketPolicyCannedACL() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func testAccBucketPolicyConfigACL(bucketName, acl string) string {
+	return fmt.Sprintf(`
+resource "minio_s3_bucket" "bucket" {
+  bucket = "%s"
+}
+resource "minio_s3_bucket_policy" "bucket" {
+  bucket = minio_s3_bucket.bucket.bucket
+  acl    = "%s"
+}
+`, bucketName, acl)
+}
+
 func testAccBucketPolicyConfig(bucketName string) string {
 	return fmt.Sprintf(`
 resource "minio_s3_bucket" "bucket" {
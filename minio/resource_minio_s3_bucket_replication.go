@@ -36,10 +36,11 @@ func resourceMinioBucketReplication() *schema.Resource {
 		},
 		Schema: map[string]*schema.Schema{
 			"bucket": {
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
-				Description: "Name of the bucket on which to setup replication rules",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateBucketName,
+				Description:      "Name of the bucket on which to setup replication rules",
 			},
 			"rule": {
 				Type:        schema.TypeList,
@@ -54,8 +55,9 @@ func resourceMinioBucketReplication() *schema.Resource {
 						},
 						"arn": {
 							Type:        schema.TypeString,
+							Optional:    true,
 							Computed:    true,
-							Description: "Rule ARN genrated by MinIO",
+							Description: "ARN of the remote target of the rule. Without a `target` block, it must be set to the ARN of an existing remote target, such as one managed by `minio_s3_bucket_replication_target`. Otherwise it is generated by MinIO",
 						},
 						"enabled": {
 							Type:        schema.TypeBool,
@@ -72,7 +74,7 @@ func resourceMinioBucketReplication() *schema.Resource {
 								newVal, _ := strconv.Atoi(newValue)
 								return oldVal == 0 && newVal == 0 || oldVal == newVal
 							},
-							Description: "Rule priority. If omitted, the inverted index will be used as priority. This means that the first rule definition will have the higher priority",
+							Description: "Rule priority. If omitted, the inverted index will be used as priority. This means that the first rule definition will have the higher priority. Priorities must be unique across the rules of the bucket",
 						},
 						"prefix": {
 							Type:        schema.TypeString,
@@ -99,30 +101,30 @@ func resourceMinioBucketReplication() *schema.Resource {
 						"delete_marker_replication": {
 							Type:        schema.TypeBool,
 							Optional:    true,
-							Description: "Whether or not to synchronise marker deletion",
+							Description: "Whether or not to synchronise marker deletion (`DeleteMarkerReplication` of the rule)",
 						},
 						"existing_object_replication": {
 							Type:        schema.TypeBool,
 							Optional:    true,
-							Description: "Whether or not to synchronise object created prior the replication configuration",
+							Description: "Whether or not to synchronise object created prior the replication configuration (`ExistingObjectReplication` of the rule). Required to replicate the data already in the bucket when enabling replication",
 						},
 						"metadata_sync": {
 							Type:        schema.TypeBool,
 							Optional:    true,
-							Description: "Whether or not to synchonise buckets and objects metadata (such as locks). This must be enabled to achieve a two-way replication",
+							Description: "Whether or not to synchonise buckets and objects metadata (such as locks), the replica modification setting (`ReplicaModifications`) of the rule. This must be enabled to achieve a two-way replication",
 						},
 						"target": {
 							Type:        schema.TypeList,
-							MinItems:    1,
 							MaxItems:    1,
-							Required:    true,
-							Description: "Bucket prefix",
+							Optional:    true,
+							Description: "Remote target managed by the rule. Omit it to use the existing remote target set in `arn` instead",
 							Elem: &schema.Resource{
 								Schema: map[string]*schema.Schema{
 									"bucket": {
-										Type:        schema.TypeString,
-										Required:    true,
-										Description: "The name of the existing target bucket to replicate into",
+										Type:             schema.TypeString,
+										Required:         true,
+										ValidateDiagFunc: validateBucketName,
+										Description:      "The name of the existing target bucket to replicate into",
 									},
 									"storage_class": {
 										Type:        schema.TypeString,
@@ -252,9 +254,14 @@ func minioPutBucketReplication(ctx context.Context, d *schema.ResourceData, meta
 		return diags
 	}
 
+	if diags := validateBucketReplicationPriorities(replicationConfig); diags.HasError() {
+		return diags
+	}
+
 	log.Printf("[DEBUG] S3 bucket: %s, put replication configuration: %v", bucketReplicationConfig.MinioBucket, replicationConfig)
 
-	cfg, err := convertBucketReplicationConfig(bucketReplicationConfig, replicationConfig)
+	oldRules, _ := d.GetChange("rule")
+	cfg, err := convertBucketReplicationConfig(bucketReplicationConfig, replicationConfig, bucketReplicationManagedTargets(oldRules.([]interface{})))
 
 	if err != nil {
 		return NewResourceError(fmt.Sprintf("error generating bucket replication configuration for %q", bucketReplicationConfig.MinioBucket), d.Id(), err)
@@ -361,6 +368,12 @@ func minioReadBucketReplication(ctx context.Context, d *schema.ResourceData, met
 			rules[ruleIdx]["tags"] = nil
 		}
 
+		// Rules referring to a remote target managed elsewhere have no target block to read it into
+		if len(bucketReplicationConfig.ReplicationRules) > ruleIdx && bucketReplicationConfig.ReplicationRules[ruleIdx].ExternalTarget {
+			rules[ruleIdx]["target"] = []interface{}{}
+			continue
+		}
+
 		// During import, there is no rules defined. Furthermore, since it is impossible to read the secret from the API, we
 		// default it to an empty string, allowing user to prevent remote changes by also using an empty string or omiting the secret_key
 		if len(bucketReplicationConfig.ReplicationRules) > ruleIdx {
@@ -377,19 +390,22 @@ func minioReadBucketReplication(ctx context.Context, d *schema.ResourceData, met
 		return diag.FromErr(fmt.Errorf("error reading replication remote target configuration: %s", err))
 	}
 
-	if len(existingRemoteTargets) != len(rules) {
-		return diag.FromErr(fmt.Errorf("inconsistent number of remote target and bucket replication rules (%d != %d)", len(existingRemoteTargets), len(rules)))
-	}
-
+	remoteTargetArns := make([]string, 0, len(existingRemoteTargets))
 	for _, remoteTarget := range existingRemoteTargets {
 		var ruleIdx int
 		var ok bool
 		var target map[string]interface{}
+		remoteTargetArns = append(remoteTargetArns, remoteTarget.Arn)
 		if ruleIdx, ok = ruleArnMap[remoteTarget.Arn]; !ok {
-			return diag.FromErr(fmt.Errorf("unable to find the remote target configuration for ARN %q on %s", remoteTarget.Arn, bucketName))
+			// Remote targets no rule uses may be managed by other resources, such as minio_s3_bucket_replication_target
+			log.Printf("[DEBUG] Ignoring remote target %q on %s, which no rule uses", remoteTarget.Arn, bucketName)
+			continue
 		}
 		var targets []interface{}
-		if targets, ok = rules[ruleIdx]["target"].([]interface{}); !ok || len(targets) != 1 {
+		if targets, ok = rules[ruleIdx]["target"].([]interface{}); ok && len(targets) == 0 {
+			continue
+		}
+		if !ok || len(targets) != 1 {
 			return diag.FromErr(fmt.Errorf("unable to find the bucket replication configuration associated to ARN %q (rule#%d) on %s", remoteTarget.Arn, ruleIdx, bucketName))
 		}
 		if target, ok = targets[0].(map[string]interface{}); !ok || len(target) == 0 {
@@ -417,6 +433,12 @@ func minioReadBucketReplication(ctx context.Context, d *schema.ResourceData, met
 		rules[ruleIdx]["target"] = []interface{}{target}
 	}
 
+	for arn := range ruleArnMap {
+		if !slices.Contains(remoteTargetArns, arn) {
+			return diag.FromErr(fmt.Errorf("unable to find the remote target configuration for ARN %q on %s", arn, bucketName))
+		}
+	}
+
 	if err := d.Set("bucket", d.Id()); err != nil {
 		return diag.FromErr(fmt.Errorf("error setting replication configuration: %w", err))
 	}
@@ -460,8 +482,15 @@ func minioDeleteBucketReplication(ctx context.Context, d *schema.ResourceData, m
 		log.Printf("[WARN] Unable to fetch existing remote target config for %q: %v", bucketReplicationConfig.MinioBucket, err)
 		return diag.FromErr(fmt.Errorf("error reading replication remote target configuration: %s", err))
 	}
-	if len(existingRemoteTargets) != 0 {
-		return diag.FromErr(fmt.Errorf("%d remote targets are still present on the bukcet while none are expected", len(existingRemoteTargets)))
+	managedARNs := bucketReplicationManagedTargets(d.Get("rule").([]interface{}))
+	remainingTargets := 0
+	for _, existingRemoteTarget := range existingRemoteTargets {
+		if slices.Contains(managedARNs, existingRemoteTarget.Arn) {
+			remainingTargets++
+		}
+	}
+	if remainingTargets != 0 {
+		return diag.FromErr(fmt.Errorf("%d remote targets are still present on the bukcet while none are expected", remainingTargets))
 	}
 
 	return diags
@@ -473,7 +502,10 @@ func toEnableFlag(b bool) string {
 	return "disable"
 }
 
-func convertBucketReplicationConfig(bucketReplicationConfig *S3MinioBucketReplication, c []S3MinioBucketReplicationRule) (rcfg replication.Config, err error) {
+// convertBucketReplicationConfig creates or updates the remote targets of the rules and returns the replication
+// configuration using them. Of the existing remote targets, only the managed ones, previously created by the rules,
+// are updated or removed when unused, the others belong to other resources such as minio_s3_bucket_replication_target.
+func convertBucketReplicationConfig(bucketReplicationConfig *S3MinioBucketReplication, c []S3MinioBucketReplicationRule, managedARNs []string) (rcfg replication.Config, err error) {
 	client := bucketReplicationConfig.MinioClient
 	admclient := bucketReplicationConfig.MinioAdmin
 
@@ -493,91 +525,16 @@ func convertBucketReplicationConfig(bucketReplicationConfig *S3MinioBucketReplic
 	}
 
 	for i, rule := range c {
-		err = s3utils.CheckValidBucketName(rule.Target.Bucket)
-		if err != nil {
-			log.Printf("[WARN] Invalid bucket name for %q: %v", rule.Target.Bucket, err)
-			return
-		}
-
-		tgtBucket := rule.Target.Bucket
-		if rule.Target.Path != "" {
-			tgtBucket = path.Clean("./" + rule.Target.Path + "/" + tgtBucket)
-		}
-		log.Printf("[DEBUG] Full path to target bucket is %s", tgtBucket)
-
-		creds := &madmin.Credentials{AccessKey: rule.Target.AccessKey, SecretKey: rule.Target.SecretKey}
-		bktTarget := &madmin.BucketTarget{
-			TargetBucket:        tgtBucket,
-			Secure:              rule.Target.Secure,
-			Credentials:         creds,
-			Endpoint:            rule.Target.Host,
-			Path:                rule.Target.PathStyle.String(),
-			API:                 "s3v4",
-			Type:                madmin.ReplicationService,
-			Region:              rule.Target.Region,
-			BandwidthLimit:      rule.Target.BandwidthLimit,
-			ReplicationSync:     rule.Target.Syncronous,
-			DisableProxy:        rule.Target.DisableProxy,
-			HealthCheckDuration: rule.Target.HealthCheckPeriod,
-		}
-		targets, _ := admclient.ListRemoteTargets(ctx, bucketReplicationConfig.MinioBucket, string(madmin.ReplicationService))
-		log.Printf("[DEBUG] Existing remote targets %q: %v", bucketReplicationConfig.MinioBucket, targets)
 		var arn string
-
-		var existingRemoteTarget *madmin.BucketTarget
-		if rule.Arn != "" {
-			for i, target := range targets {
-				if target.Arn == rule.Arn {
-					existingRemoteTarget = &targets[i]
-					break
-				}
-				// At this stage, we could also anticipate already existing remote target failure since endpoint is unique
-				// per bucket (https://github.com/minio/minio/blob/master/cmd/bucket-targets.go#L356) but this behavior could change in the future
-			}
-		}
-
-		if existingRemoteTarget == nil {
-			log.Printf("[DEBUG] Adding new remote target %v for %q", *bktTarget, bucketReplicationConfig.MinioBucket)
-			arn, err = admclient.SetRemoteTarget(ctx, bucketReplicationConfig.MinioBucket, bktTarget)
-			if err != nil {
-				log.Printf("[WARN] Unable to configure remote target %v for %q: %v", *bktTarget, bucketReplicationConfig.MinioBucket, err)
-				return
-			}
-		} else {
-			var remoteTargetUpdate []madmin.TargetUpdateType
-
-			if *existingRemoteTarget.Credentials != *bktTarget.Credentials {
-				existingRemoteTarget.Credentials = bktTarget.Credentials
-				remoteTargetUpdate = append(remoteTargetUpdate, madmin.CredentialsUpdateType)
-			}
-			if existingRemoteTarget.ReplicationSync != bktTarget.ReplicationSync {
-				existingRemoteTarget.ReplicationSync = bktTarget.ReplicationSync
-				remoteTargetUpdate = append(remoteTargetUpdate, madmin.SyncUpdateType)
-			}
-			if existingRemoteTarget.DisableProxy != bktTarget.DisableProxy {
-				existingRemoteTarget.DisableProxy = bktTarget.DisableProxy
-				remoteTargetUpdate = append(remoteTargetUpdate, madmin.ProxyUpdateType)
-			}
-			if existingRemoteTarget.BandwidthLimit != bktTarget.BandwidthLimit {
-				existingRemoteTarget.BandwidthLimit = bktTarget.BandwidthLimit
-				remoteTargetUpdate = append(remoteTargetUpdate, madmin.BandwidthLimitUpdateType)
-			}
-			if existingRemoteTarget.HealthCheckDuration != bktTarget.HealthCheckDuration {
-				existingRemoteTarget.HealthCheckDuration = bktTarget.HealthCheckDuration
-				remoteTargetUpdate = append(remoteTargetUpdate, madmin.HealthCheckDurationUpdateType)
-			}
-			if existingRemoteTarget.Path != bktTarget.Path {
-				existingRemoteTarget.Path = bktTarget.Path
-				remoteTargetUpdate = append(remoteTargetUpdate, madmin.PathUpdateType)
-			}
-			log.Printf("[DEBUG] Editing remote target %v for %q", *bktTarget, bucketReplicationConfig.MinioBucket)
-			arn, err = admclient.UpdateRemoteTarget(ctx, existingRemoteTarget, remoteTargetUpdate...)
-			if err != nil {
-				log.Printf("[WARN] Unable to update the remote target %v for %q: %v", *bktTarget, bucketReplicationConfig.MinioBucket, err)
+		if rule.ExternalTarget {
+			if !slices.ContainsFunc(existingRemoteTargets, func(target madmin.BucketTarget) bool { return target.Arn == rule.Arn }) {
+				err = fmt.Errorf("rule[%d] refers to the remote target %q, which does not exist on the bucket", i, rule.Arn)
 				return
 			}
+			arn = rule.Arn
+		} else if arn, err = putBucketReplicationRuleTarget(ctx, bucketReplicationConfig, rule, managedARNs); err != nil {
+			return
 		}
-
 		tagList := []string{}
 		for k, v := range rule.Tags {
 			var escapedValue *url.URL
@@ -622,7 +579,7 @@ func convertBucketReplicationConfig(bucketReplicationConfig *S3MinioBucketReplic
 	}
 
 	for _, existingRemoteTarget := range existingRemoteTargets {
-		if !slices.Contains(usedARNs, existingRemoteTarget.Arn) {
+		if slices.Contains(managedARNs, existingRemoteTarget.Arn) && !slices.Contains(usedARNs, existingRemoteTarget.Arn) {
 			err = admclient.RemoveRemoteTarget(ctx, bucketReplicationConfig.MinioBucket, existingRemoteTarget.Arn)
 		}
 
@@ -634,6 +591,142 @@ func convertBucketReplicationConfig(bucketReplicationConfig *S3MinioBucketReplic
 	return
 }
 
+// putBucketReplicationRuleTarget creates the remote target of a rule, or updates it when it is one of the managed
+// targets, and returns its ARN
+func putBucketReplicationRuleTarget(ctx context.Context, bucketReplicationConfig *S3MinioBucketReplication, rule S3MinioBucketReplicationRule, managedARNs []string) (arn string, err error) {
+	if err = s3utils.CheckValidBucketName(rule.Target.Bucket); err != nil {
+		log.Printf("[WARN] Invalid bucket name for %q: %v", rule.Target.Bucket, err)
+		return
+	}
+
+	tgtBucket := rule.Target.Bucket
+	if rule.Target.Path != "" {
+		tgtBucket = path.Clean("./" + rule.Target.Path + "/" + tgtBucket)
+	}
+	log.Printf("[DEBUG] Full path to target bucket is %s", tgtBucket)
+
+	creds := &madmin.Credentials{AccessKey: rule.Target.AccessKey, SecretKey: rule.Target.SecretKey}
+	bktTarget := &madmin.BucketTarget{
+		TargetBucket:        tgtBucket,
+		Secure:              rule.Target.Secure,
+		Credentials:         creds,
+		Endpoint:            rule.Target.Host,
+		Path:                rule.Target.PathStyle.String(),
+		API:                 "s3v4",
+		Type:                madmin.ReplicationService,
+		Region:              rule.Target.Region,
+		BandwidthLimit:      rule.Target.BandwidthLimit,
+		ReplicationSync:     rule.Target.Syncronous,
+		DisableProxy:        rule.Target.DisableProxy,
+		HealthCheckDuration: rule.Target.HealthCheckPeriod,
+	}
+	admclient := bucketReplicationConfig.MinioAdmin
+	targets, _ := admclient.ListRemoteTargets(ctx, bucketReplicationConfig.MinioBucket, string(madmin.ReplicationService))
+	log.Printf("[DEBUG] Existing remote targets %q: %v", bucketReplicationConfig.MinioBucket, targets)
+
+	var existingRemoteTarget *madmin.BucketTarget
+	if slices.Contains(managedARNs, rule.Arn) {
+		for i, target := range targets {
+			if target.Arn == rule.Arn {
+				existingRemoteTarget = &targets[i]
+				break
+			}
+			// At this stage, we could also anticipate already existing remote target failure since endpoint is unique
+			// per bucket (https://github.com/minio/minio/blob/master/cmd/bucket-targets.go#L356) but this behavior could change in the future
+		}
+	}
+
+	if existingRemoteTarget == nil {
+		log.Printf("[DEBUG] Adding new remote target %v for %q", *bktTarget, bucketReplicationConfig.MinioBucket)
+		arn, err = admclient.SetRemoteTarget(ctx, bucketReplicationConfig.MinioBucket, bktTarget)
+		if err != nil {
+			log.Printf("[WARN] Unable to configure remote target %v for %q: %v", *bktTarget, bucketReplicationConfig.MinioBucket, err)
+			return
+		}
+	} else {
+		var remoteTargetUpdate []madmin.TargetUpdateType
+
+		if *existingRemoteTarget.Credentials != *bktTarget.Credentials {
+			existingRemoteTarget.Credentials = bktTarget.Credentials
+			remoteTargetUpdate = append(remoteTargetUpdate, madmin.CredentialsUpdateType)
+		}
+		if existingRemoteTarget.ReplicationSync != bktTarget.ReplicationSync {
+			existingRemoteTarget.ReplicationSync = bktTarget.ReplicationSync
+			remoteTargetUpdate = append(remoteTargetUpdate, madmin.SyncUpdateType)
+		}
+		if existingRemoteTarget.DisableProxy != bktTarget.DisableProxy {
+			existingRemoteTarget.DisableProxy = bktTarget.DisableProxy
+			remoteTargetUpdate = append(remoteTargetUpdate, madmin.ProxyUpdateType)
+		}
+		if existingRemoteTarget.BandwidthLimit != bktTarget.BandwidthLimit {
+			existingRemoteTarget.BandwidthLimit = bktTarget.BandwidthLimit
+			remoteTargetUpdate = append(remoteTargetUpdate, madmin.BandwidthLimitUpdateType)
+		}
+		if existingRemoteTarget.HealthCheckDuration != bktTarget.HealthCheckDuration {
+			existingRemoteTarget.HealthCheckDuration = bktTarget.HealthCheckDuration
+			remoteTargetUpdate = append(remoteTargetUpdate, madmin.HealthCheckDurationUpdateType)
+		}
+		if existingRemoteTarget.Path != bktTarget.Path {
+			existingRemoteTarget.Path = bktTarget.Path
+			remoteTargetUpdate = append(remoteTargetUpdate, madmin.PathUpdateType)
+		}
+		log.Printf("[DEBUG] Editing remote target %v for %q", *bktTarget, bucketReplicationConfig.MinioBucket)
+		arn, err = admclient.UpdateRemoteTarget(ctx, existingRemoteTarget, remoteTargetUpdate...)
+		if err != nil {
+			log.Printf("[WARN] Unable to update the remote target %v for %q: %v", *bktTarget, bucketReplicationConfig.MinioBucket, err)
+			return
+		}
+	}
+
+	return
+}
+
+// bucketReplicationManagedTargets returns the ARNs of the remote targets created by the rules, i.e. of the rules
+// with a target block
+func bucketReplicationManagedTargets(rules []interface{}) (arns []string) {
+	for _, rule := range rules {
+		tfMap, ok := rule.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if targets, ok := tfMap["target"].([]interface{}); !ok || len(targets) == 0 {
+			continue
+		}
+		if arn, ok := tfMap["arn"].(string); ok && arn != "" {
+			arns = append(arns, arn)
+		}
+	}
+	return
+}
+
+// validateBucketReplicationPriorities rejects rules sharing a priority, as MinIO requires them to be unique.
+// Priorities generated from the rule index count too, since they are sent as positive values.
+func validateBucketReplicationPriorities(rules []S3MinioBucketReplicationRule) (errs diag.Diagnostics) {
+	byPriority := map[int]int{}
+	for i, rule := range rules {
+		priority := int(math.Abs(float64(rule.Priority)))
+		if j, ok := byPriority[priority]; ok {
+			errs = append(errs, diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       fmt.Sprintf("duplicate replication rule priority %d", priority),
+				Detail:        fmt.Sprintf("%s and %s have the same priority, set a unique priority on each rule.", bucketReplicationRuleName(j, rules[j]), bucketReplicationRuleName(i, rule)),
+				AttributePath: cty.GetAttrPath("rule").IndexInt(i).GetAttr("priority"),
+			})
+			continue
+		}
+		byPriority[priority] = i
+	}
+
+	return
+}
+
+func bucketReplicationRuleName(i int, rule S3MinioBucketReplicationRule) string {
+	if rule.Id == "" {
+		return fmt.Sprintf("rule[%d]", i)
+	}
+	return fmt.Sprintf("rule[%d] (%s)", i, rule.Id)
+}
+
 func getBucketReplicationConfig(v []interface{}) (result []S3MinioBucketReplicationRule, errs diag.Diagnostics) {
 	if len(v) == 0 || v[0] == nil {
 		return
@@ -692,7 +785,14 @@ func getBucketReplicationConfig(v []interface{}) (result []S3MinioBucketReplicat
 		result[i].MetadataSync = result[i].MetadataSync && ok
 
 		var targets []interface{}
-		if targets, ok = tfMap["target"].([]interface{}); !ok || len(targets) != 1 {
+		if targets, ok = tfMap["target"].([]interface{}); ok && len(targets) == 0 {
+			if result[i].Arn == "" {
+				errs = append(errs, diag.Errorf("rule[%d] requires either a target or the arn of an existing remote target", i)...)
+			}
+			result[i].ExternalTarget = true
+			continue
+		}
+		if !ok || len(targets) != 1 {
 			errs = append(errs, diag.Errorf("Unexpected value type for rule[%d].target. Exactly one target configuration is expected", i)...)
 			continue
 		}
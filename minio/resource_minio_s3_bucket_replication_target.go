@@ -0,0 +1,329 @@
+package minio
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/minio/madmin-go/v3"
+	"golang.org/x/exp/slices"
+)
+
+func resourceMinioBucketReplicationTarget() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: minioCreateBucketReplicationTarget,
+		ReadContext:   minioReadBucketReplicationTarget,
+		UpdateContext: minioUpdateBucketReplicationTarget,
+		DeleteContext: minioDeleteBucketReplicationTarget,
+		Importer: &schema.ResourceImporter{
+			StateContext: minioImportBucketReplicationTarget,
+		},
+		Description: "Registers a remote replication target on a bucket, returning the ARN replication rules refer to.",
+		Schema: map[string]*schema.Schema{
+			"bucket": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateBucketName,
+				Description:      "Name of the bucket replicating to the target",
+			},
+			"target_bucket": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateBucketName,
+				Description:      "Name of the existing bucket to replicate into",
+			},
+			"host": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The target host (pair IP/port or domain port). If port is omitted, HTTPS port (or HTTP if unsecure) will be used. This host must be reachable by the MinIO instance itself",
+			},
+			"secure": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				ForceNew:    true,
+				Description: "Whether to use HTTPS with this target (Recommended)",
+			},
+			"path_style": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "auto",
+				ValidateFunc: validation.StringInSlice([]string{"on", "off", "auto"}, false),
+				Description:  "Whether to use path-style or virtual-hosted-syle request to this target. `auto` allows MinIO to chose automatically the appropriate option",
+			},
+			"path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Path of the MinIO endpoint, if the MinIO API isn't served at the root, e.g. `/minio/` for `example.com/minio/`",
+			},
+			"region": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Region of the target MinIO. This will be used to generate the target ARN",
+			},
+			"access_key": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotEmpty,
+				Description:  "Access key for the replication service account in the target MinIO",
+			},
+			"secret_key": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Sensitive:    true,
+				ValidateFunc: validation.StringIsNotEmpty,
+				Description:  "Secret key for the replication service account in the target MinIO. It is not read back from the server",
+			},
+			"synchronous": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Use synchronous replication",
+			},
+			"disable_proxy": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Disable proxying requests for objects not yet replicated to this target",
+			},
+			"health_check_period": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "30s",
+				DiffSuppressFunc: func(k, oldValue, newValue string, d *schema.ResourceData) bool {
+					newVal, err := time.ParseDuration(newValue)
+					return err == nil && shortDur(newVal) == oldValue
+				},
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^[0-9]+\s?[s|m|h]$`), "must be a valid golang duration"),
+				Description:  "Period where the health of this target will be checked. This must be a valid duration, such as `5s` or `2m`",
+			},
+			"bandwidth_limit": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "0",
+				DiffSuppressFunc: func(k, oldValue, newValue string, d *schema.ResourceData) bool {
+					newVal, err := humanize.ParseBytes(newValue)
+					return err == nil && humanize.Bytes(newVal) == oldValue
+				},
+				ValidateFunc: validateBucketReplicationTargetBandwidth,
+				Description:  "Maximum bandwidth in bytes per second that MinIO can use when replicating to this target, e.g. `100MB`. Minimum is 100MB, 0 means unlimited",
+			},
+			"arn": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ARN of the remote target generated by MinIO, used as destination of replication rules",
+			},
+		},
+	}
+}
+
+func minioCreateBucketReplicationTarget(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	targetConfig, err := BucketReplicationTargetConfig(d, meta)
+	if err != nil {
+		return NewResourceError("invalid replication target", d.Get("bucket").(string), err)
+	}
+
+	log.Printf("[DEBUG] Adding remote target %s/%s to bucket %s", targetConfig.Target.Host, targetConfig.Target.Bucket, targetConfig.MinioBucket)
+
+	arn, err := targetConfig.MinioAdmin.SetRemoteTarget(ctx, targetConfig.MinioBucket, bucketReplicationTarget(targetConfig))
+	if err != nil {
+		return NewResourceError("error adding remote target", targetConfig.MinioBucket, err)
+	}
+
+	d.SetId(bucketReplicationTargetID(targetConfig.MinioBucket, arn))
+	_ = d.Set("arn", arn)
+
+	return minioReadBucketReplicationTarget(ctx, d, meta)
+}
+
+func minioReadBucketReplicationTarget(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	admin := meta.(*S3MinioClient).S3Admin
+	bucket, arn := d.Get("bucket").(string), d.Get("arn").(string)
+
+	target, err := getBucketReplicationTarget(ctx, admin, bucket, arn)
+	if err != nil {
+		if isNotFoundError(err) {
+			log.Printf("[WARN] Bucket %s of remote target %s not found, removing from state", bucket, arn)
+			d.SetId("")
+			return nil
+		}
+		return NewResourceError("error reading remote target", bucket, err)
+	}
+	if target == nil {
+		log.Printf("[WARN] Remote target %s of bucket %s not found, removing from state", arn, bucket)
+		d.SetId("")
+		return nil
+	}
+
+	pathComponents := strings.Split(target.TargetBucket, "/")
+
+	values := map[string]interface{}{
+		"target_bucket":       pathComponents[len(pathComponents)-1],
+		"path":                strings.Join(pathComponents[:len(pathComponents)-1], "/"),
+		"host":                target.Endpoint,
+		"secure":              target.Secure,
+		"path_style":          target.Path,
+		"region":              target.Region,
+		"synchronous":         target.ReplicationSync,
+		"disable_proxy":       target.DisableProxy,
+		"health_check_period": shortDur(target.HealthCheckDuration),
+		"bandwidth_limit":     flattenBucketReplicationTargetBandwidth(target.BandwidthLimit),
+	}
+	if target.Credentials != nil {
+		values["access_key"] = target.Credentials.AccessKey
+	}
+	for key, value := range values {
+		if err := d.Set(key, value); err != nil {
+			return NewResourceError(fmt.Sprintf("error setting %s", key), bucket, err)
+		}
+	}
+
+	return nil
+}
+
+func minioUpdateBucketReplicationTarget(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	targetConfig, err := BucketReplicationTargetConfig(d, meta)
+	if err != nil {
+		return NewResourceError("invalid replication target", d.Get("bucket").(string), err)
+	}
+
+	var updates []madmin.TargetUpdateType
+	for key, update := range map[string]madmin.TargetUpdateType{
+		"access_key":          madmin.CredentialsUpdateType,
+		"secret_key":          madmin.CredentialsUpdateType,
+		"synchronous":         madmin.SyncUpdateType,
+		"disable_proxy":       madmin.ProxyUpdateType,
+		"bandwidth_limit":     madmin.BandwidthLimitUpdateType,
+		"health_check_period": madmin.HealthCheckDurationUpdateType,
+		"path_style":          madmin.PathUpdateType,
+	} {
+		if d.HasChange(key) && !slices.Contains(updates, update) {
+			updates = append(updates, update)
+		}
+	}
+
+	if len(updates) > 0 {
+		target := bucketReplicationTarget(targetConfig)
+		target.Arn = d.Get("arn").(string)
+
+		log.Printf("[DEBUG] Updating remote target %s of bucket %s", target.Arn, targetConfig.MinioBucket)
+		if _, err := targetConfig.MinioAdmin.UpdateRemoteTarget(ctx, target, updates...); err != nil {
+			return NewResourceError("error updating remote target", targetConfig.MinioBucket, err)
+		}
+	}
+
+	return minioReadBucketReplicationTarget(ctx, d, meta)
+}
+
+func minioDeleteBucketReplicationTarget(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	admin := meta.(*S3MinioClient).S3Admin
+	bucket, arn := d.Get("bucket").(string), d.Get("arn").(string)
+
+	log.Printf("[DEBUG] Removing remote target %s of bucket %s", arn, bucket)
+	if err := admin.RemoveRemoteTarget(ctx, bucket, arn); err != nil {
+		if isNotFoundError(err) {
+			return nil
+		}
+		return NewResourceError("error removing remote target", bucket, err)
+	}
+
+	return nil
+}
+
+func minioImportBucketReplicationTarget(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	bucket, arn, ok := strings.Cut(d.Id(), "/")
+	if !ok || bucket == "" || arn == "" {
+		return nil, fmt.Errorf("unexpected format of ID (%q), expected <bucket>/<arn>", d.Id())
+	}
+
+	_ = d.Set("bucket", bucket)
+	_ = d.Set("arn", arn)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// bucketReplicationTarget builds the remote target registered on the bucket
+func bucketReplicationTarget(targetConfig *S3MinioBucketReplicationTarget) *madmin.BucketTarget {
+	target := targetConfig.Target
+
+	targetBucket := target.Bucket
+	if target.Path != "" {
+		targetBucket = path.Clean("./" + target.Path + "/" + targetBucket)
+	}
+
+	return &madmin.BucketTarget{
+		SourceBucket:        targetConfig.MinioBucket,
+		TargetBucket:        targetBucket,
+		Secure:              target.Secure,
+		Credentials:         &madmin.Credentials{AccessKey: target.AccessKey, SecretKey: target.SecretKey},
+		Endpoint:            target.Host,
+		Path:                target.PathStyle.String(),
+		API:                 "s3v4",
+		Type:                madmin.ReplicationService,
+		Region:              target.Region,
+		BandwidthLimit:      target.BandwidthLimit,
+		ReplicationSync:     target.Syncronous,
+		DisableProxy:        target.DisableProxy,
+		HealthCheckDuration: target.HealthCheckPeriod,
+	}
+}
+
+// getBucketReplicationTarget returns the replication target of the bucket with the given ARN, nil if there is none
+func getBucketReplicationTarget(ctx context.Context, admin *madmin.AdminClient, bucket, arn string) (*madmin.BucketTarget, error) {
+	targets, err := admin.ListRemoteTargets(ctx, bucket, string(madmin.ReplicationService))
+	if err != nil {
+		return nil, err
+	}
+	for i := range targets {
+		if targets[i].Arn == arn {
+			return &targets[i], nil
+		}
+	}
+	return nil, nil
+}
+
+func bucketReplicationTargetID(bucket, arn string) string {
+	return fmt.Sprintf("%s/%s", bucket, arn)
+}
+
+// validateBucketReplicationTargetBandwidth checks the bandwidth limit is unlimited (0) or at least the 100MB/s
+// the server accepts
+func validateBucketReplicationTargetBandwidth(v interface{}, k string) (ws []string, errors []error) {
+	limit := strings.TrimSpace(v.(string))
+	if strings.HasPrefix(limit, "-") {
+		errors = append(errors, fmt.Errorf("%q must not be negative, use 0 for an unlimited bandwidth", k))
+		return
+	}
+
+	value, err := humanize.ParseBytes(limit)
+	if err != nil {
+		errors = append(errors, fmt.Errorf("%q must be a number of bytes per second, it may use suffixes (k, m, g, ...): %s", k, err))
+		return
+	}
+	if value != 0 && value < uint64(100*humanize.BigMByte.Int64()) {
+		errors = append(errors, fmt.Errorf("%q must be at least 100MB when set", k))
+	}
+	return
+}
+
+// flattenBucketReplicationTargetBandwidth formats the bandwidth limit of a target. The server accepts negative
+// limits, they are kept as is so that the drift is planned instead of being read as a huge limit.
+func flattenBucketReplicationTargetBandwidth(limit int64) string {
+	if limit < 0 {
+		return fmt.Sprintf("%d B", limit)
+	}
+	return humanize.Bytes(uint64(limit))
+}
@@ -0,0 +1,254 @@
+package minio
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccS3BucketReplicationTarget_basic(t *testing.T) {
+	bucketName := acctest.RandomWithPrefix("tf-acc-test-a")
+	secondBucketName := acctest.RandomWithPrefix("tf-acc-test-b")
+	username := acctest.RandomWithPrefix("tf-acc-usr")
+	resourceName := "minio_s3_bucket_replication_target.replication_in_b"
+
+	primaryMinioEndpoint := os.Getenv("MINIO_ENDPOINT")
+	secondaryMinioEndpoint := os.Getenv("SECOND_MINIO_ENDPOINT")
+
+	config := func(target string) string {
+		return testAccBucketReplicationConfigLocals(primaryMinioEndpoint, secondaryMinioEndpoint) +
+			testAccBucketReplicationConfigBucket("my_bucket_in_a", "minio", bucketName) +
+			testAccBucketReplicationConfigBucket("my_bucket_in_b", "secondminio", secondBucketName) +
+			testAccMinioILMPolicyTransitionServiceAccount(username) +
+			target
+	}
+
+	// Test in parallel cannot work as remote target endpoint would conflict
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckMinioS3BucketReplicationTargetDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config(testAccBucketReplicationTargetConfig(`region = "eu-west-1"`)),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMinioS3BucketReplicationTargetExists(resourceName),
+					resource.TestMatchResourceAttr(resourceName, "arn", regexp.MustCompile(`^arn:minio:replication:eu-west-1:`)),
+					resource.TestCheckResourceAttr(resourceName, "target_bucket", secondBucketName),
+					resource.TestCheckResourceAttr(resourceName, "host", secondaryMinioEndpoint),
+					resource.TestCheckResourceAttr(resourceName, "path", ""),
+					resource.TestCheckResourceAttr(resourceName, "path_style", "auto"),
+					resource.TestCheckResourceAttr(resourceName, "health_check_period", "30s"),
+					resource.TestCheckResourceAttr(resourceName, "bandwidth_limit", "0 B"),
+					testAccCheckMinioS3BucketReplicationTargetBandwidth(resourceName, 0),
+					resource.TestCheckResourceAttr(resourceName, "synchronous", "false"),
+				),
+			},
+			{
+				Config: config(testAccBucketReplicationTargetConfig(`region = "eu-west-1"
+  bandwidth_limit = "100M"
+  health_check_period = "1m"
+  synchronous = true
+  disable_proxy = true`)),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMinioS3BucketReplicationTargetExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "bandwidth_limit", "100 MB"),
+					testAccCheckMinioS3BucketReplicationTargetBandwidth(resourceName, 100000000),
+					resource.TestCheckResourceAttr(resourceName, "health_check_period", "1m"),
+					resource.TestCheckResourceAttr(resourceName, "synchronous", "true"),
+					resource.TestCheckResourceAttr(resourceName, "disable_proxy", "true"),
+				),
+			},
+			{
+				Config: config(testAccBucketReplicationTargetConfig(`region = "eu-west-1"
+  bandwidth_limit = "0"`)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(resourceName, "bandwidth_limit", "0 B"),
+					testAccCheckMinioS3BucketReplicationTargetBandwidth(resourceName, 0),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateVerifyIgnore: []string{
+					"secret_key",
+				},
+			},
+		},
+	})
+}
+
+func TestAccS3BucketReplicationTarget_replicationRule(t *testing.T) {
+	bucketName := acctest.RandomWithPrefix("tf-acc-test-a")
+	secondBucketName := acctest.RandomWithPrefix("tf-acc-test-b")
+	username := acctest.RandomWithPrefix("tf-acc-usr")
+	resourceName := "minio_s3_bucket_replication_target.replication_in_b"
+
+	primaryMinioEndpoint := os.Getenv("MINIO_ENDPOINT")
+	secondaryMinioEndpoint := os.Getenv("SECOND_MINIO_ENDPOINT")
+
+	config := func(deleteReplication bool) string {
+		return testAccBucketReplicationConfigLocals(primaryMinioEndpoint, secondaryMinioEndpoint) +
+			testAccBucketReplicationConfigBucket("my_bucket_in_a", "minio", bucketName) +
+			testAccBucketReplicationConfigBucket("my_bucket_in_b", "secondminio", secondBucketName) +
+			testAccMinioILMPolicyTransitionServiceAccount(username) +
+			testAccBucketReplicationTargetConfig("") +
+			fmt.Sprintf(`
+resource "minio_s3_bucket_replication" "replication_in_b" {
+  bucket = minio_s3_bucket.my_bucket_in_a.bucket
+
+  rule {
+    arn                = minio_s3_bucket_replication_target.replication_in_b.arn
+    delete_replication = %t
+  }
+}
+`, deleteReplication)
+	}
+
+	// Test in parallel cannot work as remote target endpoint would conflict
+	resource.Test(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckMinioS3BucketReplicationTargetDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config(true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMinioS3BucketReplicationTargetExists(resourceName),
+					resource.TestCheckResourceAttrPair("minio_s3_bucket_replication.replication_in_b", "rule.0.arn", resourceName, "arn"),
+					resource.TestCheckResourceAttr("minio_s3_bucket_replication.replication_in_b", "rule.0.target.#", "0"),
+					resource.TestCheckResourceAttr("minio_s3_bucket_replication.replication_in_b", "rule.0.delete_replication", "true"),
+				),
+			},
+			{
+				Config: config(false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMinioS3BucketReplicationTargetExists(resourceName),
+					resource.TestCheckResourceAttrPair("minio_s3_bucket_replication.replication_in_b", "rule.0.arn", resourceName, "arn"),
+					resource.TestCheckResourceAttr("minio_s3_bucket_replication.replication_in_b", "rule.0.delete_replication", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestValidateBucketReplicationTargetBandwidth(t *testing.T) {
+	for _, limit := range []string{"0", "100M", "100MB", "1G", "104857600"} {
+		if _, errs := validateBucketReplicationTargetBandwidth(limit, "bandwidth_limit"); len(errs) != 0 {
+			t.Errorf("%q should be a valid bandwidth limit: %v", limit, errs)
+		}
+	}
+
+	for _, limit := range []string{"-1", " -100M", "10M", "1", "fast", ""} {
+		if _, errs := validateBucketReplicationTargetBandwidth(limit, "bandwidth_limit"); len(errs) == 0 {
+			t.Errorf("%q should be an invalid bandwidth limit", limit)
+		}
+	}
+}
+
+func TestFlattenBucketReplicationTargetBandwidth(t *testing.T) {
+	for limit, expected := range map[int64]string{
+		0:         "0 B",
+		100000000: "100 MB",
+		-5:        "-5 B",
+	} {
+		if actual := flattenBucketReplicationTargetBandwidth(limit); actual != expected {
+			t.Errorf("bandwidth limit %d: expected %q, got %q", limit, expected, actual)
+		}
+	}
+}
+
+func testAccBucketReplicationTargetConfig(extra string) string {
+	return fmt.Sprintf(`
+resource "minio_s3_bucket_replication_target" "replication_in_b" {
+  bucket        = minio_s3_bucket.my_bucket_in_a.bucket
+  target_bucket = minio_s3_bucket.my_bucket_in_b.bucket
+  host          = local.second_minio_host
+  secure        = false
+  access_key    = minio_iam_service_account.remote_storage.access_key
+  secret_key    = minio_iam_service_account.remote_storage.secret_key
+  %s
+
+  depends_on = [
+    minio_s3_bucket_versioning.my_bucket_in_a,
+    minio_s3_bucket_versioning.my_bucket_in_b,
+  ]
+}
+`, extra)
+}
+
+func testAccCheckMinioS3BucketReplicationTargetExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("no ID is set")
+		}
+
+		admin := testAccProvider.Meta().(*S3MinioClient).S3Admin
+		target, err := getBucketReplicationTarget(context.Background(), admin, rs.Primary.Attributes["bucket"], rs.Primary.Attributes["arn"])
+		if err != nil {
+			return fmt.Errorf("error listing remote targets: %v", err)
+		}
+		if target == nil {
+			return fmt.Errorf("remote target %s not found", rs.Primary.Attributes["arn"])
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckMinioS3BucketReplicationTargetBandwidth(n string, expected int64) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+
+		admin := testAccProvider.Meta().(*S3MinioClient).S3Admin
+		target, err := getBucketReplicationTarget(context.Background(), admin, rs.Primary.Attributes["bucket"], rs.Primary.Attributes["arn"])
+		if err != nil {
+			return fmt.Errorf("error listing remote targets: %v", err)
+		}
+		if target == nil {
+			return fmt.Errorf("remote target %s not found", rs.Primary.Attributes["arn"])
+		}
+		if target.BandwidthLimit != expected {
+			return fmt.Errorf("expected bandwidth limit %d, got %d", expected, target.BandwidthLimit)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckMinioS3BucketReplicationTargetDestroy(s *terraform.State) error {
+	admin := testAccProvider.Meta().(*S3MinioClient).S3Admin
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "minio_s3_bucket_replication_target" {
+			continue
+		}
+
+		target, err := getBucketReplicationTarget(context.Background(), admin, rs.Primary.Attributes["bucket"], rs.Primary.Attributes["arn"])
+		if err != nil {
+			if isNotFoundError(err) {
+				continue
+			}
+			return err
+		}
+		if target != nil {
+			return fmt.Errorf("remote target %s still exists", rs.Primary.Attributes["arn"])
+		}
+	}
+
+	return nil
+}
@@ -4,11 +4,13 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/dustin/go-humanize"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
@@ -373,6 +375,66 @@ resource "minio_s3_bucket_replication" "replication_in_a" {
     ]
 }`
 
+func TestValidateBucketReplicationPriorities(t *testing.T) {
+	rules := []S3MinioBucketReplicationRule{
+		{Id: "first", Priority: 2},
+		{Priority: 1},
+		{Id: "third", Priority: 2},
+		// generated from the index, sent as 1
+		{Priority: -1},
+	}
+
+	errs := validateBucketReplicationPriorities(rules)
+	if len(errs) != 2 {
+		t.Fatalf("expected 2 errors, got %v", errs)
+	}
+	if !strings.Contains(errs[0].Detail, "rule[0] (first) and rule[2] (third)") {
+		t.Fatalf("error should name both conflicting rules, got %q", errs[0].Detail)
+	}
+	if !errs[0].AttributePath.Equals(cty.GetAttrPath("rule").IndexInt(2).GetAttr("priority")) {
+		t.Fatalf("error should point at the priority of rule 2, got %#v", errs[0].AttributePath)
+	}
+	if !strings.Contains(errs[1].Detail, "rule[1] and rule[3]") {
+		t.Fatalf("error should name both conflicting rules, got %q", errs[1].Detail)
+	}
+
+	if errs := validateBucketReplicationPriorities([]S3MinioBucketReplicationRule{{Priority: 3}, {Priority: -2}, {Priority: -1}}); errs.HasError() {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+}
+
+func TestGetBucketReplicationConfig_externalTarget(t *testing.T) {
+	managedTarget := map[string]interface{}{
+		"bucket":     "target",
+		"host":       "minio:9000",
+		"secure":     true,
+		"access_key": "access",
+		"secret_key": "secret",
+	}
+	rules := []interface{}{
+		map[string]interface{}{"arn": "arn:minio:replication::managed:target", "tags": map[string]interface{}{}, "target": []interface{}{managedTarget}},
+		map[string]interface{}{"arn": "arn:minio:replication::external:target", "tags": map[string]interface{}{}, "target": []interface{}{}},
+	}
+
+	result, errs := getBucketReplicationConfig(rules)
+	if errs.HasError() {
+		t.Fatalf("unexpected errors: %v", errs)
+	}
+	if result[0].ExternalTarget || !result[1].ExternalTarget {
+		t.Fatalf("only the rule without target should use an external target, got %v", result)
+	}
+	if managed := bucketReplicationManagedTargets(rules); !reflect.DeepEqual(managed, []string{"arn:minio:replication::managed:target"}) {
+		t.Fatalf("expected only the target of the first rule to be managed, got %v", managed)
+	}
+
+	_, errs = getBucketReplicationConfig([]interface{}{
+		map[string]interface{}{"tags": map[string]interface{}{}, "target": []interface{}{}},
+	})
+	if !errs.HasError() || !strings.Contains(errs[0].Summary, "requires either a target or the arn of an existing remote target") {
+		t.Fatalf("expected an error for a rule without target nor arn, got %v", errs)
+	}
+}
+
 func TestAccS3BucketReplication_oneway_simple(t *testing.T) {
 	bucketName := acctest.RandomWithPrefix("tf-acc-test-a")
 	secondBucketName := acctest.RandomWithPrefix("tf-acc-test-b")
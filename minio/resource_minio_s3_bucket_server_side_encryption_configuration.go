@@ -23,9 +23,10 @@ func resourceMinioBucketServerSideEncryption() *schema.Resource {
 
 		Schema: map[string]*schema.Schema{
 			"bucket": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateBucketName,
 			},
 			"encryption_type": {
 				Type:         schema.TypeString,
@@ -48,6 +49,10 @@ func minioPutBucketServerSideEncryption(ctx context.Context, d *schema.ResourceD
 		return nil
 	}
 
+	if diags := minioCheckKMSReachable(ctx, meta, bucketEncryptionConfig.MinioBucket); diags.HasError() {
+		return diags
+	}
+
 	log.Printf("[DEBUG] S3 bucket: %s, put encryption configuration: %v", bucketEncryptionConfig.MinioBucket, encryptionConfig)
 
 	err := bucketEncryptionConfig.MinioClient.SetBucketEncryption(
@@ -0,0 +1,232 @@
+package minio
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/minio/minio-go/v7"
+)
+
+// minioBucketSetting maps a bucket setting name to the minio-go calls managing it
+type minioBucketSetting struct {
+	// normalize validates a configured value and returns it in the form get reports it
+	normalize func(value string) (string, error)
+	// get returns the current value, or an empty string when the setting is not set on the bucket
+	get func(ctx context.Context, client *minio.Client, bucket string) (string, error)
+	set func(ctx context.Context, client *minio.Client, bucket string, value string) error
+	// remove resets the setting to the MinIO default
+	remove func(ctx context.Context, client *minio.Client, bucket string) error
+}
+
+// minioBucketSettings lists the settings minio_s3_bucket_setting can manage. Settings are added here
+// until they are modelled by a dedicated resource.
+var minioBucketSettings = map[string]minioBucketSetting{
+	"object_lock_default_retention": {
+		normalize: func(value string) (string, error) {
+			mode, validity, unit, err := parseBucketDefaultRetention(value)
+			if err != nil {
+				return "", err
+			}
+			return formatBucketDefaultRetention(mode, validity, unit), nil
+		},
+		get: func(ctx context.Context, client *minio.Client, bucket string) (string, error) {
+			mode, validity, unit, err := client.GetBucketObjectLockConfig(ctx, bucket)
+			if err != nil {
+				if minio.ToErrorResponse(err).Code == "ObjectLockConfigurationNotFoundError" {
+					return "", nil
+				}
+				return "", err
+			}
+			if mode == nil || validity == nil || unit == nil {
+				return "", nil
+			}
+			return formatBucketDefaultRetention(*mode, *validity, *unit), nil
+		},
+		set: func(ctx context.Context, client *minio.Client, bucket string, value string) error {
+			mode, validity, unit, err := parseBucketDefaultRetention(value)
+			if err != nil {
+				return err
+			}
+			return client.SetBucketObjectLockConfig(ctx, bucket, &mode, &validity, &unit)
+		},
+		remove: func(ctx context.Context, client *minio.Client, bucket string) error {
+			return client.SetBucketObjectLockConfig(ctx, bucket, nil, nil, nil)
+		},
+	},
+}
+
+func resourceMinioBucketSetting() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: minioPutBucketSetting,
+		ReadContext:   minioReadBucketSetting,
+		UpdateContext: minioPutBucketSetting,
+		DeleteContext: minioDeleteBucketSetting,
+		Importer: &schema.ResourceImporter{
+			StateContext: minioImportBucketSetting,
+		},
+
+		Description: "`minio_s3_bucket_setting` manages a single bucket setting that has no dedicated resource yet, such as the object lock default retention. " +
+			"Destroying the resource resets the setting to the MinIO default.",
+
+		Schema: map[string]*schema.Schema{
+			"bucket": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateBucketName,
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice(minioBucketSettingNames(), false),
+				Description:  fmt.Sprintf("Name of the setting, one of: %s", strings.Join(minioBucketSettingNames(), ", ")),
+			},
+			"value": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Value of the setting. `object_lock_default_retention` takes a retention mode and a period, e.g. `GOVERNANCE 30d` or `COMPLIANCE 1y`, and requires a bucket created with object locking",
+			},
+		},
+	}
+}
+
+func minioPutBucketSetting(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*S3MinioClient).S3Client
+	bucket := d.Get("bucket").(string)
+	name := d.Get("name").(string)
+	value := d.Get("value").(string)
+
+	setting := minioBucketSettings[name]
+	if _, err := setting.normalize(value); err != nil {
+		return NewResourceError(fmt.Sprintf("invalid value for bucket setting %s", name), bucket, err)
+	}
+
+	log.Printf("[DEBUG] S3 bucket: %s, setting %s to %q", bucket, name, value)
+
+	if err := setting.set(ctx, client, bucket, value); err != nil {
+		return NewResourceError(fmt.Sprintf("error setting bucket setting %s", name), bucket, err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/%s", bucket, name))
+
+	return minioReadBucketSetting(ctx, d, meta)
+}
+
+func minioReadBucketSetting(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*S3MinioClient).S3Client
+	bucket := d.Get("bucket").(string)
+	name := d.Get("name").(string)
+
+	log.Printf("[DEBUG] S3 bucket: %s, reading setting %s", bucket, name)
+
+	value, err := minioBucketSettings[name].get(ctx, client, bucket)
+	if err != nil {
+		if minio.ToErrorResponse(err).Code == "NoSuchBucket" {
+			log.Printf("[WARN] Bucket %s not found, removing setting %s from state", bucket, name)
+			d.SetId("")
+			return nil
+		}
+		return NewResourceError(fmt.Sprintf("error reading bucket setting %s", name), bucket, err)
+	}
+
+	if value == "" {
+		log.Printf("[WARN] Setting %s is not set on bucket %s, removing it from state", name, bucket)
+		d.SetId("")
+		return nil
+	}
+
+	// keep the configured spelling when it means the same as what MinIO returned
+	if current, err := minioBucketSettings[name].normalize(d.Get("value").(string)); err == nil && current == value {
+		value = d.Get("value").(string)
+	}
+
+	if err := d.Set("value", value); err != nil {
+		return NewResourceError(fmt.Sprintf("error reading bucket setting %s", name), bucket, err)
+	}
+
+	return nil
+}
+
+func minioDeleteBucketSetting(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*S3MinioClient).S3Client
+	bucket := d.Get("bucket").(string)
+	name := d.Get("name").(string)
+
+	log.Printf("[DEBUG] S3 bucket: %s, removing setting %s", bucket, name)
+
+	if err := minioBucketSettings[name].remove(ctx, client, bucket); err != nil {
+		if minio.ToErrorResponse(err).Code == "NoSuchBucket" {
+			return nil
+		}
+		return NewResourceError(fmt.Sprintf("error removing bucket setting %s", name), bucket, err)
+	}
+
+	return nil
+}
+
+func minioImportBucketSetting(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	bucket, name, ok := strings.Cut(d.Id(), "/")
+	if !ok || bucket == "" {
+		return nil, fmt.Errorf("unexpected format of ID (%s), expected <bucket>/<setting name>", d.Id())
+	}
+	if _, ok := minioBucketSettings[name]; !ok {
+		return nil, fmt.Errorf("unknown bucket setting %q, expected one of: %s", name, strings.Join(minioBucketSettingNames(), ", "))
+	}
+
+	_ = d.Set("bucket", bucket)
+	_ = d.Set("name", name)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+func minioBucketSettingNames() []string {
+	names := make([]string, 0, len(minioBucketSettings))
+	for name := range minioBucketSettings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// parseBucketDefaultRetention parses a default retention such as "GOVERNANCE 30d" or "COMPLIANCE 1y"
+func parseBucketDefaultRetention(value string) (minio.RetentionMode, uint, minio.ValidityUnit, error) {
+	fields := strings.Fields(value)
+	if len(fields) != 2 {
+		return "", 0, "", fmt.Errorf("default retention must be a mode and a period, e.g. \"GOVERNANCE 30d\", got %q", value)
+	}
+
+	mode := minio.RetentionMode(strings.ToUpper(fields[0]))
+	if !mode.IsValid() {
+		return "", 0, "", fmt.Errorf("retention mode must be GOVERNANCE or COMPLIANCE, got %q", fields[0])
+	}
+
+	var validity uint
+	var suffix string
+	if n, err := fmt.Sscanf(fields[1], "%d%s", &validity, &suffix); n != 2 || err != nil || validity == 0 {
+		return "", 0, "", fmt.Errorf("retention period must be a positive number of days (30d) or years (1y), got %q", fields[1])
+	}
+
+	switch suffix {
+	case "d":
+		return mode, validity, minio.Days, nil
+	case "y":
+		return mode, validity, minio.Years, nil
+	}
+
+	return "", 0, "", fmt.Errorf("retention period must be a positive number of days (30d) or years (1y), got %q", fields[1])
+}
+
+func formatBucketDefaultRetention(mode minio.RetentionMode, validity uint, unit minio.ValidityUnit) string {
+	suffix := "d"
+	if unit == minio.Years {
+		suffix = "y"
+	}
+	return fmt.Sprintf("%s %d%s", mode, validity, suffix)
+}
@@ -0,0 +1,101 @@
+package minio
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/minio/minio-go/v7"
+)
+
+func TestAccS3BucketSetting_objectLockDefaultRetention(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "minio_s3_bucket_setting.retention"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckMinioS3BucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBucketSettingConfig(name, "object_lock_default_retention", "governance 30d"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckBucketDefaultRetention(name, minio.Governance, 30, minio.Days),
+					resource.TestCheckResourceAttr(resourceName, "id", name+"/object_lock_default_retention"),
+					resource.TestCheckResourceAttr(resourceName, "value", "governance 30d"),
+				),
+			},
+			{
+				Config: testAccBucketSettingConfig(name, "object_lock_default_retention", "COMPLIANCE 1y"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckBucketDefaultRetention(name, minio.Compliance, 1, minio.Years),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestParseBucketDefaultRetention(t *testing.T) {
+	valid := map[string]string{
+		"GOVERNANCE 30d":  "GOVERNANCE 30d",
+		"compliance 1y":   "COMPLIANCE 1y",
+		" GOVERNANCE  7d": "GOVERNANCE 7d",
+	}
+	for value, expected := range valid {
+		normalized, err := minioBucketSettings["object_lock_default_retention"].normalize(value)
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %s", value, err)
+		}
+		if normalized != expected {
+			t.Fatalf("%q: expected %q, got %q", value, expected, normalized)
+		}
+	}
+
+	for _, value := range []string{"", "GOVERNANCE", "LEGAL 30d", "GOVERNANCE 30", "GOVERNANCE 0d", "GOVERNANCE 30w", "GOVERNANCE 30d extra"} {
+		if _, _, _, err := parseBucketDefaultRetention(value); err == nil {
+			t.Fatalf("%q: expected an error", value)
+		}
+	}
+}
+
+func testAccCheckBucketDefaultRetention(bucket string, mode minio.RetentionMode, validity uint, unit minio.ValidityUnit) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*S3MinioClient).S3Client
+
+		gotMode, gotValidity, gotUnit, err := client.GetBucketObjectLockConfig(context.Background(), bucket)
+		if err != nil {
+			return fmt.Errorf("error getting object lock configuration of %s: %w", bucket, err)
+		}
+		if gotMode == nil || gotValidity == nil || gotUnit == nil {
+			return fmt.Errorf("bucket %s has no default retention", bucket)
+		}
+		if *gotMode != mode || *gotValidity != validity || *gotUnit != unit {
+			return fmt.Errorf("bucket %s default retention is %s %d %s, expected %s %d %s", bucket, *gotMode, *gotValidity, *gotUnit, mode, validity, unit)
+		}
+
+		return nil
+	}
+}
+
+func testAccBucketSettingConfig(bucket string, name string, value string) string {
+	return fmt.Sprintf(`
+resource "minio_s3_bucket" "bucket" {
+  bucket         = %[1]q
+  object_locking = true
+}
+
+resource "minio_s3_bucket_setting" "retention" {
+  bucket = minio_s3_bucket.bucket.bucket
+  name   = %[2]q
+  value  = %[3]q
+}
+`, bucket, name, value)
+}
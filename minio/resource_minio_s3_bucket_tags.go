@@ -0,0 +1,169 @@
+package minio
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"unicode/utf8"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/tags"
+)
+
+const (
+	bucketTagsMaxCount       = 50
+	bucketTagsMaxKeyLength   = 128
+	bucketTagsMaxValueLength = 256
+)
+
+func resourceMinioBucketTags() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: minioPutBucketTags,
+		ReadContext:   minioReadBucketTags,
+		UpdateContext: minioPutBucketTags,
+		DeleteContext: minioDeleteBucketTags,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		CustomizeDiff: minioBucketTagsAllDiff,
+
+		Schema: map[string]*schema.Schema{
+			"bucket": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateBucketName,
+			},
+			"tags": {
+				Type:             schema.TypeMap,
+				Required:         true,
+				Elem:             &schema.Schema{Type: schema.TypeString},
+				ValidateDiagFunc: validateBucketTags,
+				Description:      "Tags to set on the bucket, replacing any tags it already has. Merged with the `default_tags` of the provider",
+			},
+			"tags_all": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Tags of the bucket, including the `default_tags` of the provider",
+			},
+		},
+	}
+}
+
+func minioPutBucketTags(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	bucketTagsConfig := BucketTagsConfig(d, meta)
+
+	log.Printf("[DEBUG] S3 bucket: %s, put tags: %v", bucketTagsConfig.MinioBucket, bucketTagsConfig.Tags)
+
+	bucketTags, err := tags.NewTags(bucketTagsConfig.Tags, false)
+	if err != nil {
+		return NewResourceError("invalid bucket tags", bucketTagsConfig.MinioBucket, err)
+	}
+
+	if err := bucketTagsConfig.MinioClient.SetBucketTagging(ctx, bucketTagsConfig.MinioBucket, bucketTags); err != nil {
+		return NewResourceError("error putting bucket tags", bucketTagsConfig.MinioBucket, err)
+	}
+
+	d.SetId(bucketTagsConfig.MinioBucket)
+
+	return minioReadBucketTags(ctx, d, meta)
+}
+
+func minioReadBucketTags(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	bucketTagsConfig := BucketTagsConfig(d, meta)
+
+	log.Printf("[DEBUG] S3 bucket tags, read for bucket: %s", d.Id())
+
+	bucketTags, err := bucketTagsConfig.MinioClient.GetBucketTagging(ctx, d.Id())
+	if err != nil {
+		switch minio.ToErrorResponse(err).Code {
+		case "NoSuchBucket":
+			log.Printf("[WARN] Bucket %s not found, removing tags from state", d.Id())
+			d.SetId("")
+			return nil
+		case "NoSuchTagSet":
+			bucketTags, _ = tags.NewTags(nil, false)
+		default:
+			return NewResourceError("failed to load bucket tags", d.Id(), err)
+		}
+	}
+
+	if err := d.Set("bucket", d.Id()); err != nil {
+		return diag.FromErr(err)
+	}
+
+	defaultTags := meta.(*S3MinioClient).DefaultTags
+	if err := d.Set("tags", withoutDefaultTags(defaultTags, bucketTags.ToMap(), d.Get("tags").(map[string]interface{}))); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting bucket tags: %w", err))
+	}
+	if err := d.Set("tags_all", bucketTags.ToMap()); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting bucket tags: %w", err))
+	}
+
+	return nil
+}
+
+// minioBucketTagsAllDiff plans tags_all from the configured tags and the default tags of the provider
+func minioBucketTagsAllDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("tags") {
+		return d.SetNewComputed("tags_all")
+	}
+
+	tagsAll := mergeDefaultTags(meta.(*S3MinioClient).DefaultTags, d.Get("tags").(map[string]interface{}))
+	if len(tagsAll) > bucketTagsMaxCount {
+		return fmt.Errorf("a bucket can have at most %d tags including the default tags of the provider, got %d", bucketTagsMaxCount, len(tagsAll))
+	}
+
+	return d.SetNew("tags_all", tagsAll)
+}
+
+func minioDeleteBucketTags(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	bucketTagsConfig := BucketTagsConfig(d, meta)
+
+	log.Printf("[DEBUG] S3 bucket: %s, removing tags", d.Id())
+
+	if err := bucketTagsConfig.MinioClient.RemoveBucketTagging(ctx, d.Id()); err != nil {
+		if minio.ToErrorResponse(err).Code == "NoSuchBucket" {
+			return nil
+		}
+		return NewResourceError("error removing bucket tags", d.Id(), err)
+	}
+
+	return nil
+}
+
+func validateBucketTags(v interface{}, path cty.Path) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	bucketTags := v.(map[string]interface{})
+	if len(bucketTags) > bucketTagsMaxCount {
+		diags = append(diags, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       fmt.Sprintf("a bucket can have at most %d tags, got %d", bucketTagsMaxCount, len(bucketTags)),
+			AttributePath: path,
+		})
+	}
+
+	for key, value := range bucketTags {
+		if n := utf8.RuneCountInString(key); n == 0 || n > bucketTagsMaxKeyLength {
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       fmt.Sprintf("tag key %q must be between 1 and %d characters", key, bucketTagsMaxKeyLength),
+				AttributePath: path,
+			})
+		}
+		if n := utf8.RuneCountInString(value.(string)); n > bucketTagsMaxValueLength {
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       fmt.Sprintf("value of tag %q must be at most %d characters", key, bucketTagsMaxValueLength),
+				AttributePath: path,
+			})
+		}
+	}
+
+	return diags
+}
@@ -0,0 +1,194 @@
+package minio
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccS3BucketTags_basic(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "minio_s3_bucket_tags.bucket"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckMinioS3BucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBucketTagsConfig(name, `
+    team        = "storage"
+    cost-center = "1234"
+`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckBucketHasTags(resourceName, map[string]string{"team": "storage", "cost-center": "1234"}),
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "2"),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testAccBucketTagsConfig(name, `
+    team = "platform"
+`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckBucketHasTags(resourceName, map[string]string{"team": "platform"}),
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccS3BucketTags_defaultTags(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "minio_s3_bucket_tags.bucket"
+
+	// default tags are set on a provider of their own so they do not leak into the other tests
+	provider := newProvider()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheck(t) },
+		ProviderFactories: map[string]func() (*schema.Provider, error){
+			"minio": func() (*schema.Provider, error) { return provider, nil },
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBucketTagsDefaultTagsConfig(name, `
+    team = "storage"
+    env  = "test"
+`, `
+    team = "platform"
+`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckBucketHasTagsWithProvider(provider, resourceName, map[string]string{"team": "platform", "env": "test"}),
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "tags_all.%", "2"),
+					resource.TestCheckResourceAttr(resourceName, "tags_all.env", "test"),
+				),
+			},
+			{
+				Config: testAccBucketTagsDefaultTagsConfig(name, `
+    env = "prod"
+`, `
+    team = "platform"
+`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckBucketHasTagsWithProvider(provider, resourceName, map[string]string{"team": "platform", "env": "prod"}),
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "1"),
+					resource.TestCheckResourceAttr(resourceName, "tags_all.env", "prod"),
+				),
+			},
+		},
+	})
+}
+
+func TestDefaultTags(t *testing.T) {
+	defaultTags := map[string]string{"team": "storage", "env": "test"}
+
+	merged := mergeDefaultTags(defaultTags, map[string]interface{}{"team": "platform", "app": "web"})
+	expected := map[string]string{"team": "platform", "env": "test", "app": "web"}
+	if !reflect.DeepEqual(merged, expected) {
+		t.Fatalf("expected merged tags %v, got %v", expected, merged)
+	}
+
+	// a default tag is only reported on the resource when the resource sets it or its value differs
+	own := withoutDefaultTags(defaultTags, map[string]string{"team": "storage", "env": "prod", "app": "web"}, map[string]interface{}{"team": "storage"})
+	expected = map[string]string{"team": "storage", "env": "prod", "app": "web"}
+	if !reflect.DeepEqual(own, expected) {
+		t.Fatalf("expected resource tags %v, got %v", expected, own)
+	}
+
+	own = withoutDefaultTags(defaultTags, map[string]string{"team": "storage", "env": "test"}, map[string]interface{}{})
+	if len(own) != 0 {
+		t.Fatalf("expected default tags to be left out, got %v", own)
+	}
+}
+
+func TestValidateBucketTags(t *testing.T) {
+	if diags := validateBucketTags(map[string]interface{}{"team": "storage"}, nil); diags.HasError() {
+		t.Fatalf("valid tags rejected: %v", diags)
+	}
+
+	invalid := map[string]map[string]interface{}{
+		"empty key":     {"": "value"},
+		"long key":      {strings.Repeat("k", 129): "value"},
+		"long value":    {"team": strings.Repeat("v", 257)},
+		"too many tags": {},
+	}
+	for i := 0; i <= bucketTagsMaxCount; i++ {
+		invalid["too many tags"][fmt.Sprintf("key%d", i)] = "value"
+	}
+
+	for name, bucketTags := range invalid {
+		if diags := validateBucketTags(bucketTags, nil); !diags.HasError() {
+			t.Fatalf("%s: expected validation error", name)
+		}
+	}
+}
+
+func testAccCheckBucketHasTags(n string, expected map[string]string) resource.TestCheckFunc {
+	return testAccCheckBucketHasTagsWithProvider(testAccProvider, n, expected)
+}
+
+func testAccCheckBucketHasTagsWithProvider(provider *schema.Provider, n string, expected map[string]string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("no ID is set")
+		}
+
+		minioC := provider.Meta().(*S3MinioClient).S3Client
+		bucketTags, err := minioC.GetBucketTagging(context.Background(), rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("error getting bucket tags: %s", err)
+		}
+
+		actual := bucketTags.ToMap()
+		if len(actual) != len(expected) {
+			return fmt.Errorf("expected tags %v, got %v", expected, actual)
+		}
+		for key, value := range expected {
+			if actual[key] != value {
+				return fmt.Errorf("expected tags %v, got %v", expected, actual)
+			}
+		}
+
+		return nil
+	}
+}
+
+func testAccBucketTagsDefaultTagsConfig(bucketName, defaultTags, tags string) string {
+	return fmt.Sprintf(`
+provider "minio" {
+  default_tags = {%s  }
+}
+`, defaultTags) + testAccBucketTagsConfig(bucketName, tags)
+}
+
+func testAccBucketTagsConfig(bucketName, tags string) string {
+	return fmt.Sprintf(`
+resource "minio_s3_bucket" "bucket" {
+  bucket = %q
+}
+
+resource "minio_s3_bucket_tags" "bucket" {
+  bucket = minio_s3_bucket.bucket.bucket
+  tags = {%s  }
+}
+`, bucketName, tags)
+}
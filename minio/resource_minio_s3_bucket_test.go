@@ -4,13 +4,16 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"regexp"
 	"strings"
 	"testing"
 
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/minio/minio-go/v7"
 )
@@ -76,6 +79,7 @@ func TestAccMinioS3Bucket_objectLocking(t *testing.T) {
 						resourceName, "acl", testAccBucketACL(acl)),
 					resource.TestCheckResourceAttr(
 						resourceName, "object_locking", "true"),
+					testAccCheckMinioS3BucketObjectLocking(resourceName, true),
 				),
 			},
 			{
@@ -275,6 +279,125 @@ func TestAccMinioS3Bucket_PrivateBucketUnreadable(t *testing.T) {
 	})
 }
 
+func testAccCheckMinioS3BucketObjectLocking(n string, enabled bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+
+		minioC := testAccProvider.Meta().(*S3MinioClient).S3Client
+		actual, err := minioBucketObjectLockingEnabled(context.Background(), minioC, rs.Primary.ID)
+		if err != nil {
+			return fmt.Errorf("error reading object lock configuration of bucket %s: %s", rs.Primary.ID, err)
+		}
+		if actual != enabled {
+			return fmt.Errorf("bucket %s object locking is %t, expected %t", rs.Primary.ID, actual, enabled)
+		}
+
+		return nil
+	}
+}
+
+func TestMinioApplyBucketFeatures_failure(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceMinioBucket().Schema, map[string]interface{}{
+		"bucket": "my-bucket",
+		"acl":    "public-nothing",
+		"quota":  1024,
+	})
+	d.SetId("my-bucket")
+
+	diags := minioApplyBucketFeatures(context.Background(), d, BucketConfig(d, &S3MinioClient{}))
+	if !diags.HasError() {
+		t.Fatalf("expected the ACL to fail")
+	}
+	if summary := diags[len(diags)-1].Summary; !strings.Contains(summary, "[ACL] Unable to configure bucket") {
+		t.Fatalf("diagnostics should name the failed feature, got %q", summary)
+	}
+	for _, diag := range diags {
+		if !diag.AttributePath.Equals(cty.GetAttrPath("acl")) {
+			t.Fatalf("diagnostics should point at acl, got %#v", diag.AttributePath)
+		}
+	}
+
+	// neither the failed ACL nor the quota applied after it may be recorded as configured
+	if acl := d.Get("acl").(string); acl != "" {
+		t.Fatalf("acl should keep its previous value, got %q", acl)
+	}
+	if quota := d.Get("quota").(int); quota != 0 {
+		t.Fatalf("quota should keep its previous value, got %d", quota)
+	}
+}
+
+func TestMinioDeleteBucket_forceDestroyErrors(t *testing.T) {
+	cases := []struct {
+		name     string
+		versions func(w http.ResponseWriter)
+		expected string
+	}{
+		{
+			name: "listing",
+			versions: func(w http.ResponseWriter) {
+				w.WriteHeader(http.StatusForbidden)
+				_, _ = w.Write([]byte(`<Error><Code>AccessDenied</Code><Message>Access Denied.</Message></Error>`))
+			},
+			expected: "unable to list the objects of the bucket",
+		},
+		{
+			name: "removal",
+			versions: func(w http.ResponseWriter) {
+				_, _ = w.Write([]byte(`<ListVersionsResult xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Name>bucket</Name><IsTruncated>false</IsTruncated>` +
+					`<Version><Key>locked</Key><VersionId>v1</VersionId><IsLatest>true</IsLatest></Version></ListVersionsResult>`))
+			},
+			expected: "could not delete object locked",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				query := r.URL.Query()
+				switch {
+				case r.Method == http.MethodDelete:
+					w.WriteHeader(http.StatusConflict)
+					_, _ = w.Write([]byte(`<Error><Code>BucketNotEmpty</Code><Message>The bucket you tried to delete is not empty</Message></Error>`))
+				case query.Has("versions"):
+					tc.versions(w)
+				case query.Has("delete"):
+					_, _ = w.Write([]byte(`<DeleteResult xmlns="http://s3.amazonaws.com/doc/2006-03-01/">` +
+						`<Error><Key>locked</Key><VersionId>v1</VersionId><Code>AccessDenied</Code><Message>Object is WORM protected and cannot be overwritten</Message></Error></DeleteResult>`))
+				}
+			}))
+			defer server.Close()
+
+			config := NewConfig(schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+				"minio_server":   strings.TrimPrefix(server.URL, "http://"),
+				"minio_region":   "us-east-1",
+				"minio_user":     "access",
+				"minio_password": "secret",
+			}))
+			client, err := config.NewClient()
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			d := schema.TestResourceDataRaw(t, resourceMinioBucket().Schema, map[string]interface{}{
+				"bucket":        "bucket",
+				"force_destroy": true,
+			})
+			d.SetId("bucket")
+
+			diags := minioDeleteBucket(context.Background(), d, client)
+			if !diags.HasError() {
+				t.Fatalf("expected the bucket removal to fail")
+			}
+			if detail := diags[0].Summary + ": " + diags[0].Detail; !strings.Contains(detail, tc.expected) {
+				t.Fatalf("expected %q in the error, got %q", tc.expected, detail)
+			}
+		})
+	}
+}
+
 func TestMinioS3BucketName(t *testing.T) {
 	validDNSNames := []string{
 		"foobar",
@@ -299,6 +422,8 @@ func TestMinioS3BucketName(t *testing.T) {
 		".foo",
 		"bar.",
 		"foo_bar",
+		"-foo",
+		"foo-",
 		strings.Repeat("x", 64),
 	}
 
@@ -309,6 +434,67 @@ func TestMinioS3BucketName(t *testing.T) {
 	}
 }
 
+func TestValidateBucketName(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		valid bool
+	}{
+		{name: "my-bucket", valid: true},
+		{name: "my.bucket.01", valid: true},
+		{name: "abc", valid: true},
+		{name: strings.Repeat("b", 63), valid: true},
+		{name: ""},
+		{name: "ab"},
+		{name: strings.Repeat("b", 64)},
+		{name: "My-Bucket"},
+		{name: "my_bucket"},
+		{name: "my..bucket"},
+		{name: "10.0.0.1"},
+		{name: "-bucket"},
+		{name: "bucket-"},
+		{name: ".bucket"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			path := cty.GetAttrPath("bucket")
+			diags := validateBucketName(tc.name, path)
+			if diags.HasError() == tc.valid {
+				t.Fatalf("expected %q to be valid %t, got %v", tc.name, tc.valid, diags)
+			}
+			if !tc.valid && !diags[0].AttributePath.Equals(path) {
+				t.Errorf("expected the error on %#v, got %#v", path, diags[0].AttributePath)
+			}
+		})
+	}
+}
+
+func TestValidateBucketName_bucketScopedResources(t *testing.T) {
+	provider := Provider()
+	for _, tc := range []struct {
+		schema     map[string]*schema.Schema
+		attributes []string
+	}{
+		{provider.ResourcesMap["minio_s3_bucket"].Schema, []string{"bucket"}},
+		{provider.ResourcesMap["minio_ilm_policy"].Schema, []string{"bucket"}},
+		{provider.ResourcesMap["minio_s3_bucket_notification"].Schema, []string{"bucket"}},
+		{provider.ResourcesMap["minio_s3_bucket_policy"].Schema, []string{"bucket"}},
+		{provider.ResourcesMap["minio_s3_bucket_replication"].Schema, []string{"bucket"}},
+		{provider.ResourcesMap["minio_s3_bucket_replication"].Schema["rule"].Elem.(*schema.Resource).Schema["target"].Elem.(*schema.Resource).Schema, []string{"bucket"}},
+		{provider.ResourcesMap["minio_s3_bucket_replication_target"].Schema, []string{"bucket", "target_bucket"}},
+		{provider.ResourcesMap["minio_s3_bucket_server_side_encryption"].Schema, []string{"bucket"}},
+		{provider.ResourcesMap["minio_s3_bucket_setting"].Schema, []string{"bucket"}},
+		{provider.ResourcesMap["minio_s3_bucket_tags"].Schema, []string{"bucket"}},
+		{provider.ResourcesMap["minio_s3_bucket_versioning"].Schema, []string{"bucket"}},
+		{provider.ResourcesMap["minio_s3_object"].Schema, []string{"bucket_name"}},
+		{provider.DataSourcesMap["minio_s3_bucket_policy"].Schema, []string{"bucket"}},
+	} {
+		for _, attribute := range tc.attributes {
+			if diags := tc.schema[attribute].ValidateDiagFunc("Invalid_Bucket", cty.GetAttrPath(attribute)); !diags.HasError() {
+				t.Errorf("%s should be validated as a bucket name", attribute)
+			}
+		}
+	}
+}
+
 func testAccCheckMinioS3BucketDestroy(s *terraform.State) (err error) {
 
 	err = providerMinioS3BucketDestroy(testAccProvider.Meta().(*S3MinioClient).S3Client, s)
@@ -470,6 +656,45 @@ resource "minio_s3_bucket" "bucket" {
 `, randInt)
 }
 
+func TestAccMinioS3Bucket_region(t *testing.T) {
+	rInt := fmt.Sprintf("tf-test-bucket-%d", acctest.RandInt())
+	resourceName := "minio_s3_bucket.bucket"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckMinioS3BucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMinioS3BucketConfigRegion(rInt, "eu-west-1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMinioS3BucketExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "region", "eu-west-1"),
+					resource.TestCheckResourceAttr(resourceName, "object_locking", "true"),
+					testAccCheckMinioS3BucketObjectLocking(resourceName, true),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+				// MinIO reports its own region for imported buckets
+				ImportStateVerifyIgnore: []string{"force_destroy", "region"},
+			},
+		},
+	})
+}
+
+func testAccMinioS3BucketConfigRegion(randInt string, region string) string {
+	return fmt.Sprintf(`
+resource "minio_s3_bucket" "bucket" {
+  bucket         = "%s"
+  region         = "%s"
+  object_locking = true
+}
+`, randInt, region)
+}
+
 func testAccMinioS3BucketConfigObjectLockingEnabled(randInt string) string {
 	return fmt.Sprintf(`
 resource "minio_s3_bucket" "bucket" {
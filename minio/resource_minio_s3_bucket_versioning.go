@@ -11,20 +11,25 @@ import (
 	minio "github.com/minio/minio-go/v7"
 )
 
+// bucketVersioningMFADeleteDisabled is the MfaDelete status of buckets not requiring MFA, minio-go only defines Enabled
+const bucketVersioningMFADeleteDisabled = "Disabled"
+
 func resourceMinioBucketVersioning() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: minioPutBucketVersioning,
 		ReadContext:   minioReadBucketVersioning,
 		UpdateContext: minioPutBucketVersioning,
 		DeleteContext: minioDeleteBucketVersioning,
+		CustomizeDiff: minioBucketVersioningMFADiff,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 		Schema: map[string]*schema.Schema{
 			"bucket": {
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateBucketName,
 			},
 			"versioning_configuration": {
 				Type:     schema.TypeList,
@@ -48,6 +53,12 @@ func resourceMinioBucketVersioning() *schema.Resource {
 							Type:     schema.TypeBool,
 							Optional: true,
 						},
+						"mfa_delete": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringInSlice([]string{minio.Enabled, bucketVersioningMFADeleteDisabled}, false),
+							Description:  "Whether deleting object versions and changing the versioning state require MFA, either Enabled or Disabled. Only Disabled can be set, the MFA token needed to enable it cannot be sent",
+						},
 					},
 				},
 			},
@@ -80,6 +91,17 @@ func minioPutBucketVersioning(ctx context.Context, d *schema.ResourceData, meta
 	return nil
 }
 
+// minioBucketVersioningMFADiff rejects enabling MFA delete before the bucket is changed: minio-go cannot send the
+// MFA token S3 requires for it, and MinIO does not support MFA delete
+func minioBucketVersioningMFADiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	o, n := d.GetChange("versioning_configuration.0.mfa_delete")
+	if n.(string) == minio.Enabled && o.(string) != minio.Enabled {
+		return fmt.Errorf("versioning_configuration.0.mfa_delete cannot be Enabled, the MFA token it requires cannot be sent to the server")
+	}
+
+	return nil
+}
+
 func minioReadBucketVersioning(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	bucketVersioningConfig := BucketVersioningConfig(d, meta)
 
@@ -103,6 +125,14 @@ func minioReadBucketVersioning(ctx context.Context, d *schema.ResourceData, meta
 
 	config["exclude_folders"] = versioningConfig.ExcludeFolders
 
+	// servers report nothing unless MFA delete was ever enabled
+	config["mfa_delete"] = versioningConfig.MFADelete
+	if prior := getBucketVersioningConfig(d.Get("versioning_configuration").([]interface{})); prior != nil && versioningConfig.MFADelete == "" {
+		if prior.MFADelete == bucketVersioningMFADeleteDisabled {
+			config["mfa_delete"] = prior.MFADelete
+		}
+	}
+
 	if err := d.Set("bucket", d.Id()); err != nil {
 		return diag.FromErr(err)
 	}
@@ -136,6 +166,7 @@ func convertBucketVersioningConfig(c S3MinioBucketVersioningConfiguration) minio
 	conf := minio.BucketVersioningConfiguration{
 		Status:         c.Status,
 		ExcludeFolders: c.ExcludeFolders,
+		MFADelete:      c.MFADelete,
 	}
 
 	for _, prefix := range c.ExcludedPrefixes {
@@ -173,5 +204,9 @@ func getBucketVersioningConfig(v []interface{}) *S3MinioBucketVersioningConfigur
 		result.ExcludeFolders = excludeFolders
 	}
 
+	if mfaDelete, ok := tfMap["mfa_delete"].(string); ok {
+		result.MFADelete = mfaDelete
+	}
+
 	return result
 }
@@ -3,6 +3,7 @@ package minio
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"strconv"
 	"strings"
 	"testing"
@@ -112,6 +113,50 @@ func TestAccS3BucketVersioning_forceDestroy(t *testing.T) {
 	})
 }
 
+func TestAccS3BucketVersioning_mfaDelete(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckMinioS3BucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccBucketVersioningMFADeleteConfig(name, "Disabled"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("minio_s3_bucket_versioning.bucket", "versioning_configuration.0.mfa_delete", "Disabled"),
+				),
+			},
+			{
+				Config:      testAccBucketVersioningMFADeleteConfig(name, "Enabled"),
+				ExpectError: regexp.MustCompile("mfa_delete cannot be Enabled"),
+			},
+			{
+				// the rejected change left the bucket untouched
+				Config:   testAccBucketVersioningMFADeleteConfig(name, "Disabled"),
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func testAccBucketVersioningMFADeleteConfig(bucketName string, mfaDelete string) string {
+	return fmt.Sprintf(`
+resource "minio_s3_bucket" "bucket" {
+  bucket = "%s"
+}
+
+resource "minio_s3_bucket_versioning" "bucket" {
+  bucket = minio_s3_bucket.bucket.bucket
+
+  versioning_configuration {
+    status     = "Enabled"
+    mfa_delete = "%s"
+  }
+}
+`, bucketName, mfaDelete)
+}
+
 func testAccBucketVersioningConfig(bucketName string, status string, prefixes []string, excludeFolders bool) string {
 	prefixSlice := []string{}
 	for _, v := range prefixes {
@@ -3,19 +3,25 @@ package minio
 import (
 	"bytes"
 	"context"
+	"crypto/md5" // #nosec G501 -- MD5 is only used as an integrity checksum
 	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"log"
 	"os"
+	"path"
 	"path/filepath"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/minio/minio-go/v7"
 	"github.com/mitchellh/go-homedir"
+	"gopkg.in/yaml.v3"
 )
 
 func resourceMinioObject() *schema.Resource {
@@ -29,10 +35,10 @@ func resourceMinioObject() *schema.Resource {
 
 		Schema: map[string]*schema.Schema{
 			"bucket_name": {
-				Type:         schema.TypeString,
-				Required:     true,
-				ForceNew:     true,
-				ValidateFunc: validation.NoZeroValues,
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateBucketName,
 			},
 			"object_name": {
 				Type:         schema.TypeString,
@@ -60,6 +66,53 @@ func resourceMinioObject() *schema.Resource {
 				Optional:      true,
 				ConflictsWith: []string{"source", "content"},
 			},
+			"content_md5": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateObjectContentMD5,
+				Description:  "Base64 encoded MD5 digest of the object content. The upload fails if the content, or the object stored by the server, does not match it",
+			},
+			"validate_json": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				ConflictsWith: []string{"validate_yaml"},
+				Description:   "Parse the object content as JSON before uploading it and fail if it is malformed",
+			},
+			"validate_yaml": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				ConflictsWith: []string{"validate_json"},
+				Description:   "Parse the object content as YAML before uploading it and fail if it is malformed",
+			},
+			"metadata_rule": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Metadata and tags applied by object name pattern. Only the first rule matching `object_name` is applied",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"pattern": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateObjectNamePattern,
+							Description:  "Glob pattern matched against the object name. Patterns without a `/` are matched against the last path element, e.g. `*.html` matches `site/index.html`",
+						},
+						"metadata": {
+							Type:        schema.TypeMap,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Object metadata, e.g. `cache-control`. Standard headers are sent as such, other keys as user metadata",
+						},
+						"tags": {
+							Type:        schema.TypeMap,
+							Optional:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "Object tags, taking precedence over the `default_tags` of the provider",
+						},
+					},
+				},
+			},
 			"etag": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -70,8 +123,14 @@ func resourceMinioObject() *schema.Resource {
 				Optional: true,
 				Computed: true,
 			},
+			"tags_all": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Tags of the object, including the `default_tags` of the provider. The object is uploaded again when they change",
+			},
 		},
-		//CustomizeDiff: customDiff,
+		CustomizeDiff: minioObjectTagsAllDiff,
 	}
 }
 
@@ -117,16 +176,47 @@ func minioPutObject(ctx context.Context, d *schema.ResourceData, meta interface{
 		return NewResourceError("putting object failed", d.Id(), errors.New("one of source / content / content_base64 is not set"))
 	}
 
-	options := minio.PutObjectOptions{}
+	size := int64(-1)
+	format := objectContentFormat(d)
+	contentMD5 := d.Get("content_md5").(string)
+	if format != "" || contentMD5 != "" {
+		content, err := io.ReadAll(body)
+		if err != nil {
+			return NewResourceError("reading object content failed", d.Id(), err)
+		}
+		size = int64(len(content))
+		if format != "" {
+			if err := validateObjectContent(content, format); err != nil {
+				return NewResourceError("validating object content failed", d.Get("object_name").(string), err)
+			}
+		}
+		if contentMD5 != "" {
+			if digest := objectContentMD5(content); digest != contentMD5 {
+				return NewResourceError("object integrity check failed", d.Get("object_name").(string),
+					fmt.Errorf("content MD5 is %s, expected %s", digest, contentMD5))
+			}
+		}
+		if _, err := body.Seek(0, io.SeekStart); err != nil {
+			return NewResourceError("reading object content failed", d.Id(), err)
+		}
+	}
+
+	options := minio.PutObjectOptions{
+		SendContentMd5: contentMD5 != "",
+	}
 	if v, ok := d.GetOk("content_type"); ok {
 		options.ContentType = v.(string)
 	}
+	if rule := matchObjectMetadataRule(d.Get("metadata_rule").([]interface{}), d.Get("object_name").(string)); rule != nil {
+		applyObjectMetadataRule(&options, rule)
+	}
+	options.UserTags = withObjectDefaultTags(m.DefaultTags, options.UserTags)
 
-	_, err := m.S3Client.PutObject(
+	info, err := m.S3Client.PutObject(
 		ctx,
 		d.Get("bucket_name").(string),
 		d.Get("object_name").(string),
-		body, -1,
+		body, size,
 		options,
 	)
 
@@ -134,6 +224,12 @@ func minioPutObject(ctx context.Context, d *schema.ResourceData, meta interface{
 		return NewResourceError("putting object failed", d.Id(), err)
 	}
 
+	if contentMD5 != "" {
+		if err := verifyObjectETag(info.ETag, contentMD5); err != nil {
+			return NewResourceError("object integrity check failed", d.Get("object_name").(string), err)
+		}
+	}
+
 	d.SetId(d.Get("object_name").(string))
 
 	return minioReadObject(ctx, d, meta)
@@ -168,9 +264,54 @@ func minioReadObject(ctx context.Context, d *schema.ResourceData, meta interface
 		return NewResourceError("reading object failed", d.Id(), err)
 	}
 
+	objectTags, err := m.S3Client.GetObjectTagging(
+		ctx,
+		d.Get("bucket_name").(string),
+		d.Get("object_name").(string),
+		minio.GetObjectTaggingOptions{},
+	)
+	if err != nil {
+		return NewResourceError("reading object tags failed", d.Id(), err)
+	}
+	if err := d.Set("tags_all", objectTags.ToMap()); err != nil {
+		return NewResourceError("reading object tags failed", d.Id(), err)
+	}
+
 	return nil
 }
 
+// minioObjectTagsAllDiff plans tags_all from the tags of the matching metadata rule and the default tags of the
+// provider, so that the object is uploaded again with the new tags when the default tags change
+func minioObjectTagsAllDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("metadata_rule") || !d.NewValueKnown("object_name") {
+		return d.SetNewComputed("tags_all")
+	}
+
+	var options minio.PutObjectOptions
+	if rule := matchObjectMetadataRule(d.Get("metadata_rule").([]interface{}), d.Get("object_name").(string)); rule != nil {
+		applyObjectMetadataRule(&options, rule)
+	}
+	tagsAll := withObjectDefaultTags(meta.(*S3MinioClient).DefaultTags, options.UserTags)
+	if len(tagsAll) == 0 && len(d.Get("tags_all").(map[string]interface{})) == 0 {
+		return nil
+	}
+
+	return d.SetNew("tags_all", tagsAll)
+}
+
+// withObjectDefaultTags adds the default tags of the provider to the tags of an object
+func withObjectDefaultTags(defaultTags map[string]string, tags map[string]string) map[string]string {
+	if len(defaultTags) == 0 {
+		return tags
+	}
+
+	userTags := make(map[string]interface{}, len(tags))
+	for key, value := range tags {
+		userTags[key] = value
+	}
+	return mergeDefaultTags(defaultTags, userTags)
+}
+
 func minioUpdateObject(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	return minioPutObject(ctx, d, meta)
 }
@@ -192,3 +333,135 @@ func minioDeleteObject(ctx context.Context, d *schema.ResourceData, meta interfa
 
 	return nil
 }
+
+func validateObjectContentMD5(v interface{}, k string) (ws []string, errors []error) {
+	digest, err := base64.StdEncoding.DecodeString(v.(string))
+	if err != nil || len(digest) != md5.Size {
+		errors = append(errors, fmt.Errorf("%q must be a base64 encoded MD5 digest", k))
+	}
+	return
+}
+
+func objectContentMD5(content []byte) string {
+	digest := md5.Sum(content) // #nosec G401 -- MD5 is only used as an integrity checksum
+	return base64.StdEncoding.EncodeToString(digest[:])
+}
+
+// verifyObjectETag compares the ETag of a single part upload to the expected base64 encoded MD5 digest.
+// Multipart ETags are not a digest of the content and are not checked.
+func verifyObjectETag(etag, contentMD5 string) error {
+	etag = strings.Trim(etag, "\"")
+	if strings.Contains(etag, "-") {
+		return nil
+	}
+
+	digest, _ := base64.StdEncoding.DecodeString(contentMD5)
+	if !strings.EqualFold(etag, hex.EncodeToString(digest)) {
+		return fmt.Errorf("stored object ETag %s does not match content MD5 %s", etag, contentMD5)
+	}
+
+	return nil
+}
+
+func validateObjectNamePattern(v interface{}, k string) (ws []string, errors []error) {
+	if _, err := path.Match(v.(string), ""); err != nil {
+		errors = append(errors, fmt.Errorf("%q is not a valid glob pattern: %s", k, err))
+	}
+	return
+}
+
+// matchObjectMetadataRule returns the first metadata rule whose pattern matches the object name
+func matchObjectMetadataRule(rules []interface{}, objectName string) map[string]interface{} {
+	for _, r := range rules {
+		rule, ok := r.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		pattern := rule["pattern"].(string)
+		name := objectName
+		if !strings.Contains(pattern, "/") {
+			name = path.Base(objectName)
+		}
+		if matched, _ := path.Match(pattern, name); matched {
+			return rule
+		}
+	}
+
+	return nil
+}
+
+func applyObjectMetadataRule(options *minio.PutObjectOptions, rule map[string]interface{}) {
+	for key, v := range rule["metadata"].(map[string]interface{}) {
+		value := v.(string)
+		switch strings.ToLower(key) {
+		case "cache-control":
+			options.CacheControl = value
+		case "content-disposition":
+			options.ContentDisposition = value
+		case "content-encoding":
+			options.ContentEncoding = value
+		case "content-language":
+			options.ContentLanguage = value
+		default:
+			if options.UserMetadata == nil {
+				options.UserMetadata = map[string]string{}
+			}
+			options.UserMetadata[key] = value
+		}
+	}
+
+	for key, value := range rule["tags"].(map[string]interface{}) {
+		if options.UserTags == nil {
+			options.UserTags = map[string]string{}
+		}
+		options.UserTags[key] = value.(string)
+	}
+}
+
+func objectContentFormat(d *schema.ResourceData) string {
+	switch {
+	case d.Get("validate_json").(bool):
+		return "json"
+	case d.Get("validate_yaml").(bool):
+		return "yaml"
+	}
+	return ""
+}
+
+// validateObjectContent checks that content is well-formed in the given format, pointing at the offending line
+func validateObjectContent(content []byte, format string) error {
+	var out interface{}
+
+	switch format {
+	case "json":
+		if err := json.Unmarshal(content, &out); err != nil {
+			var syntaxErr *json.SyntaxError
+			if errors.As(err, &syntaxErr) {
+				line, column := offsetToLineColumn(content, syntaxErr.Offset)
+				return fmt.Errorf("invalid JSON at line %d, column %d: %s", line, column, syntaxErr)
+			}
+			return fmt.Errorf("invalid JSON: %s", err)
+		}
+	case "yaml":
+		if err := yaml.Unmarshal(content, &out); err != nil {
+			return fmt.Errorf("invalid YAML: %s", err)
+		}
+	}
+
+	return nil
+}
+
+func offsetToLineColumn(content []byte, offset int64) (line, column int) {
+	if offset > int64(len(content)) {
+		offset = int64(len(content))
+	}
+	before := content[:offset]
+	line = bytes.Count(before, []byte("\n")) + 1
+	column = int(offset) - bytes.LastIndexByte(before, '\n')
+	if column > 1 {
+		// the syntax error offset points just after the offending byte
+		column--
+	}
+	return
+}
@@ -0,0 +1,171 @@
+package minio
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/minio/minio-go/v7"
+)
+
+func resourceMinioObjectLegalHold() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: minioCreateObjectLegalHold,
+		ReadContext:   minioReadObjectLegalHold,
+		UpdateContext: minioUpdateObjectLegalHold,
+		DeleteContext: minioDeleteObjectLegalHold,
+		Importer: &schema.ResourceImporter{
+			StateContext: minioImportObjectLegalHold,
+		},
+
+		Description: "`minio_s3_object_legal_hold` places a legal hold on a single object version, which cannot be deleted or overwritten until the hold is released, " +
+			"independently of any retention period. The bucket must have been created with object locking. Destroying the resource releases the hold",
+
+		Schema: map[string]*schema.Schema{
+			"bucket": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateBucketName,
+			},
+			"key": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.NoZeroValues,
+				Description:  "Name of the object",
+			},
+			"version_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "Version of the object the legal hold applies to. Defaults to the current version when the resource is created",
+			},
+			"status": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      minio.LegalHoldEnabled.String(),
+				ValidateFunc: validation.StringInSlice([]string{minio.LegalHoldEnabled.String(), minio.LegalHoldDisabled.String()}, false),
+				Description:  "`ON` to hold the object version, `OFF` to release it while keeping the resource",
+			},
+		},
+	}
+}
+
+func minioCreateObjectLegalHold(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*S3MinioClient).S3Client
+	bucket := d.Get("bucket").(string)
+	key := d.Get("key").(string)
+	versionID := d.Get("version_id").(string)
+
+	locking, err := minioBucketObjectLockingEnabled(ctx, client, bucket)
+	if err != nil {
+		return NewResourceError("error reading bucket object lock configuration", bucket, err)
+	}
+	if !locking {
+		return NewResourceError("error setting object legal hold", bucket+"/"+key,
+			fmt.Errorf("bucket %s must be created with object locking, which also enables versioning, to hold its objects", bucket))
+	}
+
+	if versionID == "" {
+		if versionID, err = currentObjectVersion(ctx, client, bucket, key); err != nil {
+			return NewResourceError("error reading object version", bucket+"/"+key, err)
+		}
+	}
+
+	if err := minioPutObjectLegalHold(ctx, client, bucket, key, versionID, d.Get("status").(string)); err != nil {
+		return NewResourceError("error setting object legal hold", objectVersionID(bucket, key, versionID), err)
+	}
+
+	d.SetId(objectVersionID(bucket, key, versionID))
+	_ = d.Set("version_id", versionID)
+
+	return minioReadObjectLegalHold(ctx, d, meta)
+}
+
+func minioUpdateObjectLegalHold(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*S3MinioClient).S3Client
+
+	if err := minioPutObjectLegalHold(ctx, client, d.Get("bucket").(string), d.Get("key").(string), d.Get("version_id").(string), d.Get("status").(string)); err != nil {
+		return NewResourceError("error setting object legal hold", d.Id(), err)
+	}
+
+	return minioReadObjectLegalHold(ctx, d, meta)
+}
+
+func minioPutObjectLegalHold(ctx context.Context, client *minio.Client, bucket, key, versionID, status string) error {
+	tflog.Debug(ctx, "Setting object legal hold", map[string]interface{}{"bucket": bucket, "key": key, "version_id": versionID, "status": status})
+
+	legalHold := minio.LegalHoldStatus(status)
+	return client.PutObjectLegalHold(ctx, bucket, key, minio.PutObjectLegalHoldOptions{
+		VersionID: versionID,
+		Status:    &legalHold,
+	})
+}
+
+func minioReadObjectLegalHold(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*S3MinioClient).S3Client
+	bucket := d.Get("bucket").(string)
+	key := d.Get("key").(string)
+	versionID := d.Get("version_id").(string)
+
+	status, err := client.GetObjectLegalHold(ctx, bucket, key, minio.GetObjectLegalHoldOptions{VersionID: versionID})
+	if err != nil {
+		switch {
+		case isNotFoundError(err):
+			tflog.Warn(ctx, "Object not found, removing legal hold from state", map[string]interface{}{"bucket": bucket, "key": key, "version_id": versionID})
+			d.SetId("")
+			return nil
+		case minio.ToErrorResponse(err).Code == "NoSuchObjectLockConfiguration":
+			// objects that never had a legal hold
+			status = nil
+		default:
+			return NewResourceError("error reading object legal hold", d.Id(), err)
+		}
+	}
+	if status == nil {
+		off := minio.LegalHoldDisabled
+		status = &off
+	}
+
+	_ = d.Set("status", status.String())
+
+	return nil
+}
+
+func minioDeleteObjectLegalHold(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*S3MinioClient).S3Client
+
+	err := minioPutObjectLegalHold(ctx, client, d.Get("bucket").(string), d.Get("key").(string), d.Get("version_id").(string), minio.LegalHoldDisabled.String())
+	if err != nil && !isNotFoundError(err) {
+		return NewResourceError("error releasing object legal hold", d.Id(), err)
+	}
+
+	return nil
+}
+
+// minioImportObjectLegalHold imports the legal hold of an object by <bucket>/<key>, optionally followed by
+// ?versionId=<version>, the current version of the object being used otherwise
+func minioImportObjectLegalHold(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	bucket, key, versionID, err := parseObjectVersionID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	if versionID == "" {
+		if versionID, err = currentObjectVersion(ctx, meta.(*S3MinioClient).S3Client, bucket, key); err != nil {
+			return nil, fmt.Errorf("error reading the version of object %s/%s: %w", bucket, key, err)
+		}
+	}
+
+	d.SetId(objectVersionID(bucket, key, versionID))
+	_ = d.Set("bucket", bucket)
+	_ = d.Set("key", key)
+	_ = d.Set("version_id", versionID)
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,128 @@
+package minio
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/minio/minio-go/v7"
+)
+
+func TestAccS3ObjectLegalHold_basic(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "minio_s3_object_legal_hold.hold"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckMinioS3BucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccObjectLegalHoldConfig(name, "ON"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckObjectLegalHold(resourceName, minio.LegalHoldEnabled),
+					resource.TestCheckResourceAttrSet(resourceName, "version_id"),
+					resource.TestCheckResourceAttr(resourceName, "status", "ON"),
+				),
+			},
+			{
+				Config: testAccObjectLegalHoldConfig(name, "OFF"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckObjectLegalHold(resourceName, minio.LegalHoldDisabled),
+					resource.TestCheckResourceAttr(resourceName, "status", "OFF"),
+				),
+			},
+			{
+				Config: testAccObjectLegalHoldConfig(name, "ON"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckObjectLegalHold(resourceName, minio.LegalHoldEnabled),
+				),
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccS3ObjectLegalHold_bucketWithoutObjectLocking(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc-test")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckMinioS3BucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "minio_s3_bucket" "bucket" {
+  bucket        = "%s"
+  force_destroy = true
+}
+
+resource "minio_s3_object" "object" {
+  bucket_name = minio_s3_bucket.bucket.bucket
+  object_name = "held.txt"
+  content     = "held"
+}
+
+resource "minio_s3_object_legal_hold" "hold" {
+  bucket = minio_s3_bucket.bucket.bucket
+  key    = minio_s3_object.object.object_name
+}
+`, name),
+				ExpectError: regexp.MustCompile("must be created with object locking"),
+			},
+		},
+	})
+}
+
+func testAccObjectLegalHoldConfig(bucket, status string) string {
+	return fmt.Sprintf(`
+resource "minio_s3_bucket" "bucket" {
+  bucket         = "%s"
+  object_locking = true
+  force_destroy  = true
+}
+
+resource "minio_s3_object" "object" {
+  bucket_name = minio_s3_bucket.bucket.bucket
+  object_name = "held.txt"
+  content     = "held"
+}
+
+resource "minio_s3_object_legal_hold" "hold" {
+  bucket = minio_s3_bucket.bucket.bucket
+  key    = minio_s3_object.object.object_name
+  status = "%s"
+}
+`, bucket, status)
+}
+
+func testAccCheckObjectLegalHold(n string, expected minio.LegalHoldStatus) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+
+		client := testAccProvider.Meta().(*S3MinioClient).S3Client
+		status, err := client.GetObjectLegalHold(context.Background(), rs.Primary.Attributes["bucket"], rs.Primary.Attributes["key"], minio.GetObjectLegalHoldOptions{
+			VersionID: rs.Primary.Attributes["version_id"],
+		})
+		if err != nil {
+			return fmt.Errorf("error reading object legal hold: %w", err)
+		}
+		if status == nil || *status != expected {
+			return fmt.Errorf("expected legal hold %s, got %v", expected, status)
+		}
+
+		return nil
+	}
+}
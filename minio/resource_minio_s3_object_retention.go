@@ -0,0 +1,270 @@
+package minio
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/minio/minio-go/v7"
+)
+
+func resourceMinioObjectRetention() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: minioPutObjectRetention,
+		ReadContext:   minioReadObjectRetention,
+		UpdateContext: minioPutObjectRetention,
+		DeleteContext: minioDeleteObjectRetention,
+		Importer: &schema.ResourceImporter{
+			StateContext: minioImportObjectRetention,
+		},
+		CustomizeDiff: minioCheckObjectRetentionDate,
+
+		Description: "`minio_s3_object_retention` locks a single object version until a date, independently of the default retention of the bucket. " +
+			"The bucket must have been created with object locking. Destroying the resource only clears a `GOVERNANCE` retention, with `governance_bypass`, " +
+			"a `COMPLIANCE` retention cannot be cleared before its date and is left in place",
+
+		Schema: map[string]*schema.Schema{
+			"bucket": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateDiagFunc: validateBucketName,
+			},
+			"key": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.NoZeroValues,
+				Description:  "Name of the object",
+			},
+			"version_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "Version of the object the retention applies to. Defaults to the current version when the resource is created",
+			},
+			"mode": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{minio.Governance.String(), minio.Compliance.String()}, false),
+				Description:  "`GOVERNANCE`, which users with the `s3:BypassGovernanceRetention` permission can shorten or clear, or `COMPLIANCE`, which nobody can",
+			},
+			"retain_until_date": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateFunc:     validation.IsRFC3339Time,
+				DiffSuppressFunc: suppressEquivalentRFC3339Time,
+				Description:      "Date (2030-01-01T00:00:00Z) until which the object version cannot be deleted or overwritten. Must be in the future when it is set or changed",
+			},
+			"governance_bypass": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Bypass a `GOVERNANCE` retention to shorten it or clear it on destroy. Requires the `s3:BypassGovernanceRetention` permission",
+			},
+		},
+	}
+}
+
+func minioPutObjectRetention(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*S3MinioClient).S3Client
+	bucket := d.Get("bucket").(string)
+	key := d.Get("key").(string)
+	versionID := d.Get("version_id").(string)
+
+	if versionID == "" {
+		var err error
+		if versionID, err = currentObjectVersion(ctx, client, bucket, key); err != nil {
+			return NewResourceError("error reading object version", bucket+"/"+key, err)
+		}
+	}
+
+	mode := minio.RetentionMode(d.Get("mode").(string))
+	retainUntil, err := time.Parse(time.RFC3339, d.Get("retain_until_date").(string))
+	if err != nil {
+		return NewResourceError("invalid retain_until_date", bucket+"/"+key, err)
+	}
+
+	tflog.Debug(ctx, "Setting object retention", map[string]interface{}{
+		"bucket": bucket, "key": key, "version_id": versionID, "mode": mode.String(), "retain_until_date": retainUntil.UTC().Format(time.RFC3339),
+	})
+
+	err = client.PutObjectRetention(ctx, bucket, key, minio.PutObjectRetentionOptions{
+		GovernanceBypass: d.Get("governance_bypass").(bool),
+		Mode:             &mode,
+		RetainUntilDate:  &retainUntil,
+		VersionID:        versionID,
+	})
+	if err != nil {
+		return NewResourceError("error setting object retention", objectVersionID(bucket, key, versionID), err)
+	}
+
+	d.SetId(objectVersionID(bucket, key, versionID))
+	_ = d.Set("version_id", versionID)
+
+	return minioReadObjectRetention(ctx, d, meta)
+}
+
+func minioReadObjectRetention(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*S3MinioClient).S3Client
+	bucket := d.Get("bucket").(string)
+	key := d.Get("key").(string)
+	versionID := d.Get("version_id").(string)
+
+	mode, retainUntil, err := client.GetObjectRetention(ctx, bucket, key, versionID)
+	if err != nil {
+		if isNotFoundError(err) || minio.ToErrorResponse(err).Code == "NoSuchObjectLockConfiguration" {
+			tflog.Warn(ctx, "Object retention not found, removing from state", map[string]interface{}{"bucket": bucket, "key": key, "version_id": versionID})
+			d.SetId("")
+			return nil
+		}
+		return NewResourceError("error reading object retention", d.Id(), err)
+	}
+	if mode == nil || !mode.IsValid() || retainUntil == nil {
+		tflog.Warn(ctx, "Object has no retention, removing from state", map[string]interface{}{"bucket": bucket, "key": key, "version_id": versionID})
+		d.SetId("")
+		return nil
+	}
+
+	_ = d.Set("mode", mode.String())
+	_ = d.Set("retain_until_date", retainUntil.UTC().Format(time.RFC3339))
+
+	return nil
+}
+
+func minioDeleteObjectRetention(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*S3MinioClient).S3Client
+	bucket := d.Get("bucket").(string)
+	key := d.Get("key").(string)
+	versionID := d.Get("version_id").(string)
+
+	mode, retainUntil, err := client.GetObjectRetention(ctx, bucket, key, versionID)
+	if err != nil {
+		if isNotFoundError(err) || minio.ToErrorResponse(err).Code == "NoSuchObjectLockConfiguration" {
+			return nil
+		}
+		return NewResourceError("error reading object retention", d.Id(), err)
+	}
+	if mode == nil || retainUntil == nil || !retainUntil.After(time.Now()) {
+		return nil
+	}
+
+	bypass := d.Get("governance_bypass").(bool)
+	switch {
+	case *mode == minio.Compliance:
+		return objectRetentionKeptWarning(d.Id(), *mode, *retainUntil, "a COMPLIANCE retention cannot be cleared before its date")
+	case !bypass:
+		return objectRetentionKeptWarning(d.Id(), *mode, *retainUntil, "set governance_bypass to clear a GOVERNANCE retention on destroy")
+	}
+
+	tflog.Debug(ctx, "Clearing object retention", map[string]interface{}{"bucket": bucket, "key": key, "version_id": versionID})
+
+	err = client.PutObjectRetention(ctx, bucket, key, minio.PutObjectRetentionOptions{
+		GovernanceBypass: true,
+		VersionID:        versionID,
+	})
+	if err != nil {
+		if minio.ToErrorResponse(err).Code == "AccessDenied" {
+			return objectRetentionKeptWarning(d.Id(), *mode, *retainUntil, "the credentials of the provider lack the s3:BypassGovernanceRetention permission")
+		}
+		return NewResourceError("error clearing object retention", d.Id(), err)
+	}
+
+	return nil
+}
+
+func objectRetentionKeptWarning(id string, mode minio.RetentionMode, retainUntil time.Time, reason string) diag.Diagnostics {
+	return diag.Diagnostics{{
+		Severity: diag.Warning,
+		Summary:  fmt.Sprintf("Object retention of %s is left in place", id),
+		Detail:   fmt.Sprintf("The object stays locked in %s mode until %s: %s.", mode, retainUntil.UTC().Format(time.RFC3339), reason),
+	}}
+}
+
+// minioImportObjectRetention imports the retention of an object by <bucket>/<key>, optionally followed by
+// ?versionId=<version>, the current version of the object being used otherwise
+func minioImportObjectRetention(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	bucket, key, versionID, err := parseObjectVersionID(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	if versionID == "" {
+		if versionID, err = currentObjectVersion(ctx, meta.(*S3MinioClient).S3Client, bucket, key); err != nil {
+			return nil, fmt.Errorf("error reading the version of object %s/%s: %w", bucket, key, err)
+		}
+	}
+
+	d.SetId(objectVersionID(bucket, key, versionID))
+	_ = d.Set("bucket", bucket)
+	_ = d.Set("key", key)
+	_ = d.Set("version_id", versionID)
+	_ = d.Set("governance_bypass", false)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// currentObjectVersion returns the ID of the current version of an object
+func currentObjectVersion(ctx context.Context, client *minio.Client, bucket, key string) (string, error) {
+	info, err := client.StatObject(ctx, bucket, key, minio.StatObjectOptions{})
+	if err != nil {
+		return "", err
+	}
+	return info.VersionID, nil
+}
+
+// objectVersionID returns the ID of resources applying to an object version, <bucket>/<key>?versionId=<version>
+func objectVersionID(bucket, key, versionID string) string {
+	if versionID == "" {
+		return bucket + "/" + key
+	}
+	return fmt.Sprintf("%s/%s?versionId=%s", bucket, key, versionID)
+}
+
+func parseObjectVersionID(id string) (bucket, key, versionID string, err error) {
+	object, versionID, _ := strings.Cut(id, "?versionId=")
+	bucket, key, ok := strings.Cut(object, "/")
+	if !ok || bucket == "" || key == "" {
+		return "", "", "", fmt.Errorf("unexpected format of ID (%s), expected <bucket>/<key> or <bucket>/<key>?versionId=<version>", id)
+	}
+	return bucket, key, versionID, nil
+}
+
+// minioCheckObjectRetentionDate rejects a retention date that is not in the future when it is set or changed.
+// The date of an existing retention is left alone once it passed.
+func minioCheckObjectRetentionDate(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.HasChange("retain_until_date") || !d.NewValueKnown("retain_until_date") {
+		return nil
+	}
+	return validateObjectRetainUntilDate(d.Get("retain_until_date").(string), time.Now())
+}
+
+func validateObjectRetainUntilDate(value string, now time.Time) error {
+	retainUntil, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return fmt.Errorf("retain_until_date must be an RFC 3339 date, e.g. 2030-01-01T00:00:00Z: %w", err)
+	}
+	if !retainUntil.After(now) {
+		return fmt.Errorf("retain_until_date (%s) must be in the future", value)
+	}
+	return nil
+}
+
+// suppressEquivalentRFC3339Time ignores differences in the time zone of dates denoting the same instant
+func suppressEquivalentRFC3339Time(k, oldValue, newValue string, d *schema.ResourceData) bool {
+	oldTime, err := time.Parse(time.RFC3339, oldValue)
+	if err != nil {
+		return false
+	}
+	newTime, err := time.Parse(time.RFC3339, newValue)
+	if err != nil {
+		return false
+	}
+	return oldTime.Equal(newTime)
+}
@@ -0,0 +1,149 @@
+package minio
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/minio/minio-go/v7"
+)
+
+func TestAccS3ObjectRetention_governance(t *testing.T) {
+	name := acctest.RandomWithPrefix("tf-acc-test")
+	resourceName := "minio_s3_object_retention.retention"
+	retainUntil := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	extendedUntil := retainUntil.Add(time.Hour)
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckMinioS3BucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccObjectRetentionConfig(name, "GOVERNANCE", "2020-01-01T00:00:00Z"),
+				ExpectError: regexp.MustCompile("must be in the future"),
+			},
+			{
+				Config: testAccObjectRetentionConfig(name, "GOVERNANCE", retainUntil.Format(time.RFC3339)),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckObjectRetention(resourceName, minio.Governance, retainUntil),
+					resource.TestCheckResourceAttrSet(resourceName, "version_id"),
+					resource.TestCheckResourceAttr(resourceName, "mode", "GOVERNANCE"),
+					resource.TestCheckResourceAttr(resourceName, "retain_until_date", retainUntil.Format(time.RFC3339)),
+				),
+			},
+			{
+				// the same date in another time zone is not a change
+				Config:   testAccObjectRetentionConfig(name, "GOVERNANCE", retainUntil.In(time.FixedZone("", 2*60*60)).Format(time.RFC3339)),
+				PlanOnly: true,
+			},
+			{
+				Config: testAccObjectRetentionConfig(name, "GOVERNANCE", extendedUntil.Format(time.RFC3339)),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckObjectRetention(resourceName, minio.Governance, extendedUntil),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"governance_bypass"},
+			},
+		},
+	})
+}
+
+func TestValidateObjectRetainUntilDate(t *testing.T) {
+	now := time.Date(2030, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	for _, value := range []string{"2030-01-01T12:00:01Z", "2030-01-01T13:30:00+01:00", "2031-01-01T00:00:00Z"} {
+		if err := validateObjectRetainUntilDate(value, now); err != nil {
+			t.Errorf("%q should be a valid date: %s", value, err)
+		}
+	}
+	for _, value := range []string{"2030-01-01T12:00:00Z", "2030-01-01T12:30:00+01:00", "2029-12-31T00:00:00Z", "2031-01-01", ""} {
+		if err := validateObjectRetainUntilDate(value, now); err == nil {
+			t.Errorf("%q should be rejected", value)
+		}
+	}
+}
+
+func TestParseObjectVersionID(t *testing.T) {
+	cases := []struct {
+		id, bucket, key, versionID string
+	}{
+		{"bucket/key", "bucket", "key", ""},
+		{"bucket/path/to/key", "bucket", "path/to/key", ""},
+		{"bucket/path/to/key?versionId=abc-123", "bucket", "path/to/key", "abc-123"},
+	}
+	for _, c := range cases {
+		bucket, key, versionID, err := parseObjectVersionID(c.id)
+		if err != nil {
+			t.Fatalf("%q: unexpected error: %s", c.id, err)
+		}
+		if bucket != c.bucket || key != c.key || versionID != c.versionID {
+			t.Errorf("%q: expected (%q, %q, %q), got (%q, %q, %q)", c.id, c.bucket, c.key, c.versionID, bucket, key, versionID)
+		}
+		if id := objectVersionID(bucket, key, versionID); id != c.id {
+			t.Errorf("expected ID %q, got %q", c.id, id)
+		}
+	}
+
+	for _, id := range []string{"bucket", "bucket/", "/key", "?versionId=abc"} {
+		if _, _, _, err := parseObjectVersionID(id); err == nil {
+			t.Errorf("%q should be rejected", id)
+		}
+	}
+}
+
+func testAccObjectRetentionConfig(bucket, mode, retainUntil string) string {
+	return fmt.Sprintf(`
+resource "minio_s3_bucket" "bucket" {
+  bucket         = "%s"
+  object_locking = true
+  force_destroy  = true
+}
+
+resource "minio_s3_object" "object" {
+  bucket_name = minio_s3_bucket.bucket.bucket
+  object_name = "locked.txt"
+  content     = "locked"
+}
+
+resource "minio_s3_object_retention" "retention" {
+  bucket            = minio_s3_bucket.bucket.bucket
+  key               = minio_s3_object.object.object_name
+  mode              = "%s"
+  retain_until_date = "%s"
+  governance_bypass = true
+}
+`, bucket, mode, retainUntil)
+}
+
+func testAccCheckObjectRetention(n string, mode minio.RetentionMode, retainUntil time.Time) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+
+		client := testAccProvider.Meta().(*S3MinioClient).S3Client
+		actualMode, actualRetainUntil, err := client.GetObjectRetention(context.Background(), rs.Primary.Attributes["bucket"], rs.Primary.Attributes["key"], rs.Primary.Attributes["version_id"])
+		if err != nil {
+			return fmt.Errorf("error reading object retention: %w", err)
+		}
+		if actualMode == nil || *actualMode != mode {
+			return fmt.Errorf("expected retention mode %s, got %v", mode, actualMode)
+		}
+		if actualRetainUntil == nil || !actualRetainUntil.Equal(retainUntil) {
+			return fmt.Errorf("expected retention until %s, got %v", retainUntil, actualRetainUntil)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,400 @@
+package minio
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/minio/minio-go/v7"
+)
+
+func TestAccMinioS3Object_validateJSON(t *testing.T) {
+	bucketName := acctest.RandomWithPrefix("tf-acc-test-a")
+	resourceName := "minio_s3_object.object"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckMinioS3BucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccMinioS3ObjectValidateJSONConfig(bucketName, `{\"enabled\": true,}`),
+				ExpectError: regexp.MustCompile("invalid JSON at line 1, column 18"),
+			},
+			{
+				Config: testAccMinioS3ObjectValidateJSONConfig(bucketName, `{\"enabled\": true}`),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMinioS3ObjectExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "validate_json", "true"),
+				),
+			},
+		},
+	})
+}
+
+func TestValidateObjectContent(t *testing.T) {
+	valid := map[string]string{
+		"json": "{\n  \"enabled\": true,\n  \"items\": [1, 2]\n}",
+		"yaml": "enabled: true\nitems:\n  - 1\n  - 2\n",
+	}
+	for format, content := range valid {
+		if err := validateObjectContent([]byte(content), format); err != nil {
+			t.Fatalf("%s content should be valid: %s", format, err)
+		}
+	}
+
+	err := validateObjectContent([]byte("{\n  \"enabled\": true,\n}"), "json")
+	if err == nil || !strings.Contains(err.Error(), "line 3, column 1") {
+		t.Fatalf("expected JSON error pointing at line 3, column 1, got %v", err)
+	}
+
+	err = validateObjectContent([]byte("enabled: true\n  items: [1, 2\n"), "yaml")
+	if err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Fatalf("expected YAML error pointing at line 2, got %v", err)
+	}
+}
+
+func TestAccMinioS3Object_contentMD5(t *testing.T) {
+	bucketName := acctest.RandomWithPrefix("tf-acc-test-a")
+	resourceName := "minio_s3_object.object"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckMinioS3BucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccMinioS3ObjectContentMD5Config(bucketName, "bWlzbWF0Y2hlZGRpZ2VzdA=="),
+				ExpectError: regexp.MustCompile("object integrity check failed"),
+			},
+			{
+				Config: testAccMinioS3ObjectContentMD5Config(bucketName, "XrY7u+Ae7tCTyyK7j1rNww=="),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMinioS3ObjectExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "etag", "5eb63bbbe01eeed093cb22bb8f5acdc3"),
+				),
+			},
+		},
+	})
+}
+
+func TestObjectContentMD5(t *testing.T) {
+	content := []byte("hello world")
+	if digest := objectContentMD5(content); digest != "XrY7u+Ae7tCTyyK7j1rNww==" {
+		t.Fatalf("unexpected digest %s", digest)
+	}
+
+	if _, errs := validateObjectContentMD5("XrY7u+Ae7tCTyyK7j1rNww==", "content_md5"); len(errs) != 0 {
+		t.Fatalf("valid digest rejected: %v", errs)
+	}
+	for _, digest := range []string{"not base64!", "aGVsbG8="} {
+		if _, errs := validateObjectContentMD5(digest, "content_md5"); len(errs) == 0 {
+			t.Fatalf("invalid digest %q accepted", digest)
+		}
+	}
+
+	if err := verifyObjectETag(`"5eb63bbbe01eeed093cb22bb8f5acdc3"`, "XrY7u+Ae7tCTyyK7j1rNww=="); err != nil {
+		t.Fatalf("matching ETag rejected: %s", err)
+	}
+	if err := verifyObjectETag("d41d8cd98f00b204e9800998ecf8427e", "XrY7u+Ae7tCTyyK7j1rNww=="); err == nil {
+		t.Fatalf("mismatching ETag accepted")
+	}
+	if err := verifyObjectETag("9b2cf535f27731c974343645a3985328-2", "XrY7u+Ae7tCTyyK7j1rNww=="); err != nil {
+		t.Fatalf("multipart ETag should not be checked: %s", err)
+	}
+}
+
+func TestAccMinioS3Object_metadataRule(t *testing.T) {
+	bucketName := acctest.RandomWithPrefix("tf-acc-test-a")
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckMinioS3BucketDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMinioS3ObjectMetadataRuleConfig(bucketName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMinioS3ObjectMetadata("minio_s3_object.html", "no-cache", map[string]string{"type": "page"}),
+					testAccCheckMinioS3ObjectMetadata("minio_s3_object.js", "max-age=31536000", map[string]string{"type": "asset"}),
+				),
+			},
+		},
+	})
+}
+
+func TestAccMinioS3Object_defaultTags(t *testing.T) {
+	bucketName := acctest.RandomWithPrefix("tf-acc-test-a")
+	resourceName := "minio_s3_object.object"
+
+	// default tags are set on a provider of their own so they do not leak into the other tests
+	provider := newProvider()
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck: func() { testAccPreCheck(t) },
+		ProviderFactories: map[string]func() (*schema.Provider, error){
+			"minio": func() (*schema.Provider, error) { return provider, nil },
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMinioS3ObjectDefaultTagsConfig(bucketName, "test"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMinioS3ObjectTagsWithProvider(provider, resourceName, map[string]string{"env": "test", "type": "page"}),
+					resource.TestCheckResourceAttr(resourceName, "tags_all.%", "2"),
+					resource.TestCheckResourceAttr(resourceName, "tags_all.env", "test"),
+				),
+			},
+			{
+				Config: testAccMinioS3ObjectDefaultTagsConfig(bucketName, "prod"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMinioS3ObjectTagsWithProvider(provider, resourceName, map[string]string{"env": "prod", "type": "page"}),
+					resource.TestCheckResourceAttr(resourceName, "tags_all.env", "prod"),
+				),
+			},
+		},
+	})
+}
+
+func TestMatchObjectMetadataRule(t *testing.T) {
+	rules := []interface{}{
+		map[string]interface{}{"pattern": "assets/*.js"},
+		map[string]interface{}{"pattern": "*.js"},
+		map[string]interface{}{"pattern": "*.html"},
+		map[string]interface{}{"pattern": "*"},
+	}
+
+	cases := map[string]string{
+		"assets/app.js":   "assets/*.js",
+		"vendor/lib.js":   "*.js",
+		"app.js":          "*.js",
+		"docs/index.html": "*.html",
+		"robots.txt":      "*",
+	}
+	for objectName, pattern := range cases {
+		rule := matchObjectMetadataRule(rules, objectName)
+		if rule == nil || rule["pattern"] != pattern {
+			t.Fatalf("%s should match %s first, got %v", objectName, pattern, rule)
+		}
+	}
+
+	if rule := matchObjectMetadataRule(rules[:3], "robots.txt"); rule != nil {
+		t.Fatalf("robots.txt should not match any rule, got %v", rule)
+	}
+}
+
+func TestApplyObjectMetadataRule(t *testing.T) {
+	options := minio.PutObjectOptions{}
+	applyObjectMetadataRule(&options, map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"Cache-Control":    "no-cache",
+			"content-encoding": "gzip",
+			"x-site-version":   "42",
+		},
+		"tags": map[string]interface{}{"type": "page"},
+	})
+
+	if options.CacheControl != "no-cache" || options.ContentEncoding != "gzip" {
+		t.Fatalf("standard headers should be set as such: %+v", options)
+	}
+	if options.UserMetadata["x-site-version"] != "42" || len(options.UserMetadata) != 1 {
+		t.Fatalf("other keys should be user metadata: %v", options.UserMetadata)
+	}
+	if options.UserTags["type"] != "page" {
+		t.Fatalf("tags should be set: %v", options.UserTags)
+	}
+}
+
+func testAccCheckMinioS3ObjectMetadata(n, cacheControl string, tags map[string]string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+
+		minioC := testAccProvider.Meta().(*S3MinioClient).S3Client
+		bucket := rs.Primary.Attributes["bucket_name"]
+		info, err := minioC.StatObject(context.Background(), bucket, rs.Primary.ID, minio.StatObjectOptions{})
+		if err != nil {
+			return fmt.Errorf("error stating object %s: %s", rs.Primary.ID, err)
+		}
+		if actual := info.Metadata.Get("Cache-Control"); actual != cacheControl {
+			return fmt.Errorf("object %s has Cache-Control %q, expected %q", rs.Primary.ID, actual, cacheControl)
+		}
+
+		objectTags, err := minioC.GetObjectTagging(context.Background(), bucket, rs.Primary.ID, minio.GetObjectTaggingOptions{})
+		if err != nil {
+			return fmt.Errorf("error getting tags of object %s: %s", rs.Primary.ID, err)
+		}
+		actualTags := objectTags.ToMap()
+		for key, value := range tags {
+			if actualTags[key] != value {
+				return fmt.Errorf("object %s has tags %v, expected %v", rs.Primary.ID, actualTags, tags)
+			}
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckMinioS3ObjectTagsWithProvider(provider *schema.Provider, n string, expected map[string]string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+
+		minioC := provider.Meta().(*S3MinioClient).S3Client
+		objectTags, err := minioC.GetObjectTagging(context.Background(), rs.Primary.Attributes["bucket_name"], rs.Primary.ID, minio.GetObjectTaggingOptions{})
+		if err != nil {
+			return fmt.Errorf("error getting tags of object %s: %s", rs.Primary.ID, err)
+		}
+
+		actual := objectTags.ToMap()
+		if len(actual) != len(expected) {
+			return fmt.Errorf("expected tags %v, got %v", expected, actual)
+		}
+		for key, value := range expected {
+			if actual[key] != value {
+				return fmt.Errorf("expected tags %v, got %v", expected, actual)
+			}
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckMinioS3ObjectExists(n string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("no ID is set")
+		}
+
+		minioC := testAccProvider.Meta().(*S3MinioClient).S3Client
+		_, err := minioC.StatObject(context.Background(), rs.Primary.Attributes["bucket_name"], rs.Primary.ID, minio.StatObjectOptions{})
+		if err != nil {
+			return fmt.Errorf("error stating object %s: %s", rs.Primary.ID, err)
+		}
+
+		return nil
+	}
+}
+
+func testAccMinioS3ObjectValidateJSONConfig(bucketName, content string) string {
+	return fmt.Sprintf(`
+resource "minio_s3_bucket" "bucket" {
+  bucket = "%s"
+}
+
+resource "minio_s3_object" "object" {
+  bucket_name   = minio_s3_bucket.bucket.bucket
+  object_name   = "config.json"
+  content_type  = "application/json"
+  content       = "%s"
+  validate_json = true
+}
+`, bucketName, content)
+}
+
+func testAccMinioS3ObjectContentMD5Config(bucketName, contentMD5 string) string {
+	return fmt.Sprintf(`
+resource "minio_s3_bucket" "bucket" {
+  bucket = "%s"
+}
+
+resource "minio_s3_object" "object" {
+  bucket_name = minio_s3_bucket.bucket.bucket
+  object_name = "hello.txt"
+  content     = "hello world"
+  content_md5 = "%s"
+}
+`, bucketName, contentMD5)
+}
+
+func testAccMinioS3ObjectMetadataRuleConfig(bucketName string) string {
+	return fmt.Sprintf(`
+resource "minio_s3_bucket" "bucket" {
+  bucket = "%s"
+}
+
+locals {
+  metadata_rules = [
+    {
+      pattern  = "*.html"
+      metadata = { cache-control = "no-cache" }
+      tags     = { type = "page" }
+    },
+    {
+      pattern  = "*.js"
+      metadata = { cache-control = "max-age=31536000" }
+      tags     = { type = "asset" }
+    },
+  ]
+}
+
+resource "minio_s3_object" "html" {
+  bucket_name = minio_s3_bucket.bucket.bucket
+  object_name = "site/index.html"
+  content     = "<html></html>"
+
+  dynamic "metadata_rule" {
+    for_each = local.metadata_rules
+    content {
+      pattern  = metadata_rule.value.pattern
+      metadata = metadata_rule.value.metadata
+      tags     = metadata_rule.value.tags
+    }
+  }
+}
+
+resource "minio_s3_object" "js" {
+  bucket_name = minio_s3_bucket.bucket.bucket
+  object_name = "site/app.js"
+  content     = "console.log(1)"
+
+  dynamic "metadata_rule" {
+    for_each = local.metadata_rules
+    content {
+      pattern  = metadata_rule.value.pattern
+      metadata = metadata_rule.value.metadata
+      tags     = metadata_rule.value.tags
+    }
+  }
+}
+`, bucketName)
+}
+
+func testAccMinioS3ObjectDefaultTagsConfig(bucketName, env string) string {
+	return fmt.Sprintf(`
+provider "minio" {
+  default_tags = {
+    env = %q
+  }
+}
+
+resource "minio_s3_bucket" "bucket" {
+  bucket = %q
+}
+
+resource "minio_s3_object" "object" {
+  bucket_name = minio_s3_bucket.bucket.bucket
+  object_name = "index.html"
+  content     = "<html></html>"
+
+  metadata_rule {
+    pattern = "*.html"
+    tags    = { type = "page" }
+  }
+}
+`, env, bucketName)
+}
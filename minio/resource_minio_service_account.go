@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
+	awspolicy "github.com/hashicorp/awspolicyequivalence"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/minio/madmin-go/v3"
@@ -59,6 +60,13 @@ func resourceMinioServiceAccount() *schema.Resource {
 				ValidateFunc:     validateIAMPolicyJSON,
 				DiffSuppressFunc: suppressEquivalentAwsPolicyDiffs,
 				Description:      "policy of service account",
+				ConflictsWith:    []string{"policy_name"},
+			},
+			"policy_name": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "Name of an existing canned policy whose document is applied as the policy of the service account, instead of `policy`. Changes to the canned policy are applied again on the next apply",
+				ConflictsWith: []string{"policy"},
 			},
 		},
 	}
@@ -70,10 +78,14 @@ func minioCreateServiceAccount(ctx context.Context, d *schema.ResourceData, meta
 
 	var err error
 	targetUser := serviceAccountConfig.MinioTargetUser
-	policy := serviceAccountConfig.MinioSAPolicy
+
+	policy, err := minioServiceAccountPolicy(ctx, serviceAccountConfig)
+	if err != nil {
+		return NewResourceError("error resolving service account policy", targetUser, err)
+	}
 
 	serviceAccount, err := serviceAccountConfig.MinioAdmin.AddServiceAccount(ctx, madmin.AddServiceAccountReq{
-		Policy:     processServiceAccountPolicy(policy),
+		Policy:     policy,
 		TargetUser: targetUser,
 	})
 	if err != nil {
@@ -99,10 +111,13 @@ func minioCreateServiceAccount(ctx context.Context, d *schema.ResourceData, meta
 func minioUpdateServiceAccount(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
 	serviceAccountConfig := ServiceAccountConfig(d, meta)
-	policy := serviceAccountConfig.MinioSAPolicy
+
+	policy, err := minioServiceAccountPolicy(ctx, serviceAccountConfig)
+	if err != nil {
+		return NewResourceError("error resolving service account policy", d.Id(), err)
+	}
 
 	wantedStatus := "on"
-	var err error
 
 	if serviceAccountConfig.MinioDisableUser {
 		wantedStatus = "off"
@@ -115,7 +130,7 @@ func minioUpdateServiceAccount(ctx context.Context, d *schema.ResourceData, meta
 	if serviceAccountServerInfo.AccountStatus != wantedStatus {
 		err := serviceAccountConfig.MinioAdmin.UpdateServiceAccount(ctx, serviceAccountConfig.MinioAccessKey, madmin.UpdateServiceAccountReq{
 			NewStatus: wantedStatus,
-			NewPolicy: processServiceAccountPolicy(policy),
+			NewPolicy: policy,
 		})
 		if err != nil {
 			return NewResourceError("error to disable service account", d.Id(), err)
@@ -134,7 +149,7 @@ func minioUpdateServiceAccount(ctx context.Context, d *schema.ResourceData, meta
 	if d.HasChange("secret_key") || serviceAccountConfig.MinioSecretKey != wantedSecret {
 		err := serviceAccountConfig.MinioAdmin.UpdateServiceAccount(ctx, d.Id(), madmin.UpdateServiceAccountReq{
 			NewSecretKey: wantedSecret,
-			NewPolicy:    processServiceAccountPolicy(policy),
+			NewPolicy:    policy,
 		})
 		if err != nil {
 			return NewResourceError("error updating service account Key %s: %s", d.Id(), err)
@@ -143,15 +158,15 @@ func minioUpdateServiceAccount(ctx context.Context, d *schema.ResourceData, meta
 		_ = d.Set("secret_key", wantedSecret)
 	}
 
-	if d.HasChange("policy") {
+	if d.HasChanges("policy", "policy_name") {
 		err := serviceAccountConfig.MinioAdmin.UpdateServiceAccount(ctx, d.Id(), madmin.UpdateServiceAccountReq{
-			NewPolicy: processServiceAccountPolicy(policy),
+			NewPolicy: policy,
 		})
 		if err != nil {
 			return NewResourceError("error updating service account policy %s: %s", d.Id(), err)
 		}
 
-		_ = d.Set("policy", policy)
+		_ = d.Set("policy", serviceAccountConfig.MinioSAPolicy)
 	}
 
 	return minioReadServiceAccount(ctx, d, meta)
@@ -183,7 +198,19 @@ func minioReadServiceAccount(ctx context.Context, d *schema.ResourceData, meta i
 		return NewResourceError("reading service account failed", d.Id(), err)
 	}
 
-	_ = d.Set("policy", output.Policy)
+	if policyName := serviceAccountConfig.MinioSAPolicyName; policyName != "" {
+		// a canned policy changed since it was applied is planned as a change of policy_name
+		cannedPolicy, err := serviceAccountConfig.MinioAdmin.InfoCannedPolicy(ctx, policyName)
+		if err != nil && !isNotFoundError(err) {
+			return NewResourceError("error reading service account policy", policyName, err)
+		}
+		if equivalent, err := awspolicy.PoliciesAreEquivalent(string(cannedPolicy), output.Policy); err != nil || !equivalent {
+			log.Printf("[DEBUG] Policy of service account %s no longer matches canned policy %s", d.Id(), policyName)
+			_ = d.Set("policy_name", "")
+		}
+	} else {
+		_ = d.Set("policy", output.Policy)
+	}
 
 	return nil
 }
@@ -225,6 +252,25 @@ func deleteMinioServiceAccount(ctx context.Context, serviceAccountConfig *S3Mini
 	return
 }
 
+// minioServiceAccountPolicy returns the policy of the service account, which is the document of the canned policy
+// named by policy_name when set
+func minioServiceAccountPolicy(ctx context.Context, serviceAccountConfig *S3MinioServiceAccountConfig) ([]byte, error) {
+	policyName := serviceAccountConfig.MinioSAPolicyName
+	if policyName == "" {
+		return processServiceAccountPolicy(serviceAccountConfig.MinioSAPolicy), nil
+	}
+
+	policy, err := serviceAccountConfig.MinioAdmin.InfoCannedPolicy(ctx, policyName)
+	if err != nil {
+		if isNotFoundError(err) {
+			return nil, fmt.Errorf("policy %q does not exist", policyName)
+		}
+		return nil, err
+	}
+
+	return policy, nil
+}
+
 func processServiceAccountPolicy(policy string) []byte {
 	if len(policy) == 0 {
 		emptyPolicy := "{\n\"Version\": \"\",\n\"Statement\": null\n}"
@@ -5,9 +5,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"regexp"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 	"github.com/minio/madmin-go/v3"
@@ -184,6 +186,35 @@ resource "minio_iam_service_account" "test3" {
 }
 `, rName)
 }
+func TestServiceAccount_PolicyName(t *testing.T) {
+	var serviceAccount madmin.InfoServiceAccountResp
+
+	policyName := acctest.RandomWithPrefix("tf-acc-sa-policy")
+	resourceName := "minio_iam_service_account.test_policy_name"
+	policy := "{\"Version\":\"2012-10-17\",\"Statement\":[{\"Effect\":\"Allow\",\"Action\":[\"s3:ListAllMyBuckets\"],\"Resource\":[\"arn:aws:s3:::*\"]}]}"
+
+	resource.ParallelTest(t, resource.TestCase{
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckMinioServiceAccountDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccMinioServiceAccountConfigPolicyName("minio", policyName, policyName+"-missing"),
+				ExpectError: regexp.MustCompile(fmt.Sprintf("policy %q does not exist", policyName+"-missing")),
+			},
+			{
+				Config: testAccMinioServiceAccountConfigPolicyName("minio", policyName, policyName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMinioServiceAccountExists(resourceName, &serviceAccount),
+					resource.TestCheckResourceAttr(resourceName, "policy_name", policyName),
+					testAccCheckMinioServiceAccountCanLogIn(resourceName),
+					testAccCheckMinioServiceAccountSessionPolicy(resourceName, policy),
+				),
+			},
+		},
+	})
+}
+
 func testAccMinioServiceAccountConfigPolicy(rName string) string {
 	return fmt.Sprintf(`
 resource "minio_iam_service_account" "test4" {
@@ -237,6 +268,22 @@ resource "minio_iam_service_account" "test_service_account" {
 `, rName)
 }
 
+func testAccMinioServiceAccountConfigPolicyName(rName string, policyName string, servicePolicyName string) string {
+	return fmt.Sprintf(`
+resource "minio_iam_policy" "test_policy_name" {
+  name   = %q
+  policy = "{\"Version\":\"2012-10-17\",\"Statement\":[{\"Action\":[\"s3:ListAllMyBuckets\"],\"Effect\":\"Allow\",\"Resource\":[\"arn:aws:s3:::*\"]}]}"
+}
+
+resource "minio_iam_service_account" "test_policy_name" {
+  target_user = %q
+  policy_name = %q
+
+  depends_on = [minio_iam_policy.test_policy_name]
+}
+`, policyName, rName, servicePolicyName)
+}
+
 func testAccCheckMinioServiceAccountExists(n string, res *madmin.InfoServiceAccountResp) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[n]
@@ -380,3 +427,28 @@ func testAccCheckMinioServiceAccountPolicy(n string, expectedPolicy string) reso
 		return nil
 	}
 }
+
+func testAccCheckMinioServiceAccountSessionPolicy(n string, expectedPolicy string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("not found: %s", n)
+		}
+
+		minioIam := testAccProvider.Meta().(*S3MinioClient).S3Admin
+		output, err := minioIam.InfoServiceAccount(context.Background(), rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		var actual, expected interface{}
+		_ = json.Unmarshal([]byte(expectedPolicy), &expected)
+		_ = json.Unmarshal([]byte(output.Policy), &actual)
+		diff := cmp.Diff(actual, expected)
+		if diff != "" {
+			return fmt.Errorf("%s: mismatch (-want +got):\n%s", n, diff)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,101 @@
+package minio
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/set"
+)
+
+const (
+	// retryInitialInterval is the delay before the first retry, doubled on each attempt up to retryMaxInterval
+	retryInitialInterval = 500 * time.Millisecond
+	retryMaxInterval     = 8 * time.Second
+	// retryJitterFraction is the part of the interval randomly added to each wait, so that resources throttled
+	// together don't retry together
+	retryJitterFraction = 0.25
+	// retryTimeout bounds the time spent retrying a single call
+	retryTimeout = 1 * time.Minute
+)
+
+// retryOnError calls f, which calls the S3 client, until it succeeds, returns an error that classifyError doesn't
+// consider retryable or that the client already retried, or timeout elapses, waiting with an exponential backoff and
+// jitter between attempts. The last error is returned.
+func retryOnError(ctx context.Context, timeout time.Duration, f func() error) error {
+	deadline := time.Now().Add(timeout)
+	interval := retryInitialInterval
+
+	for attempt := 1; ; attempt++ {
+		err := f()
+		if err == nil || classifyError(err) != errorClassRetryable || minioClientRetried(err) {
+			return err
+		}
+
+		wait := retryJitter(interval)
+		if time.Now().Add(wait).After(deadline) {
+			return err
+		}
+
+		log.Printf("[DEBUG] Retrying in %s after attempt %d failed with a retryable error: %s", wait, attempt, err)
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(wait):
+		}
+
+		interval *= 2
+		if interval > retryMaxInterval {
+			interval = retryMaxInterval
+		}
+	}
+}
+
+// retryJitter returns the interval lengthened by a random part of up to retryJitterFraction of it
+func retryJitter(interval time.Duration) time.Duration {
+	return interval + time.Duration(rand.Int63n(int64(float64(interval)*retryJitterFraction)+1))
+}
+
+// minioClientRetriedStatusCodes and minioClientRetriedErrorCodes are the HTTP statuses and S3 error codes of the
+// answers minio-go retries
+var (
+	minioClientRetriedStatusCodes = map[int]bool{
+		http.StatusTooManyRequests:     true,
+		499:                            true,
+		http.StatusInternalServerError: true,
+		http.StatusBadGateway:          true,
+		http.StatusServiceUnavailable:  true,
+		http.StatusGatewayTimeout:      true,
+	}
+	minioClientRetriedErrorCodes = set.CreateStringSet(
+		"ExpiredToken",
+		"ExpiredTokenException",
+		"InternalError",
+		"RequestError",
+		"RequestLimitExceeded",
+		"RequestThrottled",
+		"RequestTimeout",
+		"SlowDown",
+		"Throttling",
+		"ThrottlingException",
+	)
+)
+
+// minioClientRetried reports whether minio-go already retried the request that failed with err, up to
+// minio.MaxRetry times: it retries the requests that could not be sent and the answers with a retryable status or
+// code, but not the failures to read an answer
+func minioClientRetried(err error) bool {
+	var minioErr minio.ErrorResponse
+	if errors.As(err, &minioErr) {
+		return minioClientRetriedStatusCodes[minioErr.StatusCode] || minioClientRetriedErrorCodes.Contains(minioErr.Code)
+	}
+
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
+}
@@ -0,0 +1,111 @@
+package minio
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/url"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+)
+
+func TestRetryOnError(t *testing.T) {
+	// failures to read an answer are not retried by minio-go
+	retryable := io.ErrUnexpectedEOF
+	terminal := minio.ErrorResponse{Code: "AccessDenied", StatusCode: 403}
+
+	t.Run("succeeds after retryable errors", func(t *testing.T) {
+		calls := 0
+		err := retryOnError(context.Background(), time.Minute, func() error {
+			calls++
+			if calls < 3 {
+				return retryable
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if calls != 3 {
+			t.Fatalf("expected 3 calls, got %d", calls)
+		}
+	})
+
+	t.Run("stops on terminal errors", func(t *testing.T) {
+		calls := 0
+		err := retryOnError(context.Background(), time.Minute, func() error {
+			calls++
+			return terminal
+		})
+		if !errors.Is(err, terminal) {
+			t.Fatalf("expected the terminal error, got %v", err)
+		}
+		if calls != 1 {
+			t.Fatalf("expected a single call, got %d", calls)
+		}
+	})
+
+	t.Run("gives up after the timeout", func(t *testing.T) {
+		calls := 0
+		err := retryOnError(context.Background(), time.Second, func() error {
+			calls++
+			return retryable
+		})
+		if !errors.Is(err, retryable) {
+			t.Fatalf("expected the last error, got %v", err)
+		}
+		if calls != 2 {
+			t.Fatalf("expected 2 calls within the timeout, got %d", calls)
+		}
+	})
+
+	t.Run("leaves the errors minio-go retried", func(t *testing.T) {
+		for _, retried := range []error{
+			minio.ErrorResponse{Code: "SlowDown", StatusCode: 503},
+			minio.ErrorResponse{Code: "RequestTimeout", StatusCode: 400},
+			&url.Error{Op: "Get", URL: "http://minio:9000/bucket", Err: syscall.ECONNREFUSED},
+		} {
+			calls := 0
+			err := retryOnError(context.Background(), time.Minute, func() error {
+				calls++
+				return retried
+			})
+			if !errors.Is(err, retried) {
+				t.Fatalf("expected the error, got %v", err)
+			}
+			if calls != 1 {
+				t.Fatalf("%v: expected a single call, got %d", retried, calls)
+			}
+		}
+	})
+
+	t.Run("stops when the context is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		calls := 0
+		err := retryOnError(ctx, time.Minute, func() error {
+			calls++
+			cancel()
+			return retryable
+		})
+		if !errors.Is(err, retryable) {
+			t.Fatalf("expected the last error, got %v", err)
+		}
+		if calls != 1 {
+			t.Fatalf("expected a single call, got %d", calls)
+		}
+	})
+}
+
+func TestRetryJitter(t *testing.T) {
+	for _, interval := range []time.Duration{retryInitialInterval, retryMaxInterval} {
+		maxWait := interval + time.Duration(float64(interval)*retryJitterFraction)
+		for i := 0; i < 100; i++ {
+			if wait := retryJitter(interval); wait < interval || wait > maxWait {
+				t.Fatalf("expected a wait between %s and %s, got %s", interval, maxWait, wait)
+			}
+		}
+	}
+}
@@ -25,6 +25,32 @@ func tagsSchema() *schema.Schema {
 	}
 }
 
+// mergeDefaultTags adds the default tags of the provider to the tags of a resource, which take precedence
+func mergeDefaultTags(defaultTags map[string]string, tags map[string]interface{}) map[string]string {
+	merged := make(map[string]string, len(defaultTags)+len(tags))
+	for key, value := range defaultTags {
+		merged[key] = value
+	}
+	for key, value := range tags {
+		merged[key] = value.(string)
+	}
+	return merged
+}
+
+// withoutDefaultTags removes from tags read on the server the default tags of the provider, unless the resource sets them
+func withoutDefaultTags(defaultTags map[string]string, tags map[string]string, configured map[string]interface{}) map[string]string {
+	own := make(map[string]string, len(tags))
+	for key, value := range tags {
+		if _, ok := configured[key]; !ok {
+			if defaultValue, ok := defaultTags[key]; ok && defaultValue == value {
+				continue
+			}
+		}
+		own[key] = value
+	}
+	return own
+}
+
 // generateSecretAccessKey - generate random base64 numeric value from a random seed.
 func generateSecretAccessKey() (string, error) {
 	rb := make([]byte, minioSecretIDLength)
@@ -55,6 +81,15 @@ func getStringList(listString []interface{}) []*string {
 	return arrayString
 }
 
+// getStringMap get map of strings
+func getStringMap(mapString map[string]interface{}) map[string]string {
+	stringMap := make(map[string]string, len(mapString))
+	for k, v := range mapString {
+		stringMap[k] = v.(string)
+	}
+	return stringMap
+}
+
 // Contains check that an array has the given element
 func Contains(slice []string, item string) bool {
 	set := make(map[string]struct{}, len(slice))